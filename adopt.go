@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// --- ADOPTING EXISTING CONTAINERS ---
+
+// imageRepository strips the tag, digest and default registry from an image
+// reference, so "docker.io/library/postgres:14" and "postgres:16" compare equal.
+func imageRepository(image string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+	image = strings.TrimPrefix(image, "docker.io/")
+	return strings.TrimPrefix(image, "library/")
+}
+
+// validateAdoption checks that ctr runs the service's image and publishes its
+// port, so adopting it won't hand out a wrong connection string.
+func validateAdoption(svc ServiceConfig, ctr containerInfo) error {
+	spec, err := getServiceSpec(svc)
+	if err != nil {
+		return err
+	}
+	if imageRepository(ctr.Image) != imageRepository(spec.Image) {
+		return fmt.Errorf("%s runs %s, not %s", ctr.Name, ctr.Image, imageRepository(spec.Image))
+	}
+	if hostPort := ctr.Ports[spec.ContainerPort]; hostPort != svc.Port {
+		return fmt.Errorf("%s publishes port %d on %d, not %d", ctr.Name, spec.ContainerPort, hostPort, svc.Port)
+	}
+	return nil
+}
+
+// findAdoptionCandidates lists containers Plate doesn't manage that run the
+// service's image and publish its port.
+func findAdoptionCandidates(svc ServiceConfig) ([]containerInfo, error) {
+	st, err := loadState()
+	if err != nil {
+		return nil, err
+	}
+	adopted := map[string]bool{}
+	for _, id := range st.Adopted {
+		adopted[id] = true
+	}
+
+	ids, err := backend.containerIDs(true)
+	if err != nil {
+		return nil, err
+	}
+	infos, err := inspectContainers(ids...)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []containerInfo
+	for _, info := range infos {
+		if info.managed() || adopted[info.ID] {
+			continue
+		}
+		if validateAdoption(svc, info) == nil {
+			candidates = append(candidates, info)
+		}
+	}
+	return candidates, nil
+}
+
+// adoptContainer records containerID as the container backing svc.
+func adoptContainer(svc ServiceConfig, containerID string) error {
+	return updateState(func(st *plateState) {
+		if st.Adopted == nil {
+			st.Adopted = map[string]string{}
+		}
+		st.Adopted[svc.Name] = containerID
+	})
+}
@@ -0,0 +1,58 @@
+package main
+
+import "testing"
+
+func TestValidateAdoption(t *testing.T) {
+	svc := ServiceConfig{Type: "postgres", Name: "main-db", Version: "14-alpine", Port: 5433}
+	testCases := []struct {
+		name    string
+		ctr     containerInfo
+		wantErr bool
+	}{
+		{"same image", containerInfo{Name: "pg", Image: "postgres:14-alpine", Ports: map[int]int{5432: 5433}}, false},
+		{"other tag", containerInfo{Name: "pg", Image: "docker.io/library/postgres:16", Ports: map[int]int{5432: 5433}}, false},
+		{"wrong image", containerInfo{Name: "my", Image: "mysql:8", Ports: map[int]int{5432: 5433}}, true},
+		{"wrong port", containerInfo{Name: "pg", Image: "postgres:14-alpine", Ports: map[int]int{5432: 5432}}, true},
+		{"unpublished", containerInfo{Name: "pg", Image: "postgres:14-alpine"}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateAdoption(svc, tc.ctr)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Expected error: %v, got '%v'", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestCheckContainerFindsCandidatesOnlyWhenAsked(t *testing.T) {
+	t.Chdir(t.TempDir())
+	fake := useFakeRuntime(t)
+	svc := ServiceConfig{Type: "redis", Name: "cache", Version: "7", Port: 6380}
+	cfg := PlateConfig{Project: "shop", Services: []ServiceConfig{svc}}
+	fake.containers = []*containerInfo{{ID: "abc", Name: "my-redis", Image: "redis:6", State: "exited", Ports: map[int]int{6379: 6380}}}
+
+	if msg := checkContainerCmd("cache", cfg, svc, true)().(containerStatusMsg); msg.conflict != conflictCandidate {
+		t.Errorf("Expected my-redis to be offered for adoption at boot, got %+v", msg)
+	}
+	if msg := checkContainerCmd("cache", cfg, svc, false)().(containerStatusMsg); msg.status != "not_found" {
+		t.Errorf("Expected later checks to skip the scan, got %+v", msg)
+	}
+}
+
+func TestCheckContainerReportsAdoptedExit(t *testing.T) {
+	t.Chdir(t.TempDir())
+	fake := useFakeRuntime(t)
+	svc := ServiceConfig{Type: "redis", Name: "cache", Version: "7", Port: 6380}
+	cfg := PlateConfig{Project: "shop", Services: []ServiceConfig{svc}}
+	fake.containers = []*containerInfo{{ID: "abc", Name: "my-redis", Image: "redis:7", State: "exited", ExitCode: oomExitCode, OOMKilled: true}}
+	if err := adoptContainer(svc, "abc"); err != nil {
+		t.Fatal(err)
+	}
+
+	msg := checkContainerCmd("cache", cfg, svc, false)().(containerStatusMsg)
+	if msg.containerID != "abc" || msg.exit != (containerExit{code: oomExitCode, oomKilled: true}) {
+		t.Errorf("Expected the adopted container's OOM exit, got %+v", msg)
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// --- ANNOUNCEMENTS ---
+
+// announcementState records when the announcement was last shown, so it
+// is shown at most once a day.
+type announcementState struct {
+	Text string `json:"text"`
+	Date string `json:"date"` // YYYY-MM-DD, local time
+}
+
+var announcementStyle = lipgloss.NewStyle().
+	Border(lipgloss.RoundedBorder()).
+	BorderForeground(lipgloss.Color("208")).
+	Padding(0, 1).
+	MarginBottom(1)
+
+// announcementDue reports whether text should be shown at now, given when
+// it was last shown. A changed announcement is shown right away.
+func announcementDue(text string, seen *announcementState, now time.Time) bool {
+	if text == "" {
+		return false
+	}
+	return seen == nil || seen.Text != text || seen.Date != now.Format(time.DateOnly)
+}
+
+// takeAnnouncement returns the config's announcement if it is due, and
+// records it as shown today. It returns "" if the state can't be read or
+// written, rather than showing the banner on every start.
+func takeAnnouncement(cfg PlateConfig, now time.Time) string {
+	st, err := loadState()
+	if err != nil || !announcementDue(cfg.Announcement, st.Announcement, now) {
+		return ""
+	}
+	err = updateState(func(st *plateState) {
+		st.Announcement = &announcementState{Text: cfg.Announcement, Date: now.Format(time.DateOnly)}
+	})
+	if err != nil {
+		return ""
+	}
+	return cfg.Announcement
+}
+
+// renderAnnouncement renders the announcement banner.
+func (m model) renderAnnouncement(width int) string {
+	return announcementStyle.Width(width).Render(confirmStyle.Render("📣 "+m.announcement) + "\n" + helpStyle.Render("x: dismiss"))
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTakeAnnouncement(t *testing.T) {
+	t.Chdir(t.TempDir())
+	cfg := PlateConfig{Announcement: "postgres bumped to 16, run plate apply"}
+	morning := time.Date(2025, 3, 3, 9, 0, 0, 0, time.Local)
+
+	if got := takeAnnouncement(cfg, morning); got != cfg.Announcement {
+		t.Errorf("Expected the announcement on the first start, got %q", got)
+	}
+	if got := takeAnnouncement(cfg, morning.Add(3*time.Hour)); got != "" {
+		t.Errorf("Expected no second banner the same day, got %q", got)
+	}
+	if got := takeAnnouncement(cfg, morning.Add(24*time.Hour)); got != cfg.Announcement {
+		t.Errorf("Expected the announcement again the next day, got %q", got)
+	}
+	cfg.Announcement = "redis bumped to 8"
+	if got := takeAnnouncement(cfg, morning.Add(25*time.Hour)); got != cfg.Announcement {
+		t.Errorf("Expected a new announcement right away, got %q", got)
+	}
+	if got := takeAnnouncement(PlateConfig{}, morning); got != "" {
+		t.Errorf("Expected no banner without an announcement, got %q", got)
+	}
+}
+
+func TestMergeConfigAnnouncement(t *testing.T) {
+	base := PlateConfig{Announcement: "postgres bumped to 16"}
+	if merged := mergeConfig(base, PlateConfig{}); merged.Announcement != base.Announcement {
+		t.Errorf("Expected the base's announcement to be inherited, got %q", merged.Announcement)
+	}
+	if merged := mergeConfig(base, PlateConfig{Announcement: "local note"}); merged.Announcement != "local note" {
+		t.Errorf("Expected the override's announcement, got %q", merged.Announcement)
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// --- DECLARATIVE APPLY ---
+
+// applyChanges converges the containers towards the config described by
+// changes. Orphaned containers are only removed when prune is set. It
+// returns the errors of the services that could not be converged.
+func applyChanges(changes []serviceChange, prune bool, out io.Writer) []error {
+	var failures []error
+	report := func(label, action string, err error) {
+		if err != nil {
+			failures = append(failures, err)
+			reportFailure(out, label, err)
+			return
+		}
+		fmt.Fprintf(out, "%s %s: %s\n", successStyle.Render("✓"), label, action)
+	}
+
+	for _, c := range changes {
+		switch c.kind {
+		case changeCreate:
+			err := createService(c.service)
+			recordAction("create", c.label(), containerName(c.service), "plate apply", err)
+			report(c.label(), "created", err)
+		case changeRecreate:
+			err := removeContainer(c.container.ID)
+			if err == nil {
+				err = createService(c.service)
+			}
+			recordAction("recreate", c.label(), c.container.Name, "plate apply", err)
+			report(c.label(), "recreated", err)
+		case changeRename:
+			err := relinkContainer(c.service, *c.container)
+			recordAction("link", c.label(), c.container.Name, "plate apply", err)
+			report(c.label(), "linked to the container of "+c.container.Labels[labelService], err)
+		case changeRemove:
+			if !prune {
+				fmt.Fprintf(out, "%s %s: orphaned, skipped (use --prune to remove)\n", stoppedStyle.Render("-"), c.label())
+				continue
+			}
+			err := removeContainer(c.container.ID)
+			recordAction("delete", c.label(), c.container.Name, "plate apply --prune", err)
+			report(c.label(), "removed", err)
+		case changeConflict:
+			report(c.label(), "", fmt.Errorf("container name is taken by %s, which Plate doesn't manage", c.container.Name))
+		case changeNone:
+			if c.container.State == "running" {
+				continue
+			}
+			err := backend.start(c.container.ID)
+			recordAction("start", c.label(), c.container.Name, "plate apply", err)
+			report(c.label(), "started", err)
+		}
+	}
+	return failures
+}
+
+// createService pulls or builds the service's image if needed, runs a new
+// container, and creates its databases and extensions.
+func createService(config ServiceConfig) error {
+	if !hasImage(config) {
+		if err := obtainImage(config, io.Discard); err != nil {
+			return err
+		}
+	}
+	containerID, _, err := runServiceContainer(config)
+	if err != nil {
+		return err
+	}
+	return prepareService(config, containerID, defaultIntervals.health)
+}
+
+// removeContainer force-removes a container.
+func removeContainer(containerID string) error {
+	if err := backend.remove(containerID, true); err != nil {
+		return fmt.Errorf("could not remove container: %w", err)
+	}
+	return nil
+}
+
+// stopServices stops the running containers of every service in the config,
+// except those pinned is true for. It returns the number of containers that
+// could not be stopped.
+func stopServices(cfg PlateConfig, containers []containerInfo, pinned func(ServiceConfig) bool, out io.Writer) int {
+	matched := serviceContainers(cfg, containers)
+
+	failures := 0
+	for _, svc := range cfg.Services {
+		ctr, ok := matched[svc.Name]
+		if !ok || !ctr.managed() || ctr.State != "running" {
+			continue
+		}
+		if pinned(svc) {
+			fmt.Fprintf(out, "%s %s: pinned, left running\n", pendingStyle.Render("📌"), svc.Name)
+			continue
+		}
+		err := backend.stop(ctr.ID)
+		recordAction("stop", svc.Name, ctr.Name, "plate down", err)
+		if err != nil {
+			failures++
+			reportFailure(out, svc.Name, err)
+			continue
+		}
+		fmt.Fprintf(out, "%s %s: stopped\n", successStyle.Render("✓"), svc.Name)
+	}
+	return failures
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// applyFixture is a project with one service of each kind of change: cache
+// is missing, main-db runs an old image, and legacy is no longer in the
+// config.
+func applyFixture(t *testing.T) (*fakeRuntime, []serviceChange) {
+	t.Helper()
+	t.Chdir(t.TempDir())
+	fake := useFakeRuntime(t)
+	cfg := PlateConfig{
+		Project: "shop",
+		Services: []ServiceConfig{
+			{Type: "redis", Name: "cache", Version: "7", Port: 6380},
+			{Type: "postgres", Name: "main-db", Version: "16", Port: 5433},
+		},
+	}
+	labels := func() map[string]string {
+		return map[string]string{labelManaged: "true", labelProject: "shop"}
+	}
+	fake.containers = []*containerInfo{
+		{ID: fmt.Sprintf("%064d", 91), Name: "plate-postgres-main-db", Image: "postgres:14-alpine", State: "running", Labels: labels()},
+		{ID: fmt.Sprintf("%064d", 92), Name: "plate-mysql-legacy", Image: "mysql:8", State: "exited", Labels: labels()},
+	}
+	containers, _ := fake.inspect(fmt.Sprintf("%064d", 91), fmt.Sprintf("%064d", 92))
+	return fake, computeDiff(cfg, containers)
+}
+
+func TestApplyChanges(t *testing.T) {
+	fake, changes := applyFixture(t)
+	var out bytes.Buffer
+	if errs := applyChanges(changes, true, &out); len(errs) > 0 {
+		t.Fatalf("Expected every change to apply, got %v", errs)
+	}
+
+	for _, op := range []string{
+		"pull redis:7", "run plate-redis-cache", // created
+		"rm plate-postgres-main-db", "run plate-postgres-main-db", // recreated on drift
+		"rm plate-mysql-legacy", // pruned
+	} {
+		if !fake.called(op) {
+			t.Errorf("Expected %q, got %v", op, fake.calls)
+		}
+	}
+	if infos, _ := fake.inspect("plate-postgres-main-db"); len(infos) != 1 || infos[0].ID == fmt.Sprintf("%064d", 91) {
+		t.Errorf("Expected main-db to get a new container, got %+v", infos)
+	}
+	for _, want := range []string{"cache: created", "main-db: recreated", "plate-mysql-legacy: removed"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected the output to report %q, got:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestApplyKeepsOrphansWithoutPrune(t *testing.T) {
+	fake, changes := applyFixture(t)
+	var out bytes.Buffer
+	applyChanges(changes, false, &out)
+	if fake.called("rm plate-mysql-legacy") {
+		t.Errorf("Expected the orphan to be kept, got %v", fake.calls)
+	}
+	if !strings.Contains(out.String(), "plate-mysql-legacy: orphaned, skipped") {
+		t.Errorf("Expected the orphan to be reported as skipped, got:\n%s", out.String())
+	}
+}
+
+func TestApplyPartialFailure(t *testing.T) {
+	fake, changes := applyFixture(t)
+	fake.fail["run plate-redis-cache"] = errors.New("port is already allocated")
+	var out bytes.Buffer
+	errs := applyChanges(changes, true, &out)
+
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "port is already allocated") {
+		t.Fatalf("Expected only cache to fail, got %v", errs)
+	}
+	if code := failuresExitCode(errs); code != exitPartial {
+		t.Errorf("Expected exit code %d, got %d", exitPartial, code)
+	}
+	// The other services are still converged.
+	if !fake.called("run plate-postgres-main-db") || !fake.called("rm plate-mysql-legacy") {
+		t.Errorf("Expected the other changes to be applied, got %v", fake.calls)
+	}
+}
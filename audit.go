@@ -0,0 +1,204 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// --- AUDIT TRAIL ---
+
+// auditFileName is the file inside stateDirName that every action changing
+// containers is appended to, one JSON object per line.
+const auditFileName = "audit.log"
+
+// historyLength is how many audit entries the history view shows.
+const historyLength = 20
+
+// auditEntry is one line of the audit log.
+type auditEntry struct {
+	Time      time.Time `json:"time"`
+	User      string    `json:"user"`
+	Host      string    `json:"host,omitempty"`
+	Action    string    `json:"action"` // e.g. "create", "stop", "delete"
+	Service   string    `json:"service,omitempty"`
+	Container string    `json:"container,omitempty"`
+	Command   string    `json:"command,omitempty"` // what was run, when there is one
+	Result    string    `json:"result"`            // "ok", or the error
+}
+
+// auditMu keeps concurrent commands from interleaving their lines.
+var auditMu sync.Mutex
+
+// auditUser returns who is driving Plate. Under sudo, that's the user who
+// ran sudo rather than root.
+func auditUser() string {
+	if u := os.Getenv("SUDO_USER"); u != "" {
+		return u
+	}
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
+
+// recordAction appends an action and its outcome to the audit log. A log
+// that can't be written never fails the action itself.
+func recordAction(action, service, container, command string, err error) {
+	host, _ := os.Hostname()
+	e := auditEntry{
+		Time:      time.Now(),
+		User:      auditUser(),
+		Host:      host,
+		Action:    action,
+		Service:   service,
+		Container: container,
+		Command:   command,
+		Result:    "ok",
+	}
+	if err != nil {
+		e.Result = err.Error()
+	}
+	appendAudit(e)
+}
+
+func appendAudit(e auditEntry) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	auditMu.Lock()
+	defer auditMu.Unlock()
+	if err := os.MkdirAll(stateDir, 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(filepath.Join(stateDir, auditFileName), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+// readAudit returns the last n entries of the audit log, oldest first.
+// Lines that don't parse are skipped. A missing log has no entries.
+func readAudit(n int) ([]auditEntry, error) {
+	f, err := os.Open(filepath.Join(stateDir, auditFileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []auditEntry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e auditEntry
+		if json.Unmarshal(scanner.Bytes(), &e) != nil {
+			continue
+		}
+		entries = append(entries, e)
+		if len(entries) > n {
+			entries = entries[1:]
+		}
+	}
+	return entries, scanner.Err()
+}
+
+// dockerCommandLine renders a docker invocation for the audit log.
+func dockerCommandLine(args ...string) string {
+	words := []string{"docker"}
+	for _, arg := range args {
+		words = append(words, shellWord(arg))
+	}
+	return strings.Join(words, " ")
+}
+
+// --- HISTORY VIEW ---
+
+type historyLoadedMsg struct {
+	entries []auditEntry
+	err     error
+}
+
+// historyView is the state of the history view.
+type historyView struct {
+	entries []auditEntry
+	err     error
+	loaded  bool
+}
+
+func loadHistoryCmd() tea.Cmd {
+	return func() tea.Msg {
+		entries, err := readAudit(historyLength)
+		return historyLoadedMsg{entries: entries, err: err}
+	}
+}
+
+func (m model) updateHistoryView(key tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch key.String() {
+	case "H", "q", "esc":
+		m.showingHistory = false
+	case "r":
+		return m, loadHistoryCmd()
+	}
+	return m, nil
+}
+
+func (m model) renderHistoryView() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("History"))
+	b.WriteString("\n\n")
+	switch {
+	case !m.history.loaded:
+		b.WriteString(fmt.Sprintf("%s Loading %s...\n", m.spinner.View(), filepath.Join(stateDir, auditFileName)))
+	case m.history.err != nil:
+		b.WriteString(errorStyle.Render(fmt.Sprintf("Could not read %s: %v", filepath.Join(stateDir, auditFileName), m.history.err)) + "\n")
+	case len(m.history.entries) == 0:
+		b.WriteString(stoppedStyle.Render("Nothing has been changed yet.") + "\n")
+	default:
+		b.WriteString(renderHistory(m.history.entries))
+	}
+	b.WriteString("\n" + helpStyle.Render("r: refresh • H/esc: close"))
+	return b.String()
+}
+
+// renderHistory lists audit entries newest first.
+func renderHistory(entries []auditEntry) string {
+	var b strings.Builder
+	now := time.Now()
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		who := e.User
+		if e.Host != "" {
+			who += "@" + e.Host
+		}
+		target := e.Service
+		if e.Container != "" && e.Container != e.Service {
+			target += " (" + e.Container + ")"
+		}
+		result := successStyle.Render("✓")
+		if e.Result != "ok" {
+			result = errorStyle.Render("✗ " + lastLine(e.Result))
+		}
+		b.WriteString(fmt.Sprintf("%s  %s  %s %s %s\n", detailValStyle.Render(timeDisplay.stamp(e.Time, now)), detailAttrStyle.Render(who), e.Action, target, result))
+		if e.Command != "" {
+			b.WriteString("    " + helpStyle.Render(e.Command) + "\n")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAuditLog(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	if entries, err := readAudit(historyLength); err != nil || len(entries) != 0 {
+		t.Fatalf("Expected an empty history without a log, got %v, %v", entries, err)
+	}
+
+	recordAction("stop", "main-db", "plate-postgres-main-db", dockerCommandLine("stop", "abc"), nil)
+	recordAction("delete", "cache", "plate-redis-cache", dockerCommandLine("rm", "def"), errors.New("no such container"))
+	f, _ := os.OpenFile(filepath.Join(stateDirName, auditFileName), os.O_APPEND|os.O_WRONLY, 0644)
+	f.WriteString("not json\n")
+	f.Close()
+	recordAction("pin", "main-db", "", "", nil)
+
+	entries, err := readAudit(2)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(entries) != 2 || entries[0].Action != "delete" || entries[1].Action != "pin" {
+		t.Fatalf("Expected the last two entries, got %+v", entries)
+	}
+	if entries[0].Result != "no such container" || entries[0].Command != "docker rm def" || entries[0].User == "" {
+		t.Errorf("Expected who, what and the error to be recorded, got %+v", entries[0])
+	}
+
+	out := renderHistory(entries)
+	if strings.Index(out, "pin main-db") > strings.Index(out, "delete cache (plate-redis-cache)") {
+		t.Errorf("Expected the newest entry first, got %q", out)
+	}
+	if !strings.Contains(out, "no such container") {
+		t.Errorf("Expected the failure in the history, got %q", out)
+	}
+}
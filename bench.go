@@ -0,0 +1,218 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// --- STARTUP BENCHMARK ---
+
+const (
+	// benchNaming keeps benchmark containers apart from the real ones.
+	benchNaming = "plate-bench-{type}-{name}"
+	// benchPoll is how often health commands are retried while benchmarking.
+	benchPoll = 100 * time.Millisecond
+	// benchEnvironment labels the time until every service was ready.
+	benchEnvironment = "(environment)"
+)
+
+// benchServices returns copies of the config's container services that run
+// under benchmark container names on free host ports, so a benchmark doesn't
+// touch the real containers or their data.
+func benchServices(cfg PlateConfig, inUse func(port int) bool) []ServiceConfig {
+	return throwawayServices(cfg, benchNaming, 0, inUse)
+}
+
+// throwawayServices returns copies of the config's container services named
+// by naming, on free host ports from firstPort up, or from each service's own
+// port when firstPort is 0.
+func throwawayServices(cfg PlateConfig, naming string, firstPort int, inUse func(port int) bool) []ServiceConfig {
+	claimed := map[int]bool{}
+	taken := func(port int) bool { return claimed[port] || inUse(port) }
+	var services []ServiceConfig
+	for _, svc := range cfg.containerServices() {
+		svc.naming = naming
+		from := svc.Port
+		if firstPort > 0 {
+			from = firstPort
+		}
+		svc.Port = nextFreePort(from, func(port int) bool {
+			// Observability services also publish OTLP/gRPC one port below.
+			return taken(port) || (svc.Type == "observability" && taken(port-1))
+		})
+		claimed[svc.Port] = true
+		if svc.Type == "observability" {
+			claimed[svc.Port-1] = true
+		}
+		if spec, _ := getServiceSpec(svc); spec.UIPort != 0 && spec.UIPort != spec.ContainerPort {
+			from := spec.ExtraPorts[spec.UIPort]
+			if firstPort > 0 {
+				from = firstPort
+			}
+			svc.UIPort = nextFreePort(from, taken)
+			claimed[svc.UIPort] = true
+		}
+		services = append(services, svc)
+	}
+	return services
+}
+
+// benchRun brings the services up at once, within the same concurrency
+// limits as the TUI, and returns each one's time until it accepted
+// connections. Services that failed are missing from the result and
+// reported in errs.
+func benchRun(services []ServiceConfig, cold bool, slots dockerSemaphores) (map[string]time.Duration, map[string]error) {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	times := map[string]time.Duration{}
+	errs := map[string]error{}
+	for _, svc := range services {
+		if cold {
+			dockerCommand("rmi", imageName(svc)).Run()
+		}
+		wg.Add(1)
+		go func(svc ServiceConfig) {
+			defer wg.Done()
+			start := time.Now()
+			var err error
+			if !hasImage(svc) {
+				slots.pulls.run(func() { err = obtainImage(svc, io.Discard) })
+			}
+			if err == nil {
+				slots.starts.run(func() { err = createService(svc) })
+			}
+			if err == nil {
+				var infos []containerInfo
+				if infos, err = inspectContainers(containerName(svc)); err == nil && len(infos) == 1 {
+					err = waitForService(svc, infos[0].ID, benchPoll)
+				}
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[svc.Name] = err
+				return
+			}
+			times[svc.Name] = time.Since(start)
+		}(svc)
+	}
+	wg.Wait()
+	return times, errs
+}
+
+// benchCleanup removes the benchmark containers and their volumes.
+func benchCleanup(services []ServiceConfig) {
+	for _, svc := range services {
+		dockerCommand("rm", "-f", "-v", containerName(svc)).Run()
+	}
+}
+
+// percentile returns the nearest-rank percentile of durations.
+func percentile(durations []time.Duration, p float64) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank, 1)-1]
+}
+
+// renderBench prints p50/p95 time-to-ready per service, in config order.
+func renderBench(names []string, samples map[string][]time.Duration, failures map[string]int, out io.Writer) {
+	round := func(d time.Duration) string { return d.Round(10 * time.Millisecond).String() }
+	fmt.Fprintln(out, detailAttrStyle.Render(fmt.Sprintf("%-20s %6s %10s %10s %10s %10s", "SERVICE", "RUNS", "P50", "P95", "MIN", "MAX")))
+	for _, name := range append(names, benchEnvironment) {
+		d := samples[name]
+		line := fmt.Sprintf("%-20s %6d", name, len(d))
+		if len(d) > 0 {
+			line += fmt.Sprintf(" %10s %10s %10s %10s", round(percentile(d, 50)), round(percentile(d, 95)), round(percentile(d, 0)), round(percentile(d, 100)))
+		} else {
+			line += fmt.Sprintf(" %10s %10s %10s %10s", "-", "-", "-", "-")
+		}
+		if n := failures[name]; n > 0 {
+			line += " " + errorStyle.Render(fmt.Sprintf("%d failed", n))
+		}
+		fmt.Fprintln(out, line)
+	}
+}
+
+func handleBenchCmd(args []string) {
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	addConfigFlag(fs)
+	runs := fs.Int("runs", 5, "how many times to bring the environment up")
+	cold := fs.Bool("cold", false, "remove the images before every run, so pulls are measured too")
+	fs.Parse(args)
+	plateConfig := mustResolveBuilds(mustLoadConfig(configPathArg(fs)))
+	mustHaveDockerAccess(plateConfig)
+	if *runs < 1 {
+		fmt.Println("Error: --runs must be at least 1.")
+		os.Exit(exitUsage)
+	}
+
+	services := benchServices(plateConfig, func(port int) bool { return !portFree(port) })
+	if len(services) == 0 {
+		fmt.Println("No container services to benchmark.")
+		return
+	}
+	names := make([]string, len(services))
+	for i, svc := range services {
+		names[i] = svc.Name
+	}
+
+	// Don't leave benchmark containers behind when interrupted.
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	go func() {
+		<-interrupt
+		fmt.Println("\nInterrupted, removing benchmark containers...")
+		benchCleanup(services)
+		os.Exit(1)
+	}()
+
+	cache := "warm"
+	if *cold {
+		cache = "cold"
+	}
+	fmt.Printf("Benchmarking %d service(s), %d run(s), %s image cache.\n\n", len(services), *runs, cache)
+	slots := newDockerSemaphores(plateConfig.Concurrency)
+	samples := map[string][]time.Duration{}
+	failures := map[string]int{}
+	benchCleanup(services)
+	for run := 1; run <= *runs; run++ {
+		times, errs := benchRun(services, *cold, slots)
+		benchCleanup(services)
+
+		var parts []string
+		slowest := time.Duration(0)
+		for _, name := range names {
+			if err, ok := errs[name]; ok {
+				failures[name]++
+				parts = append(parts, fmt.Sprintf("%s %s", name, errorStyle.Render("failed: "+lastLine(err.Error()))))
+				continue
+			}
+			samples[name] = append(samples[name], times[name])
+			slowest = max(slowest, times[name])
+			parts = append(parts, fmt.Sprintf("%s %s", name, times[name].Round(10*time.Millisecond)))
+		}
+		if len(errs) == 0 {
+			samples[benchEnvironment] = append(samples[benchEnvironment], slowest)
+		} else {
+			failures[benchEnvironment]++
+		}
+		fmt.Printf("Run %d/%d: %s\n", run, *runs, strings.Join(parts, ", "))
+	}
+	fmt.Println()
+	renderBench(names, samples, failures, os.Stdout)
+	if len(failures) > 0 {
+		os.Exit(exitPartial)
+	}
+}
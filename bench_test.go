@@ -0,0 +1,62 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPercentile(t *testing.T) {
+	var durations []time.Duration
+	for i := 10; i >= 1; i-- {
+		durations = append(durations, time.Duration(i)*time.Second)
+	}
+	tests := []struct {
+		p        float64
+		expected time.Duration
+	}{
+		{0, time.Second},
+		{50, 5 * time.Second},
+		{95, 10 * time.Second},
+		{100, 10 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := percentile(durations, tt.p); got != tt.expected {
+			t.Errorf("p%v: Expected %s, got %s", tt.p, tt.expected, got)
+		}
+	}
+	if durations[0] != 10*time.Second {
+		t.Errorf("Expected the input to be left unsorted")
+	}
+	if got := percentile(nil, 50); got != 0 {
+		t.Errorf("Expected 0 for no samples, got %s", got)
+	}
+}
+
+func TestBenchServices(t *testing.T) {
+	cfg := PlateConfig{Services: []ServiceConfig{
+		{Type: "postgres", Name: "db", Version: "16", Port: 5433},
+		{Type: "postgres", Name: "replica", Version: "16", Port: 5433},
+		{Type: "observability", Name: "otel", Version: "latest", Port: 4318},
+		{Type: "process", Name: "web", Command: "npm start"},
+	}}
+	bound := map[int]bool{5433: true, 4317: true}
+	services := benchServices(cfg, func(port int) bool { return bound[port] })
+
+	if len(services) != 3 {
+		t.Fatalf("Expected 3 container services, got %d", len(services))
+	}
+	ports := []int{services[0].Port, services[1].Port, services[2].Port}
+	if ports[0] != 5434 || ports[1] != 5435 || ports[2] != 4319 {
+		t.Errorf("Expected ports 5434, 5435, 4319, got %v", ports)
+	}
+	if services[2].UIPort != 16686 {
+		t.Errorf("Expected the UI on 16686, got %d", services[2].UIPort)
+	}
+	if name := containerName(services[0]); !strings.HasPrefix(name, "plate-bench-") {
+		t.Errorf("Expected a benchmark container name, got %s", name)
+	}
+	if cfg.Services[0].Port != 5433 {
+		t.Errorf("Expected the config to be left untouched, got port %d", cfg.Services[0].Port)
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// --- PER-BRANCH ENVIRONMENTS ---
+
+// maxBranchSlug keeps namespaced container names readable.
+const maxBranchSlug = 30
+
+// gitBranch returns the branch checked out in dir, or the short commit
+// when HEAD is detached.
+func gitBranch(dir string) (string, error) {
+	output, err := exec.Command("git", "-C", dir, "rev-parse", "--abbrev-ref", "HEAD").Output()
+	if err != nil {
+		return "", fmt.Errorf("per-branch environments need a git repository with a commit: %s", dir)
+	}
+	branch := strings.TrimSpace(string(output))
+	if branch == "HEAD" {
+		output, err = exec.Command("git", "-C", dir, "rev-parse", "--short", "HEAD").Output()
+		if err != nil {
+			return "", err
+		}
+		branch = strings.TrimSpace(string(output))
+	}
+	return branch, nil
+}
+
+// linkedWorktree reports whether dir is in a git worktree added with `git
+// worktree add`, rather than the repository's main checkout.
+func linkedWorktree(dir string) bool {
+	output, err := exec.Command("git", "-C", dir, "rev-parse", "--git-dir", "--git-common-dir").Output()
+	if err != nil {
+		return false
+	}
+	dirs := strings.Fields(string(output))
+	return len(dirs) == 2 && filepath.Clean(dirs[0]) != filepath.Clean(dirs[1])
+}
+
+// branchSlug turns a branch name into something docker accepts in a
+// container name, e.g. "feature/Add-users" becomes "feature-add-users".
+func branchSlug(branch string) string {
+	slug := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		if r >= 'A' && r <= 'Z' {
+			return r + 'a' - 'A'
+		}
+		return '-'
+	}, branch)
+	for strings.Contains(slug, "--") {
+		slug = strings.ReplaceAll(slug, "--", "-")
+	}
+	if len(slug) > maxBranchSlug {
+		slug = slug[:maxBranchSlug]
+	}
+	return strings.Trim(slug, "-")
+}
+
+// namespaceByBranch gives every service of cfg containers of its own for
+// the branch: the project gets the slug appended, and so do container names
+// unless the naming template already includes the project.
+func namespaceByBranch(cfg *PlateConfig, slug string) {
+	cfg.Project += "-" + slug
+	naming := cfg.Naming
+	if naming == "" {
+		naming = defaultNaming
+	}
+	if !strings.Contains(naming, "{project}") {
+		naming += "-" + slug
+	}
+	cfg.Naming = naming
+	for i := range cfg.Services {
+		cfg.Services[i].project = cfg.Project
+		cfg.Services[i].naming = cfg.Naming
+	}
+}
+
+// applyPerBranch namespaces cfg by the branch checked out in dir.
+func applyPerBranch(cfg *PlateConfig, dir string) error {
+	branch, err := gitBranch(dir)
+	if err != nil {
+		return err
+	}
+	slug := branchSlug(branch)
+	if slug == "" {
+		return fmt.Errorf("branch %q has no characters usable in a container name", branch)
+	}
+	namespaceByBranch(cfg, slug)
+	return nil
+}
+
+// checkWorktree warns about a linked worktree whose containers would share
+// names with the other checkouts of the repository.
+func checkWorktree(cfg PlateConfig, dir string) doctorResult {
+	branch, err := gitBranch(dir)
+	if err != nil {
+		return doctorResult{ok: true, detail: "not a git repository"}
+	}
+	switch {
+	case cfg.PerBranch:
+		return doctorResult{ok: true, detail: fmt.Sprintf("containers are namespaced for branch %s", branch)}
+	case linkedWorktree(dir) && !strings.Contains(cfg.Naming, "{project}"):
+		return doctorResult{detail: fmt.Sprintf(`this is a linked worktree (branch %s) whose containers share names with the other checkouts; set "perBranch": true, e.g. in the local config, or run 'plate --per-branch'`, branch)}
+	}
+	return doctorResult{ok: true, detail: "branch " + branch}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestBranchSlug(t *testing.T) {
+	tests := []struct {
+		branch, want string
+	}{
+		{"main", "main"},
+		{"feature/Add-users", "feature-add-users"},
+		{"fix//double__sep", "fix-double-sep"},
+		{"-weird-", "weird"},
+		{"a-very-long-branch-name-that-keeps-going-and-going", "a-very-long-branch-name-that-k"},
+		{"日本", ""},
+	}
+	for _, tt := range tests {
+		if got := branchSlug(tt.branch); got != tt.want {
+			t.Errorf("%q: Expected %q, got %q", tt.branch, tt.want, got)
+		}
+	}
+}
+
+func TestNamespaceByBranch(t *testing.T) {
+	cfg := PlateConfig{Project: "shop", Services: []ServiceConfig{{Type: "postgres", Name: "main-db"}}}
+	namespaceByBranch(&cfg, "feature-users")
+	if cfg.Project != "shop-feature-users" {
+		t.Errorf("Expected the project to be namespaced, got %s", cfg.Project)
+	}
+	if name := containerName(cfg.Services[0]); name != "plate-postgres-main-db-feature-users" {
+		t.Errorf("Expected the container name to be namespaced, got %s", name)
+	}
+
+	// A template with the project is namespaced through it.
+	cfg = PlateConfig{Project: "shop", Naming: "dev-{project}-{name}", Services: []ServiceConfig{{Type: "postgres", Name: "main-db"}}}
+	namespaceByBranch(&cfg, "main")
+	if name := containerName(cfg.Services[0]); name != "dev-shop-main-main-db" {
+		t.Errorf("Expected dev-shop-main-main-db, got %s", name)
+	}
+}
+
+// testRepo returns an empty directory and a function running git in it,
+// skipping the test without git.
+func testRepo(t *testing.T) (string, func(args ...string) string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	return dir, func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %s", args, output)
+		}
+		return strings.TrimSpace(string(output))
+	}
+}
+
+func TestGitBranch(t *testing.T) {
+	dir, git := testRepo(t)
+	if _, err := gitBranch(dir); err == nil {
+		t.Errorf("Expected an error outside a repository")
+	}
+	git("init", "-q", "-b", "feature/users")
+	git("commit", "-q", "--allow-empty", "-m", "init")
+	if branch, err := gitBranch(dir); err != nil || branch != "feature/users" {
+		t.Errorf("Expected feature/users, got %q, %v", branch, err)
+	}
+	if linkedWorktree(dir) {
+		t.Errorf("Expected the main checkout not to be a linked worktree")
+	}
+	if res := checkWorktree(PlateConfig{}, dir); !res.ok {
+		t.Errorf("Expected the main checkout to pass, got %s", res.detail)
+	}
+
+	worktree := t.TempDir() + "/other"
+	git("worktree", "add", "-q", "-b", "other", worktree)
+	if !linkedWorktree(worktree) {
+		t.Errorf("Expected a linked worktree")
+	}
+	if res := checkWorktree(PlateConfig{}, worktree); res.ok {
+		t.Errorf("Expected a warning for a shared worktree, got %s", res.detail)
+	}
+	if res := checkWorktree(PlateConfig{PerBranch: true}, worktree); !res.ok {
+		t.Errorf("Expected per-branch worktrees to pass, got %s", res.detail)
+	}
+}
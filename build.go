@@ -0,0 +1,303 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// --- CUSTOM IMAGE BUILDS ---
+
+// BuildConfig builds a service's image from a Dockerfile instead of pulling
+// it, e.g. postgres with extensions baked in. The service type still decides
+// the port, environment, and health check.
+type BuildConfig struct {
+	// Context is the build context directory.
+	Context string `json:"context"`
+	// Dockerfile is relative to the context and defaults to "Dockerfile".
+	Dockerfile string            `json:"dockerfile,omitempty"`
+	Args       map[string]string `json:"args,omitempty"`
+}
+
+// dockerfilePath returns the path of the Dockerfile.
+func (b BuildConfig) dockerfilePath() string {
+	name := b.Dockerfile
+	if name == "" {
+		name = "Dockerfile"
+	}
+	return filepath.Join(b.Context, name)
+}
+
+// ignorePattern is one line of a .dockerignore.
+type ignorePattern struct {
+	re     *regexp.Regexp
+	negate bool // the line started with "!", so it adds files back
+}
+
+// dockerignore holds the patterns of a build context's .dockerignore, in
+// order. Docker leaves the paths they match out of the context.
+type dockerignore []ignorePattern
+
+// readDockerignore reads the .dockerignore of a build context. A context
+// without one ignores nothing.
+func readDockerignore(context string) (dockerignore, error) {
+	data, err := os.ReadFile(filepath.Join(context, ".dockerignore"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var patterns dockerignore
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		p := ignorePattern{}
+		if rest, ok := strings.CutPrefix(line, "!"); ok {
+			p.negate, line = true, strings.TrimSpace(rest)
+		}
+		line = strings.TrimPrefix(filepath.ToSlash(filepath.Clean(line)), "/")
+		re, err := ignoreRegexp(line)
+		if err != nil {
+			return nil, fmt.Errorf(".dockerignore: bad pattern %q: %w", line, err)
+		}
+		p.re = re
+		patterns = append(patterns, p)
+	}
+	return patterns, nil
+}
+
+// ignoreRegexp translates a .dockerignore pattern the way docker reads it:
+// "*" and "?" stay within a path segment, and "**" spans any number of them.
+func ignoreRegexp(pattern string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			if strings.HasPrefix(pattern[i:], "**/") {
+				b.WriteString("(.*/)?")
+				i += 2
+			} else if strings.HasPrefix(pattern[i:], "**") {
+				b.WriteString(".*")
+				i++
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(pattern[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed [")
+			}
+			b.WriteString(pattern[i : i+end+1])
+			i += end
+		case '\\':
+			if i+1 < len(pattern) {
+				i++
+				b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+			}
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
+
+// ignored reports whether docker leaves rel, a slash-separated path inside
+// the context, out of it. A pattern matching a directory covers what is in
+// it, and the last matching line wins.
+func (d dockerignore) ignored(rel string) bool {
+	ignored := false
+	for _, p := range d {
+		for path := rel; ; {
+			if p.re.MatchString(path) {
+				ignored = !p.negate
+				break
+			}
+			i := strings.LastIndexByte(path, '/')
+			if i < 0 {
+				break
+			}
+			path = path[:i]
+		}
+	}
+	return ignored
+}
+
+// negates reports whether any line adds files back, so an ignored directory
+// may still hold files that count.
+func (d dockerignore) negates() bool {
+	for _, p := range d {
+		if p.negate {
+			return true
+		}
+	}
+	return false
+}
+
+// buildChecksum hashes the Dockerfile, the build args, and every file in
+// the context that docker would send, leaving out .git and what the
+// .dockerignore lists, so the image is only rebuilt when one of them
+// changed.
+func buildChecksum(b BuildConfig) (string, error) {
+	h := sha256.New()
+	dockerfile, err := os.ReadFile(b.dockerfilePath())
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintf(h, "dockerfile %d\n", len(dockerfile))
+	h.Write(dockerfile)
+	for _, k := range sortedEnvKeys(b.Args) {
+		fmt.Fprintf(h, "arg %q=%q\n", k, b.Args[k])
+	}
+	ignore, err := readDockerignore(b.Context)
+	if err != nil {
+		return "", err
+	}
+	err = filepath.WalkDir(b.Context, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(b.Context, path)
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			if d.Name() == ".git" || (rel != "." && ignore.ignored(rel) && !ignore.negates()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if ignore.ignored(rel) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(h, "file %q %v %d\n", rel, info.Mode(), info.Size())
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(h, f)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// buildTag names a built image after its service and checksum, so a changed
+// build produces a new tag and shows up as image drift in `plate diff`.
+func buildTag(config ServiceConfig, checksum string) string {
+	return "plate-build/" + strings.ToLower(containerName(config)) + ":" + checksum[:12]
+}
+
+// validateBuilds checks the build of every service that has one.
+func validateBuilds(cfg PlateConfig) error {
+	for _, svc := range cfg.Services {
+		if svc.Build == nil {
+			continue
+		}
+		if svc.isProcess() {
+			return fmt.Errorf("service %s: process services can't have a build", svc.Name)
+		}
+		if svc.Build.Context == "" {
+			return fmt.Errorf("service %s: build needs a context directory", svc.Name)
+		}
+	}
+	return nil
+}
+
+// resolveBuilds tags the image of every service with a build with the
+// checksum of its inputs. Hashing a large context takes a while, so only
+// the commands that decide whether to build call it, not loadConfig.
+func resolveBuilds(cfg *PlateConfig) error {
+	for i := range cfg.Services {
+		svc := &cfg.Services[i]
+		if svc.Build == nil {
+			continue
+		}
+		checksum, err := buildChecksum(*svc.Build)
+		if err != nil {
+			return fmt.Errorf("service %s: build: %w", svc.Name, err)
+		}
+		svc.buildTag = buildTag(*svc, checksum)
+	}
+	return nil
+}
+
+// mustResolveBuilds returns cfg with its built images tagged, or exits.
+func mustResolveBuilds(cfg PlateConfig) PlateConfig {
+	if err := resolveBuilds(&cfg); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitConfig)
+	}
+	return cfg
+}
+
+// buildImage builds the service's image with docker build, which reuses its
+// layer cache, and writes the build output to output.
+func buildImage(config ServiceConfig, output io.Writer) error {
+	args := []string{"build", "-t", imageName(config), "-f", config.Build.dockerfilePath()}
+	for _, k := range sortedEnvKeys(config.Build.Args) {
+		args = append(args, "--build-arg", k+"="+config.Build.Args[k])
+	}
+	args = append(args, config.Build.Context)
+
+	var tail bytes.Buffer
+	cmd := dockerCommand(args...)
+	cmd.Stdout = io.MultiWriter(output, &tail)
+	cmd.Stderr = cmd.Stdout
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(tail.String()); msg != "" {
+			return fmt.Errorf("docker build failed: %s", lastLine(msg))
+		}
+		return fmt.Errorf("docker build failed: %v", err)
+	}
+	return nil
+}
+
+// obtainImage builds or pulls the service's image.
+func obtainImage(config ServiceConfig, output io.Writer) error {
+	if config.Build != nil {
+		return buildImage(config, output)
+	}
+	if err := pullImage(config); err != nil {
+		return fmt.Errorf("could not pull %s: %w", imageName(config), err)
+	}
+	return nil
+}
+
+// buildImageCmd builds the service's image once a pull slot is free, with
+// the build output in the service's logs.
+func buildImageCmd(service string, config ServiceConfig, logs *logHub, slots semaphore) tea.Cmd {
+	return func() tea.Msg {
+		var output io.Writer = io.Discard
+		if logs != nil {
+			output = logs.writer(config.Name)
+		}
+		var err error
+		slots.run(func() { err = buildImage(config, output) })
+		return imagePulledMsg{service: service, err: err}
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBuildChecksum(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("Dockerfile", "FROM postgres:16\nCOPY init.sql /docker-entrypoint-initdb.d/\n")
+	write("init.sql", "CREATE EXTENSION vector;\n")
+	build := BuildConfig{Context: dir}
+
+	sum := func(b BuildConfig) string {
+		s, err := buildChecksum(b)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		return s
+	}
+	base := sum(build)
+
+	write(".git/HEAD", "ref: refs/heads/main\n")
+	if got := sum(build); got != base {
+		t.Errorf("Expected .git to be ignored")
+	}
+	if got := sum(BuildConfig{Context: dir, Args: map[string]string{"PG": "16"}}); got == base {
+		t.Errorf("Expected build args to change the checksum")
+	}
+	write("init.sql", "CREATE EXTENSION postgis;\n")
+	if got := sum(build); got == base {
+		t.Errorf("Expected a changed context file to change the checksum")
+	}
+	changed := sum(build)
+	write(".dockerignore", "node_modules\n**/*.log\n!keep.log\n")
+	ignoring := sum(build)
+	write("node_modules/left-pad/index.js", "module.exports = 1\n")
+	write("logs/app.log", "started\n")
+	if got := sum(build); got != ignoring {
+		t.Errorf("Expected the paths in .dockerignore to be left out")
+	}
+	write("keep.log", "kept\n")
+	if got := sum(build); got == ignoring {
+		t.Errorf("Expected a path added back with ! to count")
+	}
+	if ignoring == changed {
+		t.Errorf("Expected the .dockerignore itself to count")
+	}
+	if _, err := buildChecksum(BuildConfig{Context: dir, Dockerfile: "missing.Dockerfile"}); err == nil {
+		t.Errorf("Expected an error for a missing Dockerfile")
+	}
+}
+
+func TestResolveBuilds(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "Dockerfile"), []byte("FROM redis:7\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := PlateConfig{Services: []ServiceConfig{
+		{Type: "postgres", Name: "db", Version: "16"},
+		{Type: "redis", Name: "cache", Version: "7", Build: &BuildConfig{Context: dir}},
+	}}
+	if err := resolveBuilds(&cfg); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := imageName(cfg.Services[0]); got != "postgres:16" {
+		t.Errorf("Expected postgres:16, got %s", got)
+	}
+	if got := imageName(cfg.Services[1]); !strings.HasPrefix(got, "plate-build/plate-redis-cache:") {
+		t.Errorf("Expected a plate-build image, got %s", got)
+	}
+
+	for _, svc := range []ServiceConfig{
+		{Type: "redis", Name: "nocontext", Build: &BuildConfig{}},
+		{Type: "process", Name: "web", Command: "npm start", Build: &BuildConfig{Context: dir}},
+	} {
+		if err := validateBuilds(PlateConfig{Services: []ServiceConfig{svc}}); err == nil {
+			t.Errorf("%s: Expected an error", svc.Name)
+		}
+	}
+}
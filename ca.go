@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// --- LOCAL CERTIFICATE AUTHORITY ---
+
+// linuxTrustStores are the system CA directories Plate knows how to update,
+// with the command that rebuilds the bundle after a change.
+var linuxTrustStores = []struct {
+	dir    string
+	update string
+}{
+	{"/usr/local/share/ca-certificates", "update-ca-certificates"},
+	{"/etc/pki/ca-trust/source/anchors", "update-ca-trust"},
+	{"/etc/ca-certificates/trust-source/anchors", "trust extract-compat"},
+}
+
+// trustCommands returns the commands that add the CA at caPath to (or remove
+// it from) the host's trust store. Linux stores the CA as name.crt; Windows
+// finds it again by its common name.
+func trustCommands(goos, caPath, name, commonName string, install bool) ([][]string, error) {
+	switch goos {
+	case "darwin":
+		if !install {
+			return [][]string{{"security", "remove-trusted-cert", caPath}}, nil
+		}
+		home, _ := os.UserHomeDir()
+		keychain := filepath.Join(home, "Library", "Keychains", "login.keychain-db")
+		return [][]string{{"security", "add-trusted-cert", "-r", "trustRoot", "-k", keychain, caPath}}, nil
+	case "windows":
+		if !install {
+			return [][]string{{"certutil", "-delstore", "-user", "Root", commonName}}, nil
+		}
+		return [][]string{{"certutil", "-addstore", "-user", "Root", caPath}}, nil
+	case "linux":
+		for _, store := range linuxTrustStores {
+			if _, err := os.Stat(store.dir); err != nil {
+				continue
+			}
+			target := filepath.Join(store.dir, name+".crt")
+			first := []string{"sudo", "cp", caPath, target}
+			if !install {
+				first = []string{"sudo", "rm", "-f", target}
+			}
+			return [][]string{first, append([]string{"sudo"}, strings.Fields(store.update)...)}, nil
+		}
+		return nil, fmt.Errorf("no known system CA directory found; trust %s manually", caPath)
+	}
+	return nil, fmt.Errorf("trusting certificates isn't supported on %s; trust %s manually", goos, caPath)
+}
+
+// caTrustName names this project's CA in the host's trust store.
+func caTrustName() string {
+	name := "plate"
+	if abs, err := filepath.Abs("."); err == nil {
+		name += "-" + filepath.Base(abs)
+	}
+	return name
+}
+
+func handleCACmd(args []string) {
+	sub := ""
+	if len(args) > 0 {
+		sub, args = args[0], args[1:]
+	}
+	switch sub {
+	case "":
+		ca, _, err := ensureCA()
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("%s %s\n", detailAttrStyle.Render("CA:"), caCertPath())
+		fmt.Printf("%s %s\n", detailAttrStyle.Render("Subject:"), ca.Subject.CommonName)
+		fmt.Printf("%s %s\n", detailAttrStyle.Render("Expires:"), ca.NotAfter.Format("2006-01-02"))
+		if certs, _ := filepath.Glob(filepath.Join(stateDir, certsDirName, "*", "server.crt")); len(certs) > 0 {
+			fmt.Println(detailAttrStyle.Render("Certificates:"))
+			for _, c := range certs {
+				fmt.Printf("  %s\n", filepath.Dir(c))
+			}
+		}
+	case "cert":
+		if len(args) < 1 || strings.ContainsAny(args[0], `/\`) || args[0] == "." || args[0] == ".." {
+			fmt.Println("Usage: plate ca cert <name> [host...]")
+			os.Exit(exitUsage)
+		}
+		name := args[0]
+		hosts := []string{"localhost", "127.0.0.1", "::1", name}
+		hosts = append(hosts, args[1:]...)
+		dir := filepath.Join(stateDir, certsDirName, name)
+		if err := ensureCert(dir, name, hosts); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("%s %s\n", successStyle.Render("✓"), filepath.Join(dir, "server.crt"))
+		fmt.Printf("%s %s\n", successStyle.Render("✓"), filepath.Join(dir, "server.key"))
+		fmt.Printf("Valid for %s.\n", strings.Join(hosts, ", "))
+	case "install", "uninstall":
+		install := sub == "install"
+		ca, _, err := ensureCA()
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		caPath, _ := filepath.Abs(caCertPath())
+		commands, err := trustCommands(runtime.GOOS, caPath, caTrustName(), ca.Subject.CommonName, install)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		for _, c := range commands {
+			fmt.Println(stoppedStyle.Render("$ " + strings.Join(c, " ")))
+			cmd := exec.Command(c[0], c[1:]...)
+			cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+			if err := cmd.Run(); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		if install {
+			fmt.Println(successStyle.Render("The local CA is now trusted on this machine."))
+		} else {
+			fmt.Println(successStyle.Render("The local CA is no longer trusted on this machine."))
+		}
+	default:
+		fmt.Println("Usage: plate ca [cert <name> [host...] | install | uninstall]")
+		os.Exit(exitUsage)
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTrustCommands(t *testing.T) {
+	tests := []struct {
+		goos     string
+		install  bool
+		expected [][]string
+	}{
+		{"windows", true, [][]string{{"certutil", "-addstore", "-user", "Root", "/p/ca.crt"}}},
+		{"windows", false, [][]string{{"certutil", "-delstore", "-user", "Root", "Plate local CA (box)"}}},
+		{"darwin", false, [][]string{{"security", "remove-trusted-cert", "/p/ca.crt"}}},
+	}
+	for _, tt := range tests {
+		got, err := trustCommands(tt.goos, "/p/ca.crt", "plate-shop", "Plate local CA (box)", tt.install)
+		if err != nil {
+			t.Errorf("%s: Expected no error, got %v", tt.goos, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("%s: Expected %v, got %v", tt.goos, tt.expected, got)
+		}
+	}
+	if _, err := trustCommands("plan9", "/p/ca.crt", "plate-shop", "Plate local CA (box)", true); err == nil {
+		t.Errorf("Expected an error for an unsupported platform")
+	}
+}
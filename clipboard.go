@@ -0,0 +1,45 @@
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/atotto/clipboard"
+)
+
+// --- CLIPBOARD ---
+
+// copyToClipboard copies text with the system clipboard. Without one, as in
+// an SSH session or on a machine without xclip, it asks the terminal to copy
+// it with an OSC 52 sequence, reporting viaTerminal since it can't tell
+// whether the terminal did.
+func copyToClipboard(text string) (viaTerminal bool, err error) {
+	err = clipboard.WriteAll(text)
+	if err == nil {
+		return false, nil
+	}
+	if !isTerminal(os.Stdout) {
+		return false, err
+	}
+	seq := osc52Sequence(text, os.Getenv("TMUX") != "", strings.HasPrefix(os.Getenv("TERM"), "screen"))
+	if _, werr := os.Stdout.WriteString(seq); werr != nil {
+		return false, fmt.Errorf("%v; %v", err, werr)
+	}
+	return true, nil
+}
+
+// osc52Sequence returns the escape sequence that puts text on the
+// terminal's clipboard. tmux and screen only pass it on to the terminal
+// when wrapped in their own passthrough sequences.
+func osc52Sequence(text string, tmux, screen bool) string {
+	seq := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\a"
+	switch {
+	case tmux:
+		return "\x1bPtmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + "\x1b\\"
+	case screen:
+		return "\x1bP" + seq + "\x1b\\"
+	}
+	return seq
+}
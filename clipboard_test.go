@@ -0,0 +1,20 @@
+package main
+
+import "testing"
+
+func TestOSC52Sequence(t *testing.T) {
+	tests := []struct {
+		name         string
+		tmux, screen bool
+		expected     string
+	}{
+		{"plain", false, false, "\x1b]52;c;aGk=\a"},
+		{"tmux", true, false, "\x1bPtmux;\x1b\x1b]52;c;aGk=\a\x1b\\"},
+		{"screen", false, true, "\x1bP\x1b]52;c;aGk=\a\x1b\\"},
+	}
+	for _, tt := range tests {
+		if got := osc52Sequence("hi", tt.tmux, tt.screen); got != tt.expected {
+			t.Errorf("%s: Expected %q, got %q", tt.name, tt.expected, got)
+		}
+	}
+}
@@ -2,11 +2,8 @@ package main
 
 import (
 	"fmt"
-	"os/exec"
-	"strings"
 	"sync"
 
-	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -15,140 +12,278 @@ import (
 // These messages are the results of commands.
 
 type containerStatusMsg struct {
-	index       int
+	service     string
 	containerID string
-	status      string // e.g., "running", "exited", ""
+	status      string         // e.g., "running", "exited", ""
+	exit        containerExit  // how an exited container ended
+	external    *containerInfo // set when a container Plate didn't create is in the way
+	conflict    conflictKind
 }
 
 type imageStatusMsg struct {
-	index    int
+	service  string
 	hasImage bool
+	emulated string // the image's platform, when it runs under emulation
 }
 
 type imagePulledMsg struct {
-	index int
-	err   error
+	service  string
+	err      error
+	emulated string
 }
 
 type containerStartedMsg struct {
-	index            int
+	service          string
 	containerID      string
 	connectionString string
 	err              error
 }
 
 type containerStoppedMsg struct {
-	index int
-	err   error
+	service string
+	err     error
 }
 
 type containerRemovedMsg struct {
-	index   int
+	service string
 	err     error
 	isReset bool
 }
 
+type externalResolvedMsg struct {
+	service string
+	err     error
+}
+
+type processStartedMsg struct {
+	service string
+	process *runningProcess
+	err     error
+}
+
+type processExitedMsg struct {
+	service string
+	err     error
+}
+
+// copiedToClipboardMsg clears the copy notice again.
 type copiedToClipboardMsg struct{}
 
+// clipboardMsg reports how a copy went.
+type clipboardMsg struct {
+	viaTerminal bool // sent as an OSC 52 sequence, which the terminal may ignore
+	err         error
+}
+
 type cleanupCompleteMsg struct{}
 
+type diffLoadedMsg struct {
+	changes []serviceChange
+	err     error
+}
+
 // --- DOCKER & CLIPBOARD COMMANDS ---
 
 func copyToClipboardCmd(text string) tea.Cmd {
 	return func() tea.Msg {
-		clipboard.WriteAll(text)
-		return nil // We don't need a message back, the tick will handle UI
+		viaTerminal, err := copyToClipboard(text)
+		return clipboardMsg{viaTerminal: viaTerminal, err: err}
 	}
 }
 
-func checkContainerCmd(index int, config ServiceConfig) tea.Cmd {
+// checkContainerCmd finds the service's container, or what stands in its
+// way. Looking for containers it could adopt inspects every container on
+// the host, so that is only done with findCandidates: when the service is
+// first booted, not on every later check.
+func checkContainerCmd(service string, cfg PlateConfig, config ServiceConfig, findCandidates bool) tea.Cmd {
 	return func() tea.Msg {
-		containerName := fmt.Sprintf("plate-%s-%s", config.Type, config.Name)
-		cmd := exec.Command("docker", "ps", "-a", "--filter", "name="+containerName, "--format", "{{.ID}}\t{{.State}}")
-		output, _ := cmd.CombinedOutput()
-		if len(output) > 0 {
-			parts := strings.Split(strings.TrimSpace(string(output)), "\t")
-			if len(parts) == 2 {
-				return containerStatusMsg{index: index, containerID: parts[0], status: parts[1]}
+		name := containerName(config)
+		if st, err := loadState(); err == nil {
+			if id, ok := st.Adopted[config.Name]; ok {
+				if infos, _ := inspectContainers(id); len(infos) == 1 {
+					return containerStatusMsg{service: service, containerID: infos[0].ID, status: infos[0].State, exit: exitOf(infos[0])}
+				}
 			}
 		}
-		return containerStatusMsg{index: index, status: "not_found"}
+		if infos, _ := inspectContainers(name); len(infos) == 1 {
+			ctr := infos[0]
+			if !ctr.managed() {
+				return containerStatusMsg{service: service, external: &ctr, conflict: conflictName}
+			}
+			return containerStatusMsg{service: service, containerID: ctr.ID, status: ctr.State, exit: exitOf(ctr)}
+		}
+		if containers, err := listPlateContainers(cfg.Project); err == nil {
+			if ctr, ok := findRenamedContainer(cfg, config, containers); ok {
+				return containerStatusMsg{service: service, external: &ctr, conflict: conflictRenamed}
+			}
+		}
+		if ctr, ok := findPortPublisher(config.Port, name); ok && !ctr.managed() {
+			return containerStatusMsg{service: service, external: &ctr, conflict: conflictPort}
+		}
+		if findCandidates {
+			if candidates, _ := findAdoptionCandidates(config); len(candidates) > 0 {
+				return containerStatusMsg{service: service, external: &candidates[0], conflict: conflictCandidate}
+			}
+		}
+		return containerStatusMsg{service: service, status: "not_found"}
 	}
 }
 
-func checkImageCmd(index int, config ServiceConfig) tea.Cmd {
+func adoptContainerCmd(service string, config ServiceConfig, ctr containerInfo) tea.Cmd {
 	return func() tea.Msg {
-		imageName := fmt.Sprintf("%s:%s", config.Type, config.Version)
-		cmd := exec.Command("docker", "images", "-q", imageName)
-		output, _ := cmd.CombinedOutput()
-		return imageStatusMsg{index: index, hasImage: len(output) > 0}
+		err := validateAdoption(config, ctr)
+		if err != nil {
+			err = fmt.Errorf("cannot adopt: %w", err)
+		} else {
+			err = adoptContainer(config, ctr.ID)
+		}
+		recordAction("adopt", config.Name, ctr.Name, "", err)
+		return externalResolvedMsg{service: service, err: err}
+	}
+}
+
+func renameExternalCmd(service string, config ServiceConfig, ctr containerInfo) tea.Cmd {
+	return func() tea.Msg {
+		err := backend.rename(ctr.Name, ctr.Name+"-external")
+		if err != nil {
+			err = fmt.Errorf("could not rename %s: %w", ctr.Name, err)
+		}
+		recordAction("rename external", config.Name, ctr.Name, dockerCommandLine("rename", ctr.Name, ctr.Name+"-external"), err)
+		return externalResolvedMsg{service: service, err: err}
 	}
 }
 
-func pullImageCmd(index int, config ServiceConfig) tea.Cmd {
+func checkImageCmd(service string, config ServiceConfig) tea.Cmd {
 	return func() tea.Msg {
-		imageName := fmt.Sprintf("%s:%s", config.Type, config.Version)
-		err := exec.Command("docker", "pull", imageName).Run()
-		return imagePulledMsg{index: index, err: err}
+		if !hasImage(config) {
+			return imageStatusMsg{service: service}
+		}
+		return imageStatusMsg{service: service, hasImage: true, emulated: emulatedPlatform(config)}
 	}
 }
 
-func startContainerCmd(index int, config ServiceConfig, containerID string) tea.Cmd {
+// pullImageCmd pulls the service's image once a pull slot is free.
+func pullImageCmd(service string, config ServiceConfig, slots semaphore) tea.Cmd {
 	return func() tea.Msg {
-		containerName := fmt.Sprintf("plate-%s-%s", config.Type, config.Name)
-		connStr, runArgs, err := getDockerRunArgs(config, containerName)
+		var err error
+		slots.run(func() { err = pullImage(config) })
 		if err != nil {
-			return containerStartedMsg{index: index, err: err}
+			return imagePulledMsg{service: service, err: err}
 		}
-		runCmd := exec.Command("docker", runArgs...)
-		output, err := runCmd.CombinedOutput()
+		return imagePulledMsg{service: service, emulated: emulatedPlatform(config)}
+	}
+}
+
+// startContainerCmd runs a new container once a start slot is free.
+func startContainerCmd(service string, config ServiceConfig, slots semaphore) tea.Cmd {
+	return func() tea.Msg {
+		var containerID, connStr string
+		var err error
+		slots.run(func() { containerID, connStr, err = runServiceContainer(config) })
+		command, _ := dockerRunCommand(config)
+		recordAction("create", config.Name, containerName(config), command, err)
 		if err != nil {
-			return containerStartedMsg{index: index, err: fmt.Errorf("%s", strings.TrimSpace(string(output)))}
+			return containerStartedMsg{service: service, err: err}
 		}
-		return containerStartedMsg{index: index, containerID: strings.TrimSpace(string(output)), connectionString: connStr}
+		return containerStartedMsg{service: service, containerID: containerID, connectionString: connStr}
 	}
 }
 
-func restartContainerCmd(index int, config ServiceConfig, containerID string) tea.Cmd {
+func restartContainerCmd(service string, config ServiceConfig, containerID string, slots semaphore) tea.Cmd {
 	return func() tea.Msg {
-		cmd := exec.Command("docker", "start", containerID)
-		if err := cmd.Run(); err != nil {
-			return containerStartedMsg{index: index, err: err}
+		var err error
+		slots.run(func() { err = backend.start(containerID) })
+		recordAction("start", config.Name, containerName(config), dockerCommandLine("start", containerID), err)
+		if err != nil {
+			return containerStartedMsg{service: service, err: err}
 		}
 		connStr, _ := getConnectionString(config)
-		return containerStartedMsg{index: index, containerID: containerID, connectionString: connStr}
+		return containerStartedMsg{service: service, containerID: containerID, connectionString: connStr}
 	}
 }
 
-func stopContainerCmd(index int, containerID string) tea.Cmd {
+func stopContainerCmd(service string, config ServiceConfig, containerID string) tea.Cmd {
 	return func() tea.Msg {
-		err := exec.Command("docker", "stop", containerID).Run()
-		return containerStoppedMsg{index: index, err: err}
+		err := backend.stop(containerID)
+		recordAction("stop", config.Name, containerName(config), dockerCommandLine("stop", containerID), err)
+		return containerStoppedMsg{service: service, err: err}
 	}
 }
 
-func removeContainerCmd(index int, containerID string, isReset bool) tea.Cmd {
+func removeContainerCmd(service string, config ServiceConfig, containerID string, isReset bool) tea.Cmd {
 	return func() tea.Msg {
-		_ = exec.Command("docker", "stop", containerID).Run()
-		err := exec.Command("docker", "rm", containerID).Run()
-		return containerRemovedMsg{index: index, err: err, isReset: isReset}
+		_ = backend.stop(containerID)
+		err := backend.remove(containerID, false)
+		action := "delete"
+		if isReset {
+			action = "reset"
+		}
+		recordAction(action, config.Name, containerName(config), dockerCommandLine("rm", containerID), err)
+		return containerRemovedMsg{service: service, err: err, isReset: isReset}
 	}
 }
 
-func stopAllContainersOnExit(items []list.Item) tea.Cmd {
+func startProcessCmd(service string, config ServiceConfig, injected []string, logs *logHub) tea.Cmd {
+	return func() tea.Msg {
+		p, err := startProcess(config, injected, logs)
+		recordAction("start", config.Name, "", config.Command, err)
+		return processStartedMsg{service: service, process: p, err: err}
+	}
+}
+
+// waitProcessCmd reports when a started process exits, for whatever reason.
+func waitProcessCmd(service string, p *runningProcess) tea.Cmd {
+	return func() tea.Msg {
+		<-p.done
+		return processExitedMsg{service: service, err: p.err}
+	}
+}
+
+func stopProcessCmd(config ServiceConfig, p *runningProcess) tea.Cmd {
+	return func() tea.Msg {
+		p.stop()
+		recordAction("stop", config.Name, "", config.Command, nil)
+		return nil // waitProcessCmd reports the exit
+	}
+}
+
+// stopAllContainersOnExit stops the processes and, unless keepContainers,
+// the running containers that aren't pinned. Processes can't outlive
+// Plate's control of them.
+func stopAllContainersOnExit(items []list.Item, keepContainers bool) tea.Cmd {
 	return func() tea.Msg {
 		var wg sync.WaitGroup
 		for _, itm := range items {
 			i := itm.(item)
-			if i.containerID != "" && i.status == statusRunning {
+			if i.process != nil {
+				wg.Add(1)
+				go func(config ServiceConfig, p *runningProcess) {
+					defer wg.Done()
+					p.stop()
+					recordAction("stop", config.Name, "", config.Command, nil)
+				}(i.config, i.process)
+				continue
+			}
+			if i.containerID != "" && i.status == statusRunning && !keepContainers && !i.pinned {
 				wg.Add(1)
-				go func(cid string) {
+				go func(config ServiceConfig, cid string) {
 					defer wg.Done()
-					exec.Command("docker", "stop", cid).Run()
-				}(i.containerID)
+					err := backend.stop(cid)
+					recordAction("stop", config.Name, containerName(config), dockerCommandLine("stop", cid), err)
+				}(i.config, i.containerID)
 			}
 		}
 		wg.Wait()
 		return cleanupCompleteMsg{}
 	}
 }
+
+func loadDiffCmd(cfg PlateConfig) tea.Cmd {
+	return func() tea.Msg {
+		containers, err := listPlateContainers(cfg.Project)
+		if err != nil {
+			return diffLoadedMsg{err: err}
+		}
+		return diffLoadedMsg{changes: computeDiff(cfg, containers)}
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// --- DOCKER CONCURRENCY ---
+
+// ConcurrencyConfig limits how many image pulls and container starts run at
+// once. Unset values are derived from the CPU count.
+type ConcurrencyConfig struct {
+	Pulls  int `json:"pulls,omitempty"`
+	Starts int `json:"starts,omitempty"`
+}
+
+// dockerLimits are the resolved concurrency limits.
+type dockerLimits struct {
+	pulls  int
+	starts int
+}
+
+// defaultLimits derives the limits from the CPU count. Pulls mostly wait on
+// the network and disk, so fewer of them run at once.
+func defaultLimits(cpus int) dockerLimits {
+	return dockerLimits{pulls: max(2, cpus/2), starts: max(2, cpus)}
+}
+
+// resolveConcurrency applies the configured limits over the defaults for
+// this machine's CPU count.
+func resolveConcurrency(cfg *ConcurrencyConfig, cpus int) (dockerLimits, error) {
+	limits := defaultLimits(cpus)
+	if cfg == nil {
+		return limits, nil
+	}
+	if cfg.Pulls < 0 || cfg.Starts < 0 {
+		return limits, fmt.Errorf("concurrency: limits must be positive")
+	}
+	if cfg.Pulls > 0 {
+		limits.pulls = cfg.Pulls
+	}
+	if cfg.Starts > 0 {
+		limits.starts = cfg.Starts
+	}
+	return limits, nil
+}
+
+// semaphore bounds how many operations run at once. A nil semaphore doesn't
+// limit anything.
+type semaphore chan struct{}
+
+// dockerSemaphores hold the slots for pulls and starts.
+type dockerSemaphores struct {
+	pulls  semaphore
+	starts semaphore
+}
+
+func newDockerSemaphores(cfg *ConcurrencyConfig) dockerSemaphores {
+	limits, _ := resolveConcurrency(cfg, runtime.NumCPU()) // validated when the config was loaded
+	return dockerSemaphores{pulls: make(semaphore, limits.pulls), starts: make(semaphore, limits.starts)}
+}
+
+// run calls f once a slot is free.
+func (s semaphore) run(f func()) {
+	if s != nil {
+		s <- struct{}{}
+		defer func() { <-s }()
+	}
+	f()
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestResolveConcurrency(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      *ConcurrencyConfig
+		cpus     int
+		expected dockerLimits
+		wantErr  bool
+	}{
+		{"defaults", nil, 8, dockerLimits{pulls: 4, starts: 8}, false},
+		{"few cpus", nil, 1, dockerLimits{pulls: 2, starts: 2}, false},
+		{"configured", &ConcurrencyConfig{Pulls: 1}, 8, dockerLimits{pulls: 1, starts: 8}, false},
+		{"negative", &ConcurrencyConfig{Starts: -1}, 8, dockerLimits{}, true},
+	}
+	for _, tt := range tests {
+		got, err := resolveConcurrency(tt.cfg, tt.cpus)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: Expected error %v, got %v", tt.name, tt.wantErr, err)
+			continue
+		}
+		if !tt.wantErr && got != tt.expected {
+			t.Errorf("%s: Expected %+v, got %+v", tt.name, tt.expected, got)
+		}
+	}
+}
+
+func TestSemaphoreLimitsConcurrency(t *testing.T) {
+	slots := make(semaphore, 2)
+	var running, peak atomic.Int32
+	release := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slots.run(func() {
+				n := running.Add(1)
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+				<-release
+				running.Add(-1)
+			})
+		}()
+	}
+	close(release)
+	wg.Wait()
+	if peak.Load() > 2 {
+		t.Errorf("Expected at most 2 operations at once, got %d", peak.Load())
+	}
+
+	var nilSlots semaphore
+	ran := false
+	nilSlots.run(func() { ran = true })
+	if !ran {
+		t.Errorf("Expected a nil semaphore to run immediately")
+	}
+}
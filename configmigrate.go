@@ -0,0 +1,140 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// --- CONFIG SCHEMA VERSIONS ---
+
+// configMigration upgrades a config from one schema version to the next.
+// It works on the raw JSON, since an old layout may not decode into the
+// current types.
+type configMigration struct {
+	describe string // what changed, shown by `plate config migrate`
+	migrate  func(raw map[string]any)
+}
+
+// configMigrations upgrade version i+1 to i+2. Configs without a version
+// are version 1, the layout before versions were introduced.
+var configMigrations []configMigration
+
+// currentConfigVersion is the schema version this Plate writes and reads.
+func currentConfigVersion() int {
+	return 1 + len(configMigrations)
+}
+
+// rawConfigVersion returns the schema version of a raw config.
+func rawConfigVersion(raw map[string]any) (int, error) {
+	v, ok := raw["version"]
+	if !ok {
+		return 1, nil
+	}
+	n, ok := v.(float64)
+	if !ok || n != float64(int(n)) || n < 1 {
+		return 0, fmt.Errorf("version must be a whole number of at least 1, got %v", v)
+	}
+	return int(n), nil
+}
+
+// migrateRawConfig brings a raw config up to the current schema version and
+// returns the version it had and the migrations it went through.
+func migrateRawConfig(raw map[string]any) (from int, applied []string, err error) {
+	from, err = rawConfigVersion(raw)
+	if err != nil {
+		return 0, nil, err
+	}
+	if from > currentConfigVersion() {
+		return from, nil, fmt.Errorf("it has config version %d, but this Plate only reads up to %d; update Plate", from, currentConfigVersion())
+	}
+	for v := from; v < currentConfigVersion(); v++ {
+		m := configMigrations[v-1]
+		m.migrate(raw)
+		applied = append(applied, m.describe)
+	}
+	if len(applied) > 0 {
+		raw["version"] = currentConfigVersion()
+	}
+	return from, applied, nil
+}
+
+// migrateConfigData upgrades the contents of a config file in memory, so
+// configs written for older versions keep loading. The warning says how to
+// upgrade the file for good, and is empty when it is up to date.
+func migrateConfigData(path string, data []byte) ([]byte, string, error) {
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		// The error is reported when the config is decoded.
+		return data, "", nil
+	}
+	from, applied, err := migrateRawConfig(raw)
+	if err != nil {
+		return nil, "", fmt.Errorf("could not load '%s': %v", path, err)
+	}
+	if len(applied) == 0 {
+		return data, "", nil
+	}
+	migrated, err := json.Marshal(raw)
+	if err != nil {
+		return nil, "", err
+	}
+	return migrated, fmt.Sprintf("'%s' uses config version %d; run 'plate config migrate' to upgrade it to %d", path, from, currentConfigVersion()), nil
+}
+
+// migrateConfigFile upgrades the config file at path in place. It reports
+// the version the file had and what changed, which is nothing when it is
+// up to date.
+func migrateConfigFile(path string, dryRun bool) (from int, applied []string, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, nil, err
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return 0, nil, fmt.Errorf("could not parse '%s'. %v", path, err)
+	}
+	if from, applied, err = migrateRawConfig(raw); err != nil || len(applied) == 0 || dryRun {
+		return from, applied, err
+	}
+	return from, applied, writeRawConfig(path, raw)
+}
+
+// handleConfigMigrateCmd upgrades the config and its local override file
+// to the current schema version.
+func handleConfigMigrateCmd(args []string) {
+	fs := flag.NewFlagSet("config migrate", flag.ExitOnError)
+	dryRun := fs.Bool("dry-run", false, "show what would change without writing the files")
+	addConfigFlag(fs)
+	fs.Parse(args)
+	configPath := configPathArg(fs)
+	if !isFileSource(configPath) {
+		fmt.Println("Error: Only config files on disk can be migrated; ask the config's owner to run 'plate config migrate'.")
+		os.Exit(exitUsage)
+	}
+
+	paths := []string{configPath}
+	if _, err := os.Stat(localConfigPath(configPath)); err == nil {
+		paths = append(paths, localConfigPath(configPath))
+	}
+	for _, path := range paths {
+		from, applied, err := migrateConfigFile(path, *dryRun)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(exitConfig)
+		}
+		if len(applied) == 0 {
+			fmt.Printf("'%s' is at config version %d, the current one.\n", path, from)
+			continue
+		}
+		verb := "Migrated"
+		if *dryRun {
+			verb = "Would migrate"
+		}
+		fmt.Printf("%s '%s' from config version %d to %d:\n", verb, path, from, currentConfigVersion())
+		for _, describe := range applied {
+			fmt.Printf("  - %s\n", describe)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// withMigration registers a migration for version 2 that renames the
+// top-level "containerNames" to "naming", for the duration of the test.
+func withMigration(t *testing.T) {
+	orig := configMigrations
+	configMigrations = []configMigration{{
+		describe: `"containerNames" is now "naming"`,
+		migrate: func(raw map[string]any) {
+			if v, ok := raw["containerNames"]; ok {
+				raw["naming"] = v
+				delete(raw, "containerNames")
+			}
+		},
+	}}
+	t.Cleanup(func() { configMigrations = orig })
+}
+
+func TestMigrateRawConfig(t *testing.T) {
+	withMigration(t)
+	tests := []struct {
+		name        string
+		raw         map[string]any
+		wantFrom    int
+		wantApplied int
+		wantErr     bool
+	}{
+		{"unversioned is version 1", map[string]any{"containerNames": "x-{name}"}, 1, 1, false},
+		{"current", map[string]any{"version": float64(2)}, 2, 0, false},
+		{"newer than this Plate", map[string]any{"version": float64(3)}, 3, 0, true},
+		{"not a number", map[string]any{"version": "2"}, 0, 0, true},
+		{"not whole", map[string]any{"version": 1.5}, 0, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, applied, err := migrateRawConfig(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if from != tt.wantFrom || len(applied) != tt.wantApplied {
+				t.Errorf("Expected version %d and %d migrations, got %d and %v", tt.wantFrom, tt.wantApplied, from, applied)
+			}
+		})
+	}
+}
+
+func TestLoadConfigMigrates(t *testing.T) {
+	withMigration(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "plate.config.json")
+	os.WriteFile(path, []byte(`{"containerNames": "dev-{name}", "services": []}`), 0644)
+
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("Expected an old config to load, got %v", err)
+	}
+	if cfg.Naming != "dev-{name}" {
+		t.Errorf("Expected the migrated naming, got %q", cfg.Naming)
+	}
+	if len(cfg.warnings) != 1 || !strings.Contains(cfg.warnings[0], "plate config migrate") {
+		t.Errorf("Expected only a warning to migrate, got %q", cfg.warnings)
+	}
+
+	if _, applied, err := migrateConfigFile(path, true); err != nil || len(applied) != 1 {
+		t.Fatalf("Expected a dry run to list one migration, got %v (%v)", applied, err)
+	}
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), "containerNames") {
+		t.Errorf("Expected a dry run to leave the file alone, got %s", data)
+	}
+	if _, _, err := migrateConfigFile(path, false); err != nil {
+		t.Fatalf("Expected the migration to succeed, got %v", err)
+	}
+	cfg, err = loadConfig(path)
+	if err != nil || cfg.Version != 2 || cfg.Naming != "dev-{name}" || len(cfg.warnings) != 0 {
+		t.Errorf("Expected a clean version 2 config, got version %d, naming %q, warnings %q (%v)", cfg.Version, cfg.Naming, cfg.warnings, err)
+	}
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// --- CRASH LOOPS ---
+
+const (
+	// crashWindow is how long a container must stay up after a start not
+	// to count as crashed.
+	crashWindow = 5 * time.Second
+	// crashLoopLimit is how many crashes in a row Plate retries before it
+	// gives up and reports a crash loop.
+	crashLoopLimit = 3
+	// crashLogLines is how many of the last log lines a crash keeps.
+	crashLogLines = 10
+)
+
+// crashBackoff is how long Plate waits before starting a container again
+// after its nth crash in a row: 2s, 4s, 8s, and so on.
+func crashBackoff(crashes int) time.Duration {
+	return time.Second << crashes
+}
+
+// startCheckedMsg reports whether a container survived its crash window.
+type startCheckedMsg struct {
+	service     string
+	containerID string
+	exited      bool
+	exit        containerExit
+	logTail     []string
+}
+
+// crashRetryMsg starts a crashed container again once its backoff is over.
+type crashRetryMsg struct {
+	service     string
+	containerID string
+}
+
+// checkStartCmd looks at a just-started container once the crash window is
+// over, with the last lines of its logs if it has exited.
+func checkStartCmd(service string, containerID string) tea.Cmd {
+	return tea.Tick(crashWindow, func(time.Time) tea.Msg {
+		infos, err := inspectContainers(containerID)
+		if err != nil || len(infos) != 1 || infos[0].State == "running" {
+			return startCheckedMsg{service: service, containerID: containerID}
+		}
+		output := backend.logs(containerID, crashLogLines)
+		return startCheckedMsg{service: service, containerID: containerID, exited: true, exit: exitOf(infos[0]), logTail: logTail(output, crashLogLines)}
+	})
+}
+
+// crashRetryCmd schedules the next start after a crash.
+func crashRetryCmd(service string, containerID string, crashes int) tea.Cmd {
+	return tea.Tick(crashBackoff(crashes), func(time.Time) tea.Msg {
+		return crashRetryMsg{service: service, containerID: containerID}
+	})
+}
+
+// logTail returns the last n non-empty lines of output.
+func logTail(output string, n int) []string {
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimRight(line, "\r "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines
+}
+
+// handleStartChecked retries a container that crashed right after its
+// start, until it has crashed crashLoopLimit times in a row.
+func (m model) handleStartChecked(msg startCheckedMsg) (tea.Model, tea.Cmd) {
+	currentItem, ok := m.lookupItem(msg.service)
+	if !ok {
+		return m, nil
+	}
+	// Reconciling may have noticed the exit first and marked it stopped.
+	waiting := currentItem.status == statusRunning || currentItem.status == statusStopped
+	if currentItem.containerID != msg.containerID || !waiting || !currentItem.checkingStart {
+		return m, nil
+	}
+	currentItem.checkingStart = false
+	if !msg.exited {
+		currentItem.crashes = 0
+		return m, m.setItem(currentItem.index, currentItem)
+	}
+	currentItem.crashes++
+	currentItem.exit, currentItem.crashLog = &msg.exit, msg.logTail
+	if currentItem.crashes >= crashLoopLimit {
+		currentItem.status = statusCrashLooping
+		currentItem.statusText = fmt.Sprintf("exited with code %d %d times in a row", msg.exit.code, currentItem.crashes)
+		return m, m.setItem(currentItem.index, currentItem)
+	}
+	currentItem.status = statusStarting
+	return m, tea.Batch(m.setItem(currentItem.index, currentItem), crashRetryCmd(msg.service, msg.containerID, currentItem.crashes))
+}
+
+// handleCrashRetry starts a crashed container again, unless something else
+// happened to it during the backoff.
+func (m model) handleCrashRetry(msg crashRetryMsg) (tea.Model, tea.Cmd) {
+	currentItem, ok := m.lookupItem(msg.service)
+	if !ok {
+		return m, nil
+	}
+	if currentItem.containerID != msg.containerID || currentItem.status != statusStarting || m.quitting {
+		return m, nil
+	}
+	return m, restartContainerCmd(msg.service, currentItem.config, msg.containerID, m.slots.starts)
+}
+
+// renderCrashLoop describes a crash loop in the detail pane.
+func renderCrashLoop(it item) string {
+	var b strings.Builder
+	if it.exit != nil {
+		b.WriteString(fmt.Sprintf("\n%s: %s\n", detailAttrStyle.Render("Last Exit"), errorStyle.Render(it.exit.String())))
+	}
+	if len(it.crashLog) > 0 {
+		b.WriteString(fmt.Sprintf("%s:\n%s\n", detailAttrStyle.Render("Last Log Lines"), stoppedStyle.Render(strings.Join(it.crashLog, "\n"))))
+	}
+	hint := fmt.Sprintf("Plate stopped retrying after %d crashes. Fix the cause, then press b to try again.", it.crashes)
+	if it.exit != nil && it.exit.oomKilled {
+		hint = memoryHint(it.config)
+	}
+	b.WriteString(fmt.Sprintf("\n%s\n", helpStyle.Render(hint)))
+	return b.String()
+}
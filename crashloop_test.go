@@ -0,0 +1,48 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+func TestCrashLoop(t *testing.T) {
+	svc := item{config: ServiceConfig{Type: "postgres", Name: "db"}, status: statusRunning, containerID: "abc", checkingStart: true}
+	m := model{items: []list.Item{svc}}
+	crash := startCheckedMsg{service: "db", containerID: "abc", exited: true, exit: containerExit{code: 1}, logTail: []string{"FATAL: bad config"}}
+
+	for i := 1; i < crashLoopLimit; i++ {
+		next, cmd := m.handleStartChecked(crash)
+		m = next.(model)
+		it := m.items[0].(item)
+		if it.status != statusStarting || it.crashes != i || cmd == nil {
+			t.Fatalf("Crash %d: Expected a retry, got status %v after %d crashes", i, it.status, it.crashes)
+		}
+		// The retried container starts, and is checked again.
+		it.status, it.checkingStart = statusRunning, true
+		m.items[0] = it
+	}
+	next, _ := m.handleStartChecked(crash)
+	it := next.(model).items[0].(item)
+	if it.status != statusCrashLooping || it.exit == nil || it.exit.code != 1 || !slices.Equal(it.crashLog, crash.logTail) {
+		t.Errorf("Expected a crash loop with the exit code and logs, got %+v", it)
+	}
+
+	// A container that was stopped on purpose during the window didn't crash.
+	m = model{items: []list.Item{item{config: svc.config, status: statusStopped, containerID: "abc"}}}
+	next, _ = m.handleStartChecked(crash)
+	if it := next.(model).items[0].(item); it.crashes != 0 || it.status != statusStopped {
+		t.Errorf("Expected a stopped container to be left alone, got %+v", it)
+	}
+}
+
+func TestLogTail(t *testing.T) {
+	got := logTail("one\ntwo\r\n\nthree\nfour\n", 3)
+	if !slices.Equal(got, []string{"two", "three", "four"}) {
+		t.Errorf("Expected the last 3 non-empty lines, got %q", got)
+	}
+	if crashBackoff(1) != 2*crashBackoff(0) || crashBackoff(2) != 2*crashBackoff(1) {
+		t.Errorf("Expected the backoff to double, got %s, %s, %s", crashBackoff(0), crashBackoff(1), crashBackoff(2))
+	}
+}
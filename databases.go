@@ -0,0 +1,230 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// --- DATABASES ---
+
+// defaultDatabasePassword is the password of database users that don't set
+// one, matching the instance's own password.
+const defaultDatabasePassword = "mysecretpassword"
+
+// databaseIdentPattern matches the database and user names Plate creates,
+// which are used unquoted (and so lowercase) in SQL.
+var databaseIdentPattern = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// DatabaseConfig is a database Plate creates inside a postgres or mysql
+// service, so several apps can share one container.
+type DatabaseConfig struct {
+	Name string `json:"name"`
+	// User, if set, is created with Password and owns the database.
+	User     string `json:"user,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+func (d DatabaseConfig) password() string {
+	if d.Password == "" {
+		return defaultDatabasePassword
+	}
+	return d.Password
+}
+
+// validateDatabases checks the databases of every service.
+func validateDatabases(cfg PlateConfig) error {
+	for _, svc := range cfg.Services {
+		if len(svc.Databases) == 0 {
+			continue
+		}
+		if svc.Type != "postgres" && svc.Type != "mysql" {
+			return fmt.Errorf("service %s: databases are supported for postgres and mysql, not %s", svc.Name, svc.Type)
+		}
+		seen := map[string]bool{}
+		for _, db := range svc.Databases {
+			if !databaseIdentPattern.MatchString(db.Name) {
+				return fmt.Errorf("service %s: invalid database name '%s' (use lowercase letters, digits and _)", svc.Name, db.Name)
+			}
+			if seen[db.Name] {
+				return fmt.Errorf("service %s: database '%s' is listed twice", svc.Name, db.Name)
+			}
+			seen[db.Name] = true
+			if db.User != "" && !databaseIdentPattern.MatchString(db.User) {
+				return fmt.Errorf("service %s: invalid user name '%s' (use lowercase letters, digits and _)", svc.Name, db.User)
+			}
+			if strings.ContainsAny(db.Password, `'\`) {
+				return fmt.Errorf("service %s: the password of '%s' can't contain quotes or backslashes", svc.Name, db.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// databaseURL turns the service's connection string into one for db.
+func databaseURL(connStr string, db DatabaseConfig) string {
+	u, err := url.Parse(connStr)
+	if err != nil {
+		return connStr
+	}
+	u.Path = "/" + db.Name
+	if db.User != "" {
+		u.User = url.UserPassword(db.User, db.password())
+	}
+	return u.String()
+}
+
+// databaseEnvVarName returns the variable db's connection string is
+// exported as, e.g. PLATE_MAIN_DB_ORDERS_URL for "orders" in "main-db".
+func databaseEnvVarName(config ServiceConfig, db DatabaseConfig) string {
+	return envVarNameFor(config, envKey(config.Name)+"_"+strings.ToUpper(db.Name))
+}
+
+// connectionEnv returns KEY=value pairs with the service's connection
+// string and those of its databases.
+func connectionEnv(config ServiceConfig, connStr string) []string {
+	env := []string{envVarName(config) + "=" + connStr}
+	for _, db := range config.Databases {
+		env = append(env, databaseEnvVarName(config, db)+"="+databaseURL(connStr, db))
+	}
+	for _, u := range config.Users {
+		env = append(env, redisUserEnvVarName(config, u)+"="+redisUserURL(connStr, u))
+	}
+	if isIdentityProvider(config) {
+		env = append(env, issuerEnvVarName(config)+"="+issuerURL(config, connStr))
+	}
+	return env
+}
+
+// postgresDatabasesSQL creates the databases and users unless they exist.
+// CREATE DATABASE can't run in a DO block, hence \gexec.
+func postgresDatabasesSQL(databases []DatabaseConfig) string {
+	var b strings.Builder
+	for _, db := range databases {
+		if db.User != "" {
+			b.WriteString(fmt.Sprintf("SELECT 'CREATE ROLE %s LOGIN PASSWORD ''%s''' WHERE NOT EXISTS (SELECT FROM pg_roles WHERE rolname = '%s')\\gexec\n", db.User, db.password(), db.User))
+		}
+		owner := "postgres"
+		if db.User != "" {
+			owner = db.User
+		}
+		b.WriteString(fmt.Sprintf("SELECT 'CREATE DATABASE %s OWNER %s' WHERE NOT EXISTS (SELECT FROM pg_database WHERE datname = '%s')\\gexec\n", db.Name, owner, db.Name))
+	}
+	return b.String()
+}
+
+// mysqlDatabasesSQL creates the databases and users unless they exist.
+func mysqlDatabasesSQL(databases []DatabaseConfig) string {
+	var b strings.Builder
+	for _, db := range databases {
+		b.WriteString(fmt.Sprintf("CREATE DATABASE IF NOT EXISTS `%s`;\n", db.Name))
+		if db.User != "" {
+			b.WriteString(fmt.Sprintf("CREATE USER IF NOT EXISTS '%s'@'%%' IDENTIFIED BY '%s';\n", db.User, db.password()))
+			b.WriteString(fmt.Sprintf("GRANT ALL PRIVILEGES ON `%s`.* TO '%s'@'%%';\n", db.Name, db.User))
+		}
+	}
+	return b.String()
+}
+
+// execSQL runs SQL inside the service's container, retrying while the
+// server is still starting.
+func execSQL(config ServiceConfig, containerID, database, sql string, poll time.Duration) error {
+	command := []string{"psql", "-U", "postgres", "-d", database, "-v", "ON_ERROR_STOP=1"}
+	if config.Type == "mysql" {
+		command = []string{"mysql", "-uroot", "-pmysecretpassword"}
+	}
+	deadline := time.Now().Add(readyTimeout)
+	for {
+		output, err := backend.exec(containerID, strings.NewReader(sql), command...)
+		if err == nil {
+			return nil
+		}
+		out := strings.TrimSpace(string(output))
+		if !databaseStarting(out) || time.Now().After(deadline) {
+			if out != "" {
+				return fmt.Errorf("%s", lastLine(out))
+			}
+			return err
+		}
+		time.Sleep(poll)
+	}
+}
+
+// databaseStarting reports whether a client failed because the server isn't
+// up yet, or is restarting after the image's init scripts ran.
+func databaseStarting(output string) bool {
+	return strings.Contains(output, "could not connect") || strings.Contains(output, "the database system is") ||
+		strings.Contains(output, "Can't connect")
+}
+
+// needsSetup reports whether the service has databases, extensions, users,
+// or a replica set to set up after it starts.
+func needsSetup(config ServiceConfig) bool {
+	return len(config.Databases) > 0 || len(config.Extensions) > 0 || len(config.Users) > 0 || config.ReplicaSet
+}
+
+// prepareService waits for a database service to accept connections, then
+// initiates its replica set and creates its databases, extensions, and
+// users. Existing ones are left alone (redis users are updated), so it runs
+// after every start.
+func prepareService(config ServiceConfig, containerID string, poll time.Duration) error {
+	if !needsSetup(config) {
+		return nil
+	}
+	if err := waitForService(config, containerID, poll); err != nil {
+		return err
+	}
+	if config.ReplicaSet {
+		if err := initiateReplicaSet(containerID, poll); err != nil {
+			return fmt.Errorf("replica set: %w", err)
+		}
+	}
+	if len(config.Databases) > 0 {
+		sql := mysqlDatabasesSQL(config.Databases)
+		if config.Type == "postgres" {
+			sql = postgresDatabasesSQL(config.Databases)
+		}
+		if err := execSQL(config, containerID, "postgres", sql, poll); err != nil {
+			return fmt.Errorf("databases: %w", err)
+		}
+	}
+	if len(config.Extensions) > 0 {
+		// Extensions are per database.
+		for _, database := range append([]string{"postgres"}, databaseNames(config)...) {
+			if err := execSQL(config, containerID, database, extensionsSQL(config.Extensions), poll); err != nil {
+				return fmt.Errorf("extensions: %w", err)
+			}
+		}
+	}
+	if len(config.Users) > 0 {
+		if err := createRedisUsers(config, containerID, poll); err != nil {
+			return fmt.Errorf("users: %w", err)
+		}
+	}
+	return nil
+}
+
+// databaseNames returns the names of the service's databases.
+func databaseNames(config ServiceConfig) []string {
+	names := make([]string, len(config.Databases))
+	for i, db := range config.Databases {
+		names[i] = db.Name
+	}
+	return names
+}
+
+type servicePreparedMsg struct {
+	service string
+	err     error
+}
+
+// prepareServiceCmd runs prepareService for a started container.
+func prepareServiceCmd(service string, config ServiceConfig, containerID string, poll time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		return servicePreparedMsg{service: service, err: prepareService(config, containerID, poll)}
+	}
+}
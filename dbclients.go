@@ -0,0 +1,143 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// --- DATABASE CLIENT EXPORTS ---
+
+// clientConnection is one connection a database GUI gets: a service, or one
+// of its extra databases.
+type clientConnection struct {
+	id       string // stable across exports, so re-importing replaces it
+	name     string
+	typ      string
+	host     string
+	port     int
+	user     string
+	password string
+	database string
+	tls      bool
+}
+
+// clientConnections lists the connections of the config's container
+// services whose type is in drivers, in config order.
+func clientConnections(cfg PlateConfig, drivers map[string]string) []clientConnection {
+	data := renderDataFor(cfg)
+	var conns []clientConnection
+	for _, svc := range cfg.containerServices() {
+		s, ok := data.Services[svc.Name]
+		if _, supported := drivers[svc.Type]; !ok || !supported {
+			continue
+		}
+		base := clientConnection{
+			id: "plate-" + cfg.Project + "-" + svc.Name, name: cfg.Project + "/" + svc.Name, typ: svc.Type,
+			host: s.Host, port: s.Port, user: s.User, password: s.Password, database: s.Database, tls: svc.TLS,
+		}
+		conns = append(conns, base)
+		for _, db := range svc.Databases {
+			d := s.Databases[db.Name]
+			conn := base
+			conn.id += "-" + db.Name
+			conn.name += "/" + db.Name
+			conn.user, conn.password, conn.database = d.User, d.Password, d.Name
+			conns = append(conns, conn)
+		}
+	}
+	return conns
+}
+
+// tablePlusDrivers maps service types to TablePlus drivers.
+var tablePlusDrivers = map[string]string{
+	"postgres": "PostgreSQL",
+	"mysql":    "MySQL",
+	"redis":    "Redis",
+	"mongodb":  "MongoDB",
+}
+
+// renderTablePlus renders the connections as a .tableplusconnection file,
+// which TablePlus imports with File > Import > Connections.
+func renderTablePlus(cfg PlateConfig) []byte {
+	var entries []map[string]any
+	for _, c := range clientConnections(cfg, tablePlusDrivers) {
+		entry := map[string]any{
+			"ID":               c.id,
+			"ConnectionName":   c.name,
+			"Driver":           tablePlusDrivers[c.typ],
+			"DatabaseHost":     c.host,
+			"DatabasePort":     strconv.Itoa(c.port),
+			"DatabaseUser":     c.user,
+			"DatabasePassword": c.password,
+			"DatabaseName":     c.database,
+			// TablePlus spells it this way.
+			"Enviroment": "local",
+		}
+		if c.tls {
+			entry["TLSMode"] = 1
+		}
+		entries = append(entries, entry)
+	}
+	data, _ := json.MarshalIndent(entries, "", "  ")
+	return append(data, '\n')
+}
+
+// dbeaverDrivers maps service types to DBeaver provider/driver ids. The
+// community edition has no Redis or MongoDB drivers.
+var dbeaverDrivers = map[string]string{
+	"postgres": "postgresql/postgres-jdbc",
+	"mysql":    "mysql/mysql8",
+}
+
+// renderDBeaver renders the connections as a DBeaver data-sources.json,
+// which DBeaver reads from a project's .dbeaver directory.
+func renderDBeaver(cfg PlateConfig) []byte {
+	connections := map[string]any{}
+	for _, c := range clientConnections(cfg, dbeaverDrivers) {
+		provider, driver, _ := strings.Cut(dbeaverDrivers[c.typ], "/")
+		// The provider ids double as the JDBC URL schemes.
+		url := fmt.Sprintf("jdbc:%s://%s:%d/%s", provider, c.host, c.port, c.database)
+		connections[c.id] = map[string]any{
+			"provider":      provider,
+			"driver":        driver,
+			"name":          c.name,
+			"save-password": true,
+			"configuration": map[string]any{
+				"host":       c.host,
+				"port":       strconv.Itoa(c.port),
+				"database":   c.database,
+				"url":        url,
+				"user":       c.user,
+				"password":   c.password,
+				"type":       "dev",
+				"auth-model": "native",
+			},
+		}
+	}
+	data, _ := json.MarshalIndent(map[string]any{"folders": map[string]any{}, "connections": connections}, "", "  ")
+	return append(data, '\n')
+}
+
+// handleExportClientCmd writes the connection file of a database GUI.
+func handleExportClientCmd(target, defaultPath string, drivers map[string]string, render func(PlateConfig) []byte, args []string) {
+	fs := flag.NewFlagSet("export "+target, flag.ExitOnError)
+	addConfigFlag(fs)
+	output := fs.String("o", defaultPath, "file to write")
+	force := fs.Bool("force", false, "overwrite an existing file")
+	fs.Parse(args)
+	plateConfig := mustLoadConfig(configPathArg(fs))
+
+	if len(clientConnections(plateConfig, drivers)) == 0 {
+		fmt.Printf("Error: The config has no services %s can connect to.\n", target)
+		os.Exit(1)
+	}
+	if err := writeExportFile(*output, render(plateConfig), *force); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("✅ Wrote '%s'.\n", *output)
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// --- DEBUG TOOLS ---
+
+// The "mailpit" service type catches the mail an app sends over SMTP, and
+// "webhook-capture" records the webhooks sent to it. Both are looked at in
+// their web UIs, which the detail pane lists under Tools.
+const (
+	mailpitSMTPPort = 1025
+	mailpitUIPort   = 8025
+	webhookPort     = 8080
+)
+
+// mailpitSpec describes the container of a "mailpit" service. Its port is
+// the SMTP server, and the web UI is published on uiPort, 8025 by default.
+func mailpitSpec(config ServiceConfig) serviceSpec {
+	uiHostPort := config.UIPort
+	if uiHostPort == 0 {
+		uiHostPort = mailpitUIPort
+	}
+	return serviceSpec{
+		Image:         "axllent/mailpit",
+		ContainerPort: mailpitSMTPPort,
+		DataDir:       "/data",
+		// Keeps the caught mail with the container's data.
+		Env:        []string{"MP_DATABASE=/data/mailpit.db", "MP_SMTP_AUTH_ACCEPT_ANY=true", "MP_SMTP_AUTH_ALLOW_INSECURE=true"},
+		UIPort:     mailpitUIPort,
+		ExtraPorts: map[int]int{mailpitUIPort: uiHostPort},
+	}
+}
+
+// webhookCaptureSpec describes the container of a "webhook-capture"
+// service, which takes webhooks and shows them on the same port.
+func webhookCaptureSpec(config ServiceConfig) serviceSpec {
+	return serviceSpec{
+		Image:         "tarampampam/webhook-tester",
+		ContainerPort: webhookPort,
+		UIPort:        webhookPort,
+	}
+}
+
+// toolLines lists the web UIs of the running services, one line with the
+// name and URL each.
+func toolLines(items []list.Item) []string {
+	var lines []string
+	for _, itm := range items {
+		it := itm.(item)
+		if it.status != statusRunning {
+			continue
+		}
+		if ui := serviceUIURL(it.config); ui != "" {
+			lines = append(lines, fmt.Sprintf("%s %s", detailValStyle.Render(it.config.Name), successStyle.Render(ui)))
+		}
+	}
+	return lines
+}
+
+// renderTools renders the Tools section of the detail pane, or "" when no
+// running service has a web UI.
+func (m model) renderTools() string {
+	lines := toolLines(m.items)
+	if len(lines) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("\n%s:\n%s\n", detailAttrStyle.Render("Tools"), strings.Join(lines, "\n"))
+}
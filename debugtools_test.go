@@ -0,0 +1,38 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+func TestDebugToolServices(t *testing.T) {
+	mail := ServiceConfig{Type: "mailpit", Name: "mail", Version: "latest", Port: 1026, UIPort: 8026}
+	connStr, args, err := getDockerRunArgs(mail, "plate-mailpit-mail")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if connStr != "smtp://localhost:1026" {
+		t.Errorf("Expected an smtp URL, got %s", connStr)
+	}
+	if !slices.Contains(args, "1026:1025") || !slices.Contains(args, "8026:8025") {
+		t.Errorf("Expected the SMTP and UI ports published, got %v", args)
+	}
+
+	hooks := ServiceConfig{Type: "webhook-capture", Name: "hooks", Version: "latest", Port: 8090}
+	if got := serviceUIURL(hooks); got != "http://localhost:8090" {
+		t.Errorf("Expected the webhook UI on the service's port, got %s", got)
+	}
+
+	items := []list.Item{
+		item{config: mail, status: statusRunning},
+		item{config: hooks, status: statusStopped},
+		item{config: ServiceConfig{Type: "postgres", Name: "db", Port: 5433}, status: statusRunning},
+	}
+	lines := toolLines(items)
+	if len(lines) != 1 || !strings.Contains(lines[0], "http://localhost:8026") {
+		t.Errorf("Expected only the running mailpit's UI, got %v", lines)
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// --- DEMO MODE ---
+
+// `plate --demo` runs the TUI on a built-in project against a fake runtime
+// with fixed timings, so the UI can be explored, and screenshots recorded,
+// without docker. The same things happen at the same moments every run.
+
+// demoConfig is the project the demo shows.
+const demoConfig = `{
+  "project": "demo-shop",
+  "services": [
+    { "type": "postgres", "name": "main-db", "version": "16", "port": 5432 },
+    { "type": "redis", "name": "cache", "version": "7", "port": 6379 },
+    { "type": "mysql", "name": "legacy-db", "version": "8.0", "port": 3306 },
+    { "type": "mongodb", "name": "docs", "version": "7", "port": 27017 }
+  ]
+}
+`
+
+// demoPullTime is how long every demo image takes to download.
+const demoPullTime = 3 * time.Second
+
+// demoDelays are how long the other demo operations take.
+var demoDelays = map[string]time.Duration{
+	"pull":  demoPullTime,
+	"run":   800 * time.Millisecond,
+	"start": 500 * time.Millisecond,
+	"stop":  time.Second,
+	"rm":    300 * time.Millisecond,
+	"log":   250 * time.Millisecond,
+}
+
+// demoOutput is what the demo containers log when they start, by type.
+var demoOutput = map[string][]string{
+	"postgres": {
+		"PostgreSQL init process complete; ready for start up.",
+		"LOG:  starting PostgreSQL 16.4 on x86_64-pc-linux-gnu",
+		"LOG:  listening on IPv4 address \"0.0.0.0\", port 5432",
+		"LOG:  database system is ready to accept connections",
+	},
+	"redis": {
+		"# oO0OoO0OoO0Oo Redis is starting oO0OoO0OoO0Oo",
+		"# Server initialized",
+		"* Ready to accept connections tcp",
+	},
+	"mysql": {
+		"[System] [MY-010116] [Server] /usr/sbin/mysqld (mysqld 8.0.39) starting as process 1",
+		"[System] [MY-013576] [InnoDB] InnoDB initialization has started.",
+		"[System] [MY-010931] [Server] /usr/sbin/mysqld: ready for connections. Version: '8.0.39'  port: 3306",
+	},
+}
+
+// errDemoPortTaken is how the demo's docs service fails to start.
+var errDemoPortTaken = errors.New("Bind for 0.0.0.0:27017 failed: port is already allocated")
+
+// newDemoRuntime scripts a fake runtime for the demo project: cache's image
+// is already there, the others take demoPullTime to pull, and docs fails to
+// start because its port is taken.
+func newDemoRuntime(cfg PlateConfig) *fakeRuntime {
+	f := newFakeRuntime()
+	f.delays = demoDelays
+	for _, svc := range cfg.Services {
+		switch svc.Name {
+		case "cache":
+			f.images[imageName(svc)] = true
+		case "docs":
+			f.fail["run "+containerName(svc)] = errDemoPortTaken
+		}
+		f.output[containerName(svc)] = demoOutput[svc.Type]
+	}
+	return f
+}
+
+// startDemo moves to a scratch directory holding the demo config, so the
+// demo leaves no state behind, and switches to the demo runtime. cleanup
+// removes the directory again.
+func startDemo() (cfg PlateConfig, cleanup func(), err error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return cfg, nil, err
+	}
+	dir, err := os.MkdirTemp("", "plate-demo-")
+	if err != nil {
+		return cfg, nil, err
+	}
+	cleanup = func() {
+		os.Chdir(wd)
+		os.RemoveAll(dir)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "plate.config.json"), []byte(demoConfig), 0644); err != nil {
+		cleanup()
+		return cfg, nil, err
+	}
+	if err := os.Chdir(dir); err != nil {
+		cleanup()
+		return cfg, nil, err
+	}
+	if cfg, err = loadConfig("plate.config.json"); err != nil {
+		cleanup()
+		return cfg, nil, err
+	}
+	backend = newDemoRuntime(cfg)
+	return cfg, cleanup, nil
+}
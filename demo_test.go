@@ -0,0 +1,60 @@
+package main
+
+import (
+	"maps"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/exp/teatest"
+)
+
+func TestDemo(t *testing.T) {
+	t.Chdir(t.TempDir())
+	orig := backend
+	t.Cleanup(func() { backend = orig })
+	wd, _ := os.Getwd()
+
+	cfg, cleanup, err := startDemo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if now, _ := os.Getwd(); now == wd {
+		t.Errorf("Expected the demo to run in a scratch directory")
+	}
+	t.Cleanup(cleanup)
+	fake, ok := backend.(*fakeRuntime)
+	if !ok {
+		t.Fatalf("Expected the demo to use the fake runtime, got %T", backend)
+	}
+	// Recorded at full speed, the demo takes too long for a test.
+	fake.delays = map[string]time.Duration{}
+
+	tm := teatest.NewTestModel(t, initialModel(cfg, false), teatest.WithInitialTermSize(120, 40))
+	waitForScreen(t, tm, "🔥 Error: Bind for 0.0.0.0:27017")
+	// The status line's snapshot settles once every service is up or failed.
+	want := map[string]string{"main-db": "running", "cache": "running", "legacy-db": "running", "docs": "error"}
+	got := map[string]string{}
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline) && !maps.Equal(got, want); time.Sleep(20 * time.Millisecond) {
+		st, _ := readLiveStatus()
+		clear(got)
+		for _, svc := range st.Services {
+			got[svc.Name] = svc.Status
+		}
+	}
+	if !maps.Equal(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	tm.Quit()
+	tm.WaitFinished(t, teatest.WithFinalTimeout(5*time.Second))
+	for _, svc := range cfg.Services {
+		if pulled := fake.called("pull " + imageName(svc)); pulled == (svc.Name == "cache") {
+			t.Errorf("%s: Expected only cache's image to be present already, pulled=%v", svc.Name, pulled)
+		}
+	}
+
+	cleanup()
+	if now, _ := os.Getwd(); now != wd {
+		t.Errorf("Expected cleanup to return to %s, got %s", wd, now)
+	}
+}
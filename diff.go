@@ -0,0 +1,244 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// --- CONFIG VS. DOCKER DIFF ---
+
+// changeKind describes what it would take to bring a service in line with the config.
+type changeKind int
+
+const (
+	changeNone     changeKind = iota // container matches the config
+	changeCreate                     // no container exists yet
+	changeRecreate                   // container exists but has drifted
+	changeRemove                     // container has no matching service
+	changeConflict                   // the name is taken by a container Plate doesn't manage
+	changeRename                     // container of a renamed service, to be linked to it
+)
+
+// serviceChange is one line item of a diff.
+type serviceChange struct {
+	kind      changeKind
+	service   ServiceConfig
+	container *containerInfo
+	drift     []string // human-readable "field: actual → desired" lines
+}
+
+// label returns the name a change is displayed under.
+func (c serviceChange) label() string {
+	if c.kind == changeRemove {
+		return c.container.Name
+	}
+	return c.service.Name
+}
+
+// serviceContainers maps service names to their containers. Containers
+// labelled (or adopted) for a service win over plain name matches.
+func serviceContainers(cfg PlateConfig, containers []containerInfo) map[string]*containerInfo {
+	byName := make(map[string]*containerInfo, len(containers))
+	byService := make(map[string]*containerInfo, len(containers))
+	for i := range containers {
+		byName[containers[i].Name] = &containers[i]
+		if containers[i].managed() && containers[i].Labels[labelProject] == cfg.Project {
+			byService[containers[i].Labels[labelService]] = &containers[i]
+		}
+	}
+
+	matched := map[string]*containerInfo{}
+	for _, svc := range cfg.Services {
+		if svc.isProcess() {
+			continue
+		}
+		ctr, ok := byName[containerName(svc)]
+		if adopted, isAdopted := byService[svc.Name]; isAdopted && (!ok || !ctr.managed()) {
+			ctr, ok = adopted, true
+		}
+		if ok {
+			matched[svc.Name] = ctr
+		}
+	}
+	return matched
+}
+
+// computeDiff compares the desired config against the actual containers.
+func computeDiff(cfg PlateConfig, containers []containerInfo) []serviceChange {
+	matched := serviceContainers(cfg, containers)
+
+	var changes []serviceChange
+	claimed := map[string]bool{}
+	for _, svc := range cfg.Services {
+		if svc.isProcess() {
+			continue
+		}
+		ctr, ok := matched[svc.Name]
+		if svc.Disabled {
+			// A locally disabled service keeps its container; it just isn't managed.
+			if ok {
+				claimed[ctr.Name] = true
+			}
+			continue
+		}
+		if !ok {
+			changes = append(changes, serviceChange{kind: changeCreate, service: svc})
+			continue
+		}
+		claimed[ctr.Name] = true
+		if !ctr.managed() {
+			changes = append(changes, serviceChange{kind: changeConflict, service: svc, container: ctr})
+			continue
+		}
+		change := serviceChange{kind: changeNone, service: svc, container: ctr, drift: detectDrift(svc, *ctr)}
+		if len(change.drift) > 0 {
+			change.kind = changeRecreate
+		}
+		changes = append(changes, change)
+	}
+
+	// A new service and an orphan running its image are most likely the
+	// same service under a new name, so link them instead of starting empty.
+	for i, c := range changes {
+		if c.kind != changeCreate {
+			continue
+		}
+		var orphans []containerInfo
+		for _, ctr := range containers {
+			if !claimed[ctr.Name] {
+				orphans = append(orphans, ctr)
+			}
+		}
+		if ctr, ok := findRenamedContainer(cfg, c.service, orphans); ok {
+			for j := range containers {
+				if containers[j].ID == ctr.ID {
+					changes[i] = serviceChange{kind: changeRename, service: c.service, container: &containers[j]}
+					claimed[ctr.Name] = true
+				}
+			}
+		}
+	}
+
+	for i := range containers {
+		ctr := &containers[i]
+		if claimed[ctr.Name] || !ctr.managed() || ctr.Labels[labelProject] != cfg.Project {
+			continue
+		}
+		changes = append(changes, serviceChange{kind: changeRemove, container: ctr})
+	}
+	return changes
+}
+
+// detectDrift lists the differences between a service and its container.
+func detectDrift(svc ServiceConfig, ctr containerInfo) []string {
+	spec, err := getServiceSpec(svc)
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	var drift []string
+	if ctr.Image != spec.Image {
+		drift = append(drift, fmt.Sprintf("image: %s → %s", ctr.Image, spec.Image))
+	}
+	if hostPort := ctr.Ports[spec.ContainerPort]; hostPort != svc.Port {
+		drift = append(drift, fmt.Sprintf("port: %d → %d", hostPort, svc.Port))
+	}
+	for _, port := range sortedPorts(spec.ExtraPorts) {
+		if hostPort := ctr.Ports[port]; hostPort != spec.ExtraPorts[port] {
+			drift = append(drift, fmt.Sprintf("port %d: %d → %d", port, hostPort, spec.ExtraPorts[port]))
+		}
+	}
+	if hasTLS := ctr.Labels[labelTLS] == "true"; hasTLS != svc.TLS {
+		drift = append(drift, fmt.Sprintf("tls: %s → %s", onOff(hasTLS), onOff(svc.TLS)))
+	}
+	wantIP := ""
+	if ip := net.ParseIP(svc.Host); ip != nil {
+		wantIP = ip.String()
+	}
+	if gotIP := ctr.BindIPs[spec.ContainerPort]; gotIP != wantIP {
+		drift = append(drift, fmt.Sprintf("bind address: %s → %s", orAll(gotIP), orAll(wantIP)))
+	}
+
+	actual := make(map[string]string, len(ctr.Env))
+	for _, kv := range ctr.Env {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			actual[k] = v
+		}
+	}
+	for _, kv := range spec.Env {
+		k, want, _ := strings.Cut(kv, "=")
+		got, ok := actual[k]
+		switch {
+		case !ok:
+			drift = append(drift, fmt.Sprintf("env %s: (unset) → %q", k, want))
+		case got != want:
+			drift = append(drift, fmt.Sprintf("env %s: %q → %q", k, got, want))
+		}
+	}
+	return drift
+}
+
+// onOff names a boolean setting.
+func onOff(b bool) string {
+	if b {
+		return "on"
+	}
+	return "off"
+}
+
+// orAll names an empty bind address.
+func orAll(ip string) string {
+	if ip == "" {
+		return "all interfaces"
+	}
+	return ip
+}
+
+// hasChanges reports whether applying the diff would do anything.
+func hasChanges(changes []serviceChange) bool {
+	for _, c := range changes {
+		if c.kind != changeNone {
+			return true
+		}
+	}
+	return false
+}
+
+// renderDiff formats a diff as +/- lines.
+func renderDiff(changes []serviceChange) string {
+	var b strings.Builder
+	var creates, recreates, removes, renames int
+	for _, c := range changes {
+		switch c.kind {
+		case changeCreate:
+			creates++
+			spec, _ := getServiceSpec(c.service)
+			b.WriteString(successStyle.Render(fmt.Sprintf("+ %s (%s on port %d)", c.label(), spec.Image, c.service.Port)))
+		case changeRecreate:
+			recreates++
+			b.WriteString(pendingStyle.Render(fmt.Sprintf("~ %s (%s)", c.label(), c.container.Name)))
+			for _, d := range c.drift {
+				b.WriteString("\n" + pendingStyle.Render("    "+d))
+			}
+		case changeRename:
+			renames++
+			b.WriteString(pendingStyle.Render(fmt.Sprintf("→ %s (renamed from %s, keeps %s and its data)", c.label(), c.container.Labels[labelService], c.container.Name)))
+		case changeRemove:
+			removes++
+			b.WriteString(errorStyle.Render(fmt.Sprintf("- %s (orphaned, %s)", c.label(), c.container.Image)))
+		case changeConflict:
+			b.WriteString(errorStyle.Render(fmt.Sprintf("! %s (name taken by external container %s, adopt or rename it in the TUI)", c.label(), c.container.Name)))
+		default:
+			b.WriteString(stoppedStyle.Render(fmt.Sprintf("  %s (in sync, %s)", c.label(), c.container.State)))
+		}
+		b.WriteString("\n")
+	}
+	if !hasChanges(changes) {
+		b.WriteString("\nNo changes. Containers match the config.")
+		return b.String()
+	}
+	b.WriteString(fmt.Sprintf("\n%d to create, %d to recreate, %d to link, %d to remove.", creates, recreates, renames, removes))
+	b.WriteString("\nRun 'plate apply' to converge (add --prune to remove orphans).")
+	return b.String()
+}
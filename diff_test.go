@@ -0,0 +1,122 @@
+package main
+
+import "testing"
+
+func TestComputeDiff(t *testing.T) {
+	cfg := PlateConfig{
+		Project: "shop",
+		Services: []ServiceConfig{
+			{Type: "postgres", Name: "main-db", Version: "16", Port: 5433},
+			{Type: "redis", Name: "cache", Version: "7", Port: 6380},
+			{Type: "mongodb", Name: "docs", Version: "latest", Port: 27017},
+			{Type: "mysql", Name: "orders", Version: "8", Port: 3307},
+			{Type: "process", Name: "web", Command: "npm run dev"},
+		},
+	}
+	containers := []containerInfo{
+		{
+			Name:   "plate-postgres-main-db",
+			Image:  "postgres:14-alpine",
+			State:  "running",
+			Labels: map[string]string{labelManaged: "true", labelProject: "shop"},
+			Env:    []string{"POSTGRES_PASSWORD=mysecretpassword", "PATH=/usr/bin"},
+			Ports:  map[int]int{5432: 5432},
+		},
+		{
+			Name:   "plate-redis-cache",
+			Image:  "redis:7",
+			State:  "exited",
+			Labels: map[string]string{labelManaged: "true", labelProject: "shop"},
+			Ports:  map[int]int{6379: 6380},
+		},
+		{
+			Name:  "plate-mysql-orders",
+			Image: "mysql:8",
+		},
+		{
+			Name:   "plate-mysql-legacy",
+			Image:  "mysql:8",
+			Labels: map[string]string{labelManaged: "true", labelProject: "shop"},
+		},
+		{
+			Name:   "plate-mysql-other-project",
+			Image:  "mysql:8",
+			Labels: map[string]string{labelManaged: "true", labelProject: "blog"},
+		},
+	}
+
+	changes := computeDiff(cfg, containers)
+
+	expected := []struct {
+		label string
+		kind  changeKind
+		drift int
+	}{
+		{"main-db", changeRecreate, 2},
+		{"cache", changeNone, 0},
+		{"docs", changeCreate, 0},
+		{"orders", changeConflict, 0},
+		{"plate-mysql-legacy", changeRemove, 0},
+	}
+	if len(changes) != len(expected) {
+		t.Fatalf("Expected %d changes, got %d: %+v", len(expected), len(changes), changes)
+	}
+	for i, want := range expected {
+		got := changes[i]
+		if got.label() != want.label || got.kind != want.kind || len(got.drift) != want.drift {
+			t.Errorf("Expected change %d to be %s (kind %d, %d drift), got %s (kind %d, drift %v)", i, want.label, want.kind, want.drift, got.label(), got.kind, got.drift)
+		}
+	}
+	if !hasChanges(changes) {
+		t.Errorf("Expected diff to report changes")
+	}
+}
+
+func TestDetectDriftEnv(t *testing.T) {
+	svc := ServiceConfig{Type: "redis", Name: "cache", Version: "7", Port: 6380, Env: map[string]string{"REDIS_ARGS": "--save 60 1"}}
+	ctr := containerInfo{Image: "redis:7", Ports: map[int]int{6379: 6380}, Env: []string{"REDIS_ARGS=--save 900 1"}}
+
+	drift := detectDrift(svc, ctr)
+	if len(drift) != 1 || drift[0] != `env REDIS_ARGS: "--save 900 1" → "--save 60 1"` {
+		t.Errorf("Unexpected drift: %v", drift)
+	}
+}
+
+func TestComputeDiffRename(t *testing.T) {
+	cfg := PlateConfig{
+		Project: "shop",
+		Services: []ServiceConfig{
+			{Type: "postgres", Name: "primary", Version: "16", Port: 5433},
+			{Type: "redis", Name: "sessions", Version: "7", Port: 6380},
+		},
+	}
+	labels := func(service string) map[string]string {
+		return map[string]string{labelManaged: "true", labelProject: "shop", labelService: service}
+	}
+	containers := []containerInfo{
+		{ID: "a1", Name: "plate-postgres-main-db", Image: "postgres:16", Labels: labels("main-db"), Ports: map[int]int{5432: 5433}},
+		{ID: "b2", Name: "plate-mongo-docs", Image: "mongo:latest", Labels: labels("docs")},
+	}
+
+	changes := computeDiff(cfg, containers)
+
+	expected := []struct {
+		label string
+		kind  changeKind
+	}{
+		{"primary", changeRename},
+		{"sessions", changeCreate},
+		{"plate-mongo-docs", changeRemove},
+	}
+	if len(changes) != len(expected) {
+		t.Fatalf("Expected %d changes, got %d: %+v", len(expected), len(changes), changes)
+	}
+	for i, want := range expected {
+		if got := changes[i]; got.label() != want.label || got.kind != want.kind {
+			t.Errorf("Expected change %d to be %s (kind %d), got %s (kind %d)", i, want.label, want.kind, got.label(), got.kind)
+		}
+	}
+	if changes[0].container.ID != "a1" {
+		t.Errorf("Expected primary to be linked to a1, got %s", changes[0].container.ID)
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// --- DIRENV ---
+
+// envrcFileName is the file direnv loads when entering a directory.
+const envrcFileName = ".envrc"
+
+// direnvFunction defines `use plate` for direnv. It can go into the .envrc
+// itself, or into ~/.config/direnv/direnvrc to share it between projects.
+const direnvFunction = `# use_plate loads Plate's connection strings, such as PLATE_MAIN_DB_URL,
+# into the shell. direnv unsets them again when you leave the directory.
+# Arguments go to 'plate env', e.g. 'use plate --config other.json'.
+use_plate() {
+  if ! has plate; then
+    log_error "use plate: plate isn't on the PATH"
+    return 1
+  fi
+  watch_file plate.config.json plate.config.local.json
+  eval "$(plate env --format shell "$@")"
+}
+`
+
+// direnvUseLine is the .envrc line that loads the config at configPath.
+func direnvUseLine(configPath string) string {
+	if configPath == "plate.config.json" {
+		return "use plate\n"
+	}
+	return "use plate --config " + shellWord(configPath) + "\n"
+}
+
+// direnvSnippet returns what goes into the .envrc: the function, unless it
+// is defined in the direnvrc, and the line using it.
+func direnvSnippet(configPath string, withFunction bool) string {
+	if !withFunction {
+		return direnvUseLine(configPath)
+	}
+	return direnvFunction + direnvUseLine(configPath)
+}
+
+// appendEnvrc adds the snippet to the .envrc at path, which is created if
+// needed. It reports false when the .envrc already uses plate.
+func appendEnvrc(path, snippet string) (bool, error) {
+	existing, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return false, err
+	}
+	for _, line := range strings.Split(string(existing), "\n") {
+		if f := strings.Fields(line); len(f) >= 2 && f[0] == "use" && f[1] == "plate" {
+			return false, nil
+		}
+	}
+	content := string(existing)
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	if content != "" {
+		content += "\n"
+	}
+	return true, os.WriteFile(path, []byte(content+snippet), 0644)
+}
+
+// handleDirenvCmd prints, or adds to the .envrc, what makes direnv load the
+// connection strings when entering the project directory.
+func handleDirenvCmd(args []string) {
+	fs := flag.NewFlagSet("direnv", flag.ExitOnError)
+	addConfigFlag(fs)
+	write := fs.Bool("write", false, "add it to the .envrc in the current directory")
+	function := fs.Bool("function", false, "print only the use_plate function, for ~/.config/direnv/direnvrc")
+	noFunction := fs.Bool("no-function", false, "leave out the function, when the direnvrc defines it")
+	fs.Parse(args)
+	if *function {
+		fmt.Print(direnvFunction)
+		return
+	}
+	configPath := configPathArg(fs)
+	if configPath == stdinSource {
+		fmt.Println("Error: direnv can't read the config from stdin; pass a path, URL, or git reference.")
+		os.Exit(exitUsage)
+	}
+	snippet := direnvSnippet(configPath, !*noFunction)
+	if !*write {
+		fmt.Print(snippet)
+		return
+	}
+	added, err := appendEnvrc(envrcFileName, snippet)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if !added {
+		fmt.Printf("%s already uses plate.\n", envrcFileName)
+		return
+	}
+	fmt.Printf("%s Added 'use plate' to %s. Run 'direnv allow' to load it.\n", successStyle.Render("✓"), envrcFileName)
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestDirenvSnippet(t *testing.T) {
+	if got := direnvSnippet("plate.config.json", false); got != "use plate\n" {
+		t.Errorf("Expected just 'use plate', got %q", got)
+	}
+	if got := direnvSnippet("configs/dev env.json", false); got != "use plate --config 'configs/dev env.json'\n" {
+		t.Errorf("Expected the config passed on, got %q", got)
+	}
+	if got := direnvSnippet("plate.config.json", true); !strings.HasPrefix(got, direnvFunction) || !strings.HasSuffix(got, "use plate\n") {
+		t.Errorf("Expected the function before 'use plate', got %q", got)
+	}
+}
+
+func TestAppendEnvrc(t *testing.T) {
+	t.Chdir(t.TempDir())
+	os.WriteFile(envrcFileName, []byte("export FOO=1"), 0644)
+
+	added, err := appendEnvrc(envrcFileName, "use plate\n")
+	if err != nil || !added {
+		t.Fatalf("Expected 'use plate' to be added, got %v, %v", added, err)
+	}
+	data, _ := os.ReadFile(envrcFileName)
+	if string(data) != "export FOO=1\n\nuse plate\n" {
+		t.Errorf("Expected it after the existing content, got %q", data)
+	}
+	if added, _ := appendEnvrc(envrcFileName, "use plate\n"); added {
+		t.Errorf("Expected no second 'use plate'")
+	}
+}
@@ -0,0 +1,316 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// --- DOCKER INSPECTION ---
+
+// Labels Plate attaches to every container it creates.
+const (
+	labelManaged = "plate.managed"
+	labelProject = "plate.project"
+	labelService = "plate.service"
+	labelExpires = "plate.expires" // when an ephemeral container is reaped
+	labelState   = "plate.state"   // the absolute state directory of the project, which holds its lock
+)
+
+// containerInfo is the subset of `docker inspect` output Plate cares about.
+type containerInfo struct {
+	ID    string
+	Name  string
+	Image string
+	State string
+	// ExitCode is the exit code of the container's last run, and OOMKilled
+	// whether the kernel killed it for running out of memory.
+	ExitCode  int
+	OOMKilled bool
+	Labels    map[string]string
+	Env       []string
+	Ports     map[int]int // container port -> published host port
+	// BindIPs maps container ports to the host address they are published
+	// on, when the binding is limited to one.
+	BindIPs map[int]string
+}
+
+// managed reports whether the container was created by Plate.
+func (c containerInfo) managed() bool {
+	return c.Labels[labelManaged] == "true"
+}
+
+// shortID abbreviates a container ID to the 12 characters docker shows.
+// Shorter IDs, such as from other container runtimes, are kept whole.
+func shortID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
+
+// DockerConfig tunes how Plate runs the docker CLI.
+type DockerConfig struct {
+	// Sudo runs docker through sudo, for hosts where only root may use the
+	// daemon's socket.
+	Sudo bool `json:"sudo,omitempty"`
+}
+
+// dockerSudo is set from the loaded config's docker.sudo.
+var dockerSudo bool
+
+// dockerPermissionHint explains how to get access to the docker socket.
+const dockerPermissionHint = `This user isn't allowed to use the docker daemon's socket. Either:
+  - add yourself to the docker group: sudo usermod -aG docker $USER, then log out and back in
+  - use rootless docker, or podman with its docker-compatible socket
+  - or set "docker": {"sudo": true} in the config to run docker through sudo`
+
+// dockerCommand builds an exec.Cmd for the docker CLI.
+func dockerCommand(args ...string) *exec.Cmd {
+	logDockerCommand(args)
+	if dockerSudo {
+		// -n fails rather than prompting, which would hang the TUI;
+		// primeDockerSudo asks for the password up front.
+		return exec.Command("sudo", append([]string{"-n", "docker"}, args...)...)
+	}
+	return exec.Command("docker", args...)
+}
+
+// primeDockerSudo asks for the sudo password, if needed, before docker
+// commands run without a terminal to prompt on.
+func primeDockerSudo() error {
+	if !dockerSudo {
+		return nil
+	}
+	cmd := exec.Command("sudo", "-v")
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("docker.sudo is set, but sudo failed: %v", err)
+	}
+	return nil
+}
+
+// dockerPermissionDenied reports whether docker output says the user may
+// not use the daemon's socket.
+func dockerPermissionDenied(output string) bool {
+	return strings.Contains(strings.ToLower(output), "permission denied while trying to connect to the docker daemon")
+}
+
+// checkDockerAccess returns an error with dockerPermissionHint when docker
+// fails for lack of permission. Other failures are left to the callers,
+// which report them per service.
+func checkDockerAccess() error {
+	output, err := dockerCommand("info", "--format", "{{.ServerVersion}}").CombinedOutput()
+	if err != nil && dockerPermissionDenied(string(output)) {
+		return fmt.Errorf("%s\n%s", lastLine(strings.TrimSpace(string(output))), dockerPermissionHint)
+	}
+	return nil
+}
+
+// sudoInvoked reports whether Plate itself was started with sudo, which
+// leaves root-owned files behind in .plate.
+func sudoInvoked() bool {
+	return os.Geteuid() == 0 && os.Getenv("SUDO_USER") != ""
+}
+
+// hasImage reports whether the service's image is present locally.
+func hasImage(config ServiceConfig) bool {
+	return imagePresent(imageName(config))
+}
+
+// imagePresent reports whether an image is present locally.
+func imagePresent(image string) bool {
+	return backend.imagePresent(image)
+}
+
+// pullImage downloads the service's image.
+func pullImage(config ServiceConfig) error {
+	return backend.pull(imageName(config))
+}
+
+// runServiceContainer creates and starts a new container for the service,
+// returning its ID and connection string.
+func runServiceContainer(config ServiceConfig) (string, string, error) {
+	if config.TLS {
+		if err := ensureServiceCert(config); err != nil {
+			return "", "", err
+		}
+		// Under userns-remap, container root can't read a key only its
+		// owner may read; the start script copies it to a private file.
+		if detectDaemonMode().userns {
+			if err := os.Chmod(filepath.Join(serviceCertDir(config), "server.key"), 0644); err != nil {
+				return "", "", err
+			}
+		}
+	}
+	connStr, runArgs, err := getDockerRunArgs(config, containerName(config))
+	if err != nil {
+		return "", "", err
+	}
+	id, err := backend.run(containerName(config), runArgs)
+	if err != nil {
+		return "", "", err
+	}
+	return id, connStr, nil
+}
+
+// dockerRunCommand returns the docker run command Plate starts the service
+// with, quoted for a POSIX shell, so it can be reproduced by hand.
+func dockerRunCommand(config ServiceConfig) (string, error) {
+	_, runArgs, err := getDockerRunArgs(config, containerName(config))
+	if err != nil {
+		return "", err
+	}
+	words := []string{"docker"}
+	for _, arg := range runArgs {
+		words = append(words, shellWord(arg))
+	}
+	return strings.Join(words, " "), nil
+}
+
+// shellWord quotes s for a shell, unless it's safe as it is.
+func shellWord(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_.,:/=@+%") == "" {
+		return s
+	}
+	return shellQuote(s)
+}
+
+// createServiceContainer creates, but doesn't start, a container for the
+// service. This leaves room to seed its data before the first boot.
+func createServiceContainer(config ServiceConfig) (string, error) {
+	if config.TLS {
+		if err := ensureServiceCert(config); err != nil {
+			return "", err
+		}
+	}
+	_, runArgs, err := getDockerRunArgs(config, containerName(config))
+	if err != nil {
+		return "", err
+	}
+	// getDockerRunArgs starts with "run", "-d"; create takes the same flags.
+	createArgs := append([]string{"create"}, runArgs[2:]...)
+	output, err := dockerCommand(createArgs...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("%s", strings.TrimSpace(string(output)))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// listPlateContainers returns every container that is either labelled as
+// Plate-managed, follows Plate's `plate-` naming scheme, or was adopted into
+// the given project.
+func listPlateContainers(project string) ([]containerInfo, error) {
+	st, err := loadState()
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	var ids []string
+	for _, id := range st.Adopted {
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	for _, filter := range []string{"label=" + labelManaged + "=true", "name=^plate-"} {
+		found, err := backend.containerIDs(true, filter)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range found {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	containers, err := inspectContainers(ids...)
+	if err != nil {
+		return nil, err
+	}
+	applyAdoptions(containers, st, project)
+	return containers, nil
+}
+
+// inspectContainers looks up the given containers in the runtime.
+// Containers that no longer exist are skipped.
+func inspectContainers(ids ...string) ([]containerInfo, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	return backend.inspect(ids...)
+}
+
+// findPortPublisher returns a container other than the service's own that
+// publishes the given host port.
+func findPortPublisher(port int, exceptName string) (containerInfo, bool) {
+	ids, err := backend.containerIDs(false, fmt.Sprintf("publish=%d", port))
+	if err != nil {
+		return containerInfo{}, false
+	}
+	infos, _ := inspectContainers(ids...)
+	for _, info := range infos {
+		if info.Name != exceptName {
+			return info, true
+		}
+	}
+	return containerInfo{}, false
+}
+
+// parseInspectOutput decodes the JSON array printed by `docker inspect`.
+func parseInspectOutput(data []byte) ([]containerInfo, error) {
+	var raw []struct {
+		ID     string `json:"Id"`
+		Name   string `json:"Name"`
+		Config struct {
+			Image  string            `json:"Image"`
+			Env    []string          `json:"Env"`
+			Labels map[string]string `json:"Labels"`
+		} `json:"Config"`
+		State struct {
+			Status    string `json:"Status"`
+			ExitCode  int    `json:"ExitCode"`
+			OOMKilled bool   `json:"OOMKilled"`
+		} `json:"State"`
+		HostConfig struct {
+			PortBindings map[string][]struct {
+				HostIP   string `json:"HostIp"`
+				HostPort string `json:"HostPort"`
+			} `json:"PortBindings"`
+		} `json:"HostConfig"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("could not parse docker inspect output: %w", err)
+	}
+
+	infos := make([]containerInfo, 0, len(raw))
+	for _, r := range raw {
+		info := containerInfo{
+			ID:        r.ID,
+			Name:      strings.TrimPrefix(r.Name, "/"),
+			Image:     r.Config.Image,
+			State:     r.State.Status,
+			ExitCode:  r.State.ExitCode,
+			OOMKilled: r.State.OOMKilled,
+			Labels:    r.Config.Labels,
+			Env:       r.Config.Env,
+		}
+		info.Ports = map[int]int{}
+		info.BindIPs = map[int]string{}
+		for port, bindings := range r.HostConfig.PortBindings {
+			containerPort, err := strconv.Atoi(strings.TrimSuffix(port, "/tcp"))
+			if err != nil || len(bindings) == 0 {
+				continue
+			}
+			info.Ports[containerPort], _ = strconv.Atoi(bindings[0].HostPort)
+			if ip := bindings[0].HostIP; ip != "" && ip != "0.0.0.0" && ip != "::" {
+				info.BindIPs[containerPort] = ip
+			}
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
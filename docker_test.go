@@ -0,0 +1,17 @@
+package main
+
+import "testing"
+
+func TestShortID(t *testing.T) {
+	tests := map[string]string{
+		"3f4e9a1b2c7d8e9f0a1b2c3d": "3f4e9a1b2c7d",
+		"3f4e9a1b2c7d":             "3f4e9a1b2c7d",
+		"abc":                      "abc",
+		"":                         "",
+	}
+	for id, want := range tests {
+		if got := shortID(id); got != want {
+			t.Errorf("shortID(%q): Expected %q, got %q", id, want, got)
+		}
+	}
+}
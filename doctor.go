@@ -0,0 +1,323 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// --- DIAGNOSTICS ---
+
+// runtimeStartTimeout is how long `plate doctor --fix` waits for a docker
+// runtime it started to accept connections.
+const runtimeStartTimeout = 90 * time.Second
+
+// DoctorConfig tunes `plate doctor --fix`.
+type DoctorConfig struct {
+	// StartRuntime is the shell command that starts the docker runtime,
+	// e.g. "colima start". Empty means detect it; "none" never starts one.
+	StartRuntime string `json:"startRuntime,omitempty"`
+	// AutoStart starts the runtime without asking when a command finds the
+	// daemon stopped.
+	AutoStart bool `json:"autoStart,omitempty"`
+}
+
+// doctorResult is the outcome of one check. A failed check may carry a fix.
+type doctorResult struct {
+	ok      bool
+	detail  string
+	fixDesc string       // what fix would do, shown when --fix isn't given
+	fix     func() error // nil when there is no safe automatic fix
+}
+
+// doctorCheck is one diagnostic.
+type doctorCheck struct {
+	name        string
+	gatesDocker bool // when it fails, the checks that need docker are skipped
+	needsDocker bool
+	run         func() doctorResult
+}
+
+// runtimeStartCommand returns the command that starts the docker runtime on
+// this machine, or "" if Plate doesn't know one.
+func runtimeStartCommand(cfg PlateConfig) string {
+	if cfg.Doctor != nil && cfg.Doctor.StartRuntime != "" {
+		if cfg.Doctor.StartRuntime == "none" {
+			return ""
+		}
+		return cfg.Doctor.StartRuntime
+	}
+	if _, err := exec.LookPath("colima"); err == nil {
+		return "colima start"
+	}
+	if runtime.GOOS == "darwin" {
+		return "open -a Docker"
+	}
+	return ""
+}
+
+// dockerReachable reports whether the docker daemon answers.
+func dockerReachable() bool {
+	return dockerCommand("info", "--format", "{{.ServerVersion}}").Run() == nil
+}
+
+// startRuntime runs the start command and waits until reachable reports
+// the daemon up.
+func startRuntime(command string, reachable func() bool) error {
+	if output, err := exec.Command("sh", "-c", command).CombinedOutput(); err != nil {
+		return fmt.Errorf("'%s' failed: %s", command, output)
+	}
+	deadline := time.Now().Add(runtimeStartTimeout)
+	for time.Now().Before(deadline) {
+		if reachable() {
+			return nil
+		}
+		time.Sleep(2 * time.Second)
+	}
+	return fmt.Errorf("docker still isn't reachable %s after '%s'", runtimeStartTimeout, command)
+}
+
+// askOnTerminal returns a confirmation that asks on in, or nil when in
+// isn't a terminal to ask on.
+func askOnTerminal(in *os.File) func(question string) bool {
+	if !isTerminal(in) {
+		return nil
+	}
+	return func(question string) bool { return confirm(in, question) }
+}
+
+// offerRuntimeStart starts the docker runtime when reachable reports the
+// daemon down and ask agrees, or the config says not to ask. A nil ask
+// means there is no one to ask. It reports whether it started one.
+func offerRuntimeStart(cfg PlateConfig, reachable func() bool, ask func(question string) bool) (bool, error) {
+	command := runtimeStartCommand(cfg)
+	if command == "" || reachable() {
+		return false, nil
+	}
+	// Without the CLI there is nothing to start; doctor explains that.
+	if _, err := exec.LookPath("docker"); err != nil {
+		return false, nil
+	}
+	if cfg.Doctor == nil || !cfg.Doctor.AutoStart {
+		if ask == nil || !ask(fmt.Sprintf("Docker isn't running. Start it with '%s'?", command)) {
+			return false, nil
+		}
+	}
+	fmt.Fprintf(noticeOut(), "Starting docker with '%s'...\n", command)
+	return true, startRuntime(command, reachable)
+}
+
+// portFree reports whether nothing listens on the host port.
+func portFree(port int) bool {
+	l, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return false
+	}
+	l.Close()
+	return true
+}
+
+// doctorChecks builds the diagnostics for a config.
+func doctorChecks(cfg PlateConfig) []doctorCheck {
+	checks := []doctorCheck{
+		{name: "docker CLI", gatesDocker: true, run: func() doctorResult {
+			if path, err := exec.LookPath("docker"); err == nil {
+				return doctorResult{ok: true, detail: path}
+			}
+			return doctorResult{detail: "docker is not on your PATH. Install Docker Desktop, colima, or another docker-compatible runtime."}
+		}},
+		{name: "user", run: func() doctorResult {
+			if sudoInvoked() {
+				return doctorResult{detail: `Plate runs through sudo, which leaves root-owned files in .plate; run it as yourself, with "docker": {"sudo": true} if docker needs root`}
+			}
+			return doctorResult{ok: true, detail: "not running through sudo"}
+		}},
+		{name: "docker daemon", gatesDocker: true, run: func() doctorResult {
+			if dockerReachable() {
+				return doctorResult{ok: true, detail: "reachable"}
+			}
+			if err := checkDockerAccess(); err != nil {
+				// Starting the runtime wouldn't help.
+				return doctorResult{detail: err.Error()}
+			}
+			res := doctorResult{detail: "the docker daemon isn't responding"}
+			if command := runtimeStartCommand(cfg); command != "" {
+				res.fixDesc = fmt.Sprintf("run '%s'", command)
+				res.fix = func() error { return startRuntime(command, dockerReachable) }
+			}
+			return res
+		}},
+		{name: "docker mode", needsDocker: true, run: func() doctorResult {
+			return checkDaemonMode(cfg, detectDaemonMode(), unprivilegedPortStart())
+		}},
+		{name: "registry access", needsDocker: true, run: func() doctorResult {
+			n, err := inspectDaemonNetwork()
+			if err != nil {
+				return doctorResult{ok: true, detail: "unknown, docker info doesn't report it"}
+			}
+			return checkRegistryNetwork(n, firstNonEmpty(os.Getenv("HTTPS_PROXY"), os.Getenv("https_proxy")), loadCLIProxy())
+		}},
+		{name: "emulation", needsDocker: true, run: func() doctorResult {
+			return emulationCheck(cfg)
+		}},
+		{name: "git worktree", run: func() doctorResult {
+			return checkWorktree(cfg, ".")
+		}},
+		{name: "state file", run: func() doctorResult {
+			_, err := loadState()
+			if err == nil {
+				return doctorResult{ok: true, detail: "readable"}
+			}
+			path := filepath.Join(stateDir, stateFileName)
+			backup := fmt.Sprintf("%s.corrupt-%s", path, time.Now().Format("20060102-150405"))
+			return doctorResult{
+				detail:  err.Error(),
+				fixDesc: fmt.Sprintf("move it to %s and start fresh (adoptions must be redone)", backup),
+				fix: func() error {
+					if err := os.Rename(path, backup); err != nil {
+						return err
+					}
+					return updateState(func(*plateState) {})
+				},
+			}
+		}},
+	}
+
+	for _, svc := range cfg.containerServices() {
+		checks = append(checks, doctorCheck{name: fmt.Sprintf("port %d (%s)", svc.Port, svc.Name), needsDocker: true, run: func() doctorResult {
+			return checkServicePort(svc)
+		}})
+	}
+	return checks
+}
+
+// checkDaemonMode reports a rootless or userns-remapped daemon, failing when
+// a rootless one can't publish a service's port.
+func checkDaemonMode(cfg PlateConfig, mode daemonMode, unprivilegedStart int) doctorResult {
+	switch {
+	case mode.rootless:
+		var refused []string
+		for _, svc := range cfg.containerServices() {
+			if svc.Port != 0 && svc.Port < unprivilegedStart {
+				refused = append(refused, fmt.Sprintf("%d (%s, try %d)", svc.Port, svc.Name, privilegedPortAlternative(svc.Port)))
+			}
+		}
+		if len(refused) > 0 {
+			return doctorResult{detail: fmt.Sprintf("rootless docker can't publish ports below %d: %s; run 'plate ports' to move them", unprivilegedStart, strings.Join(refused, ", "))}
+		}
+		return doctorResult{ok: true, detail: "rootless"}
+	case mode.userns:
+		return doctorResult{ok: true, detail: "userns-remap"}
+	}
+	return doctorResult{ok: true, detail: "rootful"}
+}
+
+// checkServicePort checks that a service's host port is free or used by the
+// service itself. A port held by another Plate container, such as one left
+// over from a different project, can be freed by stopping that container,
+// unless a Plate instance, other than this one, still runs that project.
+func checkServicePort(svc ServiceConfig) doctorResult {
+	name := containerName(svc)
+	if infos, _ := inspectContainers(name); len(infos) == 1 && infos[0].State == "running" {
+		return doctorResult{ok: true, detail: "used by " + name}
+	}
+	if portFree(svc.Port) {
+		return doctorResult{ok: true, detail: "free"}
+	}
+	ctr, ok := findPortPublisher(svc.Port, name)
+	switch {
+	case !ok:
+		return doctorResult{detail: "in use by a program outside docker"}
+	case !ctr.managed():
+		return doctorResult{detail: fmt.Sprintf("published by %s, which Plate doesn't manage; stop it or adopt it", ctr.Name)}
+	case ctr.Labels[labelState] == "":
+		// Made by an older Plate, which didn't record where its project is.
+		return doctorResult{detail: fmt.Sprintf("held by Plate container %s of project %s; stop it if no Plate instance uses it", ctr.Name, ctr.Labels[labelProject])}
+	}
+	if pid, err := runningInstanceIn(ctr.Labels[labelState]); !errors.Is(err, errNoInstance) && pid != os.Getpid() {
+		return doctorResult{detail: fmt.Sprintf("held by %s, which the Plate instance of %s is using", ctr.Name, filepath.Dir(ctr.Labels[labelState]))}
+	}
+	return doctorResult{
+		detail:  fmt.Sprintf("held by stale Plate container %s", ctr.Name),
+		fixDesc: fmt.Sprintf("stop %s", ctr.Name),
+		fix:     func() error { return backend.stop(ctr.ID) },
+	}
+}
+
+// runDoctor runs every check, applying fixes when fix is set. It returns
+// the number of problems left.
+func runDoctor(checks []doctorCheck, fix bool, out io.Writer) int {
+	problems := 0
+	dockerUp := true
+	for _, c := range checks {
+		if c.needsDocker && !dockerUp {
+			fmt.Fprintf(out, "%s %s: skipped, docker isn't reachable\n", stoppedStyle.Render("-"), c.name)
+			continue
+		}
+		res := c.run()
+		if !res.ok && fix && res.fix != nil {
+			fmt.Fprintf(out, "%s %s: %s, fixing: %s...\n", pendingStyle.Render("🔧"), c.name, res.detail, res.fixDesc)
+			if err := res.fix(); err != nil {
+				res.detail = fmt.Sprintf("fix failed: %v", err)
+			} else {
+				res = c.run()
+			}
+		}
+		if c.gatesDocker && !res.ok {
+			dockerUp = false
+		}
+		if res.ok {
+			fmt.Fprintf(out, "%s %s: %s\n", successStyle.Render("✓"), c.name, res.detail)
+			continue
+		}
+		problems++
+		hint := ""
+		if res.fix != nil && !fix {
+			hint = fmt.Sprintf(" (--fix would %s)", res.fixDesc)
+		}
+		fmt.Fprintf(out, "%s %s: %s%s\n", errorStyle.Render("✗"), c.name, res.detail, hint)
+	}
+	return problems
+}
+
+// handleDoctorCmd diagnoses the environment and optionally repairs it.
+func handleDoctorCmd(args []string) {
+	fs := flag.NewFlagSet("doctor", flag.ExitOnError)
+	fix := fs.Bool("fix", false, "apply safe automatic fixes")
+	force := fs.Bool("force", false, "run even if another plate instance holds the lock")
+	addConfigFlag(fs)
+	fs.Parse(args)
+	useStateDirOf(configPathArg(fs))
+	plateConfig, err := loadConfig(configPathArg(fs))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitConfig)
+	}
+	printConfigWarnings(plateConfig)
+
+	if err := primeDockerSudo(); err != nil {
+		fmt.Println(errorStyle.Render(err.Error()))
+	}
+
+	var lock *instanceLock
+	if *fix {
+		lock = mustAcquireLock(*force)
+		defer lock.release()
+	}
+	if problems := runDoctor(doctorChecks(plateConfig), *fix, os.Stdout); problems > 0 {
+		fmt.Printf("\n%d problem(s) found.\n", problems)
+		if lock != nil {
+			lock.release()
+		}
+		os.Exit(1)
+	}
+	fmt.Println("\n✅ Everything looks good.")
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunDoctor(t *testing.T) {
+	fixed := false
+	portChecked := false
+	checks := func() []doctorCheck {
+		return []doctorCheck{
+			{name: "docker daemon", gatesDocker: true, run: func() doctorResult {
+				if fixed {
+					return doctorResult{ok: true}
+				}
+				return doctorResult{fixDesc: "start it", fix: func() error { fixed = true; return nil }}
+			}},
+			{name: "unfixable", run: func() doctorResult { return doctorResult{detail: "broken"} }},
+			{name: "port", needsDocker: true, run: func() doctorResult { portChecked = true; return doctorResult{ok: true} }},
+		}
+	}
+
+	if problems := runDoctor(checks(), false, io.Discard); problems != 2 {
+		t.Errorf("Expected 2 problems without --fix, got %d", problems)
+	}
+	if fixed || portChecked {
+		t.Errorf("Expected no fixes and docker checks skipped without --fix")
+	}
+
+	if problems := runDoctor(checks(), true, io.Discard); problems != 1 {
+		t.Errorf("Expected 1 problem left with --fix, got %d", problems)
+	}
+	if !fixed || !portChecked {
+		t.Errorf("Expected the daemon fix to run and the port check to follow")
+	}
+}
+
+func TestDockerAccess(t *testing.T) {
+	denied := "permission denied while trying to connect to the Docker daemon socket at unix:///var/run/docker.sock: Get \"http://%2Fvar%2Frun%2Fdocker.sock/v1.24/info\": dial unix /var/run/docker.sock: connect: permission denied"
+	if !dockerPermissionDenied(denied) {
+		t.Errorf("Expected a socket permission error to be detected")
+	}
+	if dockerPermissionDenied("Cannot connect to the Docker daemon at unix:///var/run/docker.sock. Is the docker daemon running?") {
+		t.Errorf("Expected a stopped daemon not to count as a permission error")
+	}
+
+	dockerSudo = true
+	defer func() { dockerSudo = false }()
+	if args := dockerCommand("ps").Args; strings.Join(args, " ") != "sudo -n docker ps" {
+		t.Errorf("Expected docker to run through sudo, got %v", args)
+	}
+}
+
+func TestCheckServicePortStopsOnlyUnusedContainers(t *testing.T) {
+	fake := useFakeRuntime(t)
+	l, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	port := l.Addr().(*net.TCPAddr).Port
+	svc := ServiceConfig{Type: "redis", Name: "cache", Port: port}
+
+	other := t.TempDir()
+	fake.containers = []*containerInfo{{
+		ID: "abc", Name: "plate-redis-other", State: "running", Ports: map[int]int{6379: port},
+		Labels: map[string]string{labelManaged: "true", labelProject: "other", labelState: filepath.Join(other, stateDirName)},
+	}}
+	if res := checkServicePort(svc); res.fix == nil {
+		t.Errorf("Expected to offer stopping a container no instance uses, got %q", res.detail)
+	}
+
+	// Another instance runs the other project.
+	t.Chdir(other)
+	lock, err := acquireLock(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lock.release()
+	lock.file.WriteAt([]byte("1      "), 0)
+	if res := checkServicePort(svc); res.fix != nil || !strings.Contains(res.detail, "Plate instance") {
+		t.Errorf("Expected no fix for a container another instance uses, got %q", res.detail)
+	}
+
+	// Containers from before the state label was added can't be checked.
+	delete(fake.containers[0].Labels, labelState)
+	if res := checkServicePort(svc); res.fix != nil {
+		t.Errorf("Expected no fix for a container of an unknown project, got %q", res.detail)
+	}
+}
+
+func TestOfferRuntimeStart(t *testing.T) {
+	// Only the docker CLI's presence is checked; the daemon is stood in for.
+	bin := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bin, "docker"), nil, 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	ask := PlateConfig{Doctor: &DoctorConfig{StartRuntime: "true"}}
+	tests := []struct {
+		name     string
+		cfg      PlateConfig
+		up       bool
+		terminal bool
+		answer   bool
+		asked    bool
+		expected bool
+	}{
+		{"already running", ask, true, true, true, false, false},
+		{"agreed", ask, false, true, true, true, true},
+		{"declined", ask, false, true, false, true, false},
+		{"no terminal", ask, false, false, true, false, false},
+		{"auto-start", PlateConfig{Doctor: &DoctorConfig{StartRuntime: "true", AutoStart: true}}, false, false, false, false, true},
+		{"never start", PlateConfig{Doctor: &DoctorConfig{StartRuntime: "none"}}, false, true, true, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The daemon comes up once the runtime has been started.
+			checks := 0
+			reachable := func() bool {
+				checks++
+				return tt.up || checks > 1
+			}
+			asked := false
+			var confirm func(string) bool
+			if tt.terminal {
+				confirm = func(question string) bool {
+					asked = true
+					if question != "Docker isn't running. Start it with 'true'?" {
+						t.Errorf("Expected the question to name the command, got %q", question)
+					}
+					return tt.answer
+				}
+			}
+			started, err := offerRuntimeStart(tt.cfg, reachable, confirm)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if started != tt.expected || asked != tt.asked {
+				t.Errorf("Expected started=%v asked=%v, got started=%v asked=%v", tt.expected, tt.asked, started, asked)
+			}
+		})
+	}
+
+	in, err := os.CreateTemp(t.TempDir(), "stdin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer in.Close()
+	if askOnTerminal(in) != nil {
+		t.Errorf("Expected no confirmation when stdin isn't a terminal")
+	}
+}
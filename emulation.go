@@ -0,0 +1,210 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// --- CPU ARCHITECTURE EMULATION ---
+
+// binfmtImage registers QEMU with the kernel docker runs on, so images for
+// another architecture run under emulation.
+const binfmtImage = "tonistiigi/binfmt"
+
+// pullPlatforms maps the images of services with a platform to it, so
+// every pull of this run fetches the right variant. It is set when the
+// config is loaded.
+var pullPlatforms = map[string]string{}
+
+// pullArgs returns the docker arguments that pull image.
+func pullArgs(image string) []string {
+	if platform := pullPlatforms[image]; platform != "" {
+		return []string{"pull", "--platform", platform, image}
+	}
+	return []string{"pull", image}
+}
+
+// platformPattern matches image platforms such as linux/amd64 or
+// linux/arm/v7.
+var platformPattern = regexp.MustCompile(`^[a-z0-9]+/[a-z0-9_]+(/[a-z0-9]+)?$`)
+
+// validatePlatforms checks every service's platform.
+func validatePlatforms(cfg PlateConfig) error {
+	for _, svc := range cfg.Services {
+		if svc.Platform == "" {
+			continue
+		}
+		if svc.isProcess() {
+			return fmt.Errorf("service %s: platform is for container services", svc.Name)
+		}
+		if !platformPattern.MatchString(svc.Platform) {
+			return fmt.Errorf("service %s: platform %q must look like linux/amd64", svc.Name, svc.Platform)
+		}
+	}
+	return nil
+}
+
+// normalizeArch turns the architecture names of uname and docker info into
+// the ones image platforms use.
+func normalizeArch(arch string) string {
+	switch arch {
+	case "x86_64", "x86-64":
+		return "amd64"
+	case "aarch64", "arm64/v8":
+		return "arm64"
+	}
+	return arch
+}
+
+// platformArch returns the architecture of a platform such as linux/amd64.
+func platformArch(platform string) string {
+	_, arch, _ := strings.Cut(platform, "/")
+	return normalizeArch(arch)
+}
+
+var (
+	daemonPlatformOnce sync.Once
+	daemonPlatformName string
+)
+
+// daemonPlatform returns the platform of the machine docker runs
+// containers on, e.g. linux/arm64 for Docker Desktop on Apple silicon, or
+// "" if docker doesn't say.
+func daemonPlatform() string {
+	daemonPlatformOnce.Do(func() {
+		osType, arch, ok := strings.Cut(backend.platform(), "/")
+		if ok && osType != "" && arch != "" {
+			daemonPlatformName = osType + "/" + normalizeArch(arch)
+		}
+	})
+	return daemonPlatformName
+}
+
+// imagePlatform returns the platform of a local image, or "" if it isn't
+// present.
+func imagePlatform(image string) string {
+	return backend.imagePlatform(image)
+}
+
+// emulatedPlatform returns the platform of the service's image when docker
+// can only run it under emulation, or "" when it runs natively or either
+// platform is unknown.
+func emulatedPlatform(config ServiceConfig) string {
+	image := imagePlatform(imageName(config))
+	if needsEmulation(image, daemonPlatform()) {
+		return image
+	}
+	return ""
+}
+
+// needsEmulation reports whether an image for one platform runs under
+// emulation on a machine of another.
+func needsEmulation(image, machine string) bool {
+	return image != "" && machine != "" && platformArch(image) != platformArch(machine)
+}
+
+// renderEmulation renders the detail pane's warning for an emulated image.
+func renderEmulation(platform string) string {
+	return confirmStyle.Render(fmt.Sprintf("⚠️ %s, emulated on %s: expect it to be several times slower", platform, daemonPlatform()))
+}
+
+// binfmtStatus is what tonistiigi/binfmt prints when run without flags.
+type binfmtStatus struct {
+	Supported []string `json:"supported"`
+}
+
+// parseBinfmtStatus reads the platforms binfmt says the kernel can run.
+func parseBinfmtStatus(output []byte) ([]string, error) {
+	var status binfmtStatus
+	if err := json.Unmarshal(output, &status); err != nil {
+		return nil, fmt.Errorf("unexpected %s output: %v", binfmtImage, err)
+	}
+	return status.Supported, nil
+}
+
+// emulationPlatforms lists the platforms of the services' images that need
+// emulation: the configured platform, or that of the local image.
+func emulationPlatforms(cfg PlateConfig, machine string) []string {
+	var platforms []string
+	for _, svc := range cfg.containerServices() {
+		platform := svc.Platform
+		if platform == "" {
+			platform = imagePlatform(imageName(svc))
+		}
+		if needsEmulation(platform, machine) && !slices.Contains(platforms, platform) {
+			platforms = append(platforms, platform)
+		}
+	}
+	return platforms
+}
+
+// checkEmulation checks that the machine docker runs on can emulate the
+// platforms the services need, offering to install QEMU for those it
+// can't.
+func checkEmulation(needed, supported []string) doctorResult {
+	if len(needed) == 0 {
+		return doctorResult{ok: true, detail: "every image runs natively"}
+	}
+	var missing, archs []string
+	for _, platform := range needed {
+		if !slices.ContainsFunc(supported, func(s string) bool { return platformArch(s) == platformArch(platform) }) {
+			missing = append(missing, platform)
+			archs = append(archs, platformArch(platform))
+		}
+	}
+	if len(missing) == 0 {
+		return doctorResult{ok: true, detail: fmt.Sprintf("%s emulated, expect those services to be slow", strings.Join(needed, ", "))}
+	}
+	args := []string{"run", "--rm", "--privileged", binfmtImage, "--install", strings.Join(archs, ",")}
+	return doctorResult{
+		detail:  fmt.Sprintf("%s can't run here without QEMU emulation", strings.Join(missing, ", ")),
+		fixDesc: "install QEMU with " + binfmtImage,
+		fix: func() error {
+			if output, err := dockerCommand(args...).CombinedOutput(); err != nil {
+				return fmt.Errorf("%s", lastLine(strings.TrimSpace(string(output))))
+			}
+			return nil
+		},
+	}
+}
+
+// emulationCheck is the doctor check for emulation. binfmt is only run,
+// which needs a privileged container, when a service needs emulation.
+func emulationCheck(cfg PlateConfig) doctorResult {
+	needed := emulationPlatforms(cfg, daemonPlatform())
+	if len(needed) == 0 {
+		return checkEmulation(nil, nil)
+	}
+	output, err := dockerCommand("run", "--rm", "--privileged", binfmtImage).Output()
+	if err != nil {
+		return doctorResult{detail: fmt.Sprintf("%s need emulation, but %s couldn't tell whether QEMU is set up: %v", strings.Join(needed, ", "), binfmtImage, err)}
+	}
+	supported, err := parseBinfmtStatus(output)
+	if err != nil {
+		return doctorResult{detail: err.Error()}
+	}
+	return checkEmulation(needed, supported)
+}
+
+// noManifestHint explains a pull that failed because the image has no
+// variant for this machine.
+func noManifestHint(output string) string {
+	lower := strings.ToLower(output)
+	if !strings.Contains(lower, "no matching manifest for") {
+		return ""
+	}
+	return `The image isn't built for this machine. Set "platform": "linux/amd64" on the service to run it under emulation (slowly); 'plate doctor --fix' sets up QEMU if needed`
+}
+
+// execFormatHint explains a container that couldn't start its binary,
+// which happens when an image for another architecture runs without QEMU.
+func execFormatHint(output string) string {
+	if !strings.Contains(strings.ToLower(output), "exec format error") {
+		return ""
+	}
+	return "The image is built for another CPU architecture and this machine can't emulate it. Run 'plate doctor --fix' to set up QEMU"
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNeedsEmulation(t *testing.T) {
+	tests := []struct {
+		image, machine string
+		expected       bool
+	}{
+		{"linux/amd64", "linux/arm64", true},
+		{"linux/arm64", "linux/arm64", false},
+		{"linux/amd64", "linux/x86_64", false},
+		{"linux/arm64/v8", "linux/aarch64", false},
+		{"", "linux/arm64", false},
+		{"linux/amd64", "", false},
+	}
+	for _, tt := range tests {
+		if got := needsEmulation(tt.image, tt.machine); got != tt.expected {
+			t.Errorf("%s on %s: Expected %v, got %v", tt.image, tt.machine, tt.expected, got)
+		}
+	}
+}
+
+func TestCheckEmulation(t *testing.T) {
+	supported, err := parseBinfmtStatus([]byte(`{"supported": ["linux/arm64", "linux/arm/v7"], "emulators": []}`))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	res := checkEmulation([]string{"linux/amd64"}, supported)
+	if res.ok || res.fix == nil || !strings.Contains(res.detail, "linux/amd64") {
+		t.Errorf("Expected a failure with a fix for missing amd64 emulation, got %+v", res)
+	}
+	if res := checkEmulation([]string{"linux/amd64"}, append(supported, "linux/amd64")); !res.ok {
+		t.Errorf("Expected ok once amd64 is supported, got %+v", res)
+	}
+	if res := checkEmulation(nil, nil); !res.ok {
+		t.Errorf("Expected ok when nothing needs emulation, got %+v", res)
+	}
+}
+
+func TestServicePlatform(t *testing.T) {
+	svc := ServiceConfig{Type: "mysql", Name: "db", Version: "5.7", Port: 3307, Platform: "linux/amd64"}
+	_, args, err := getDockerRunArgs(svc, "plate-mysql-db")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if i := slices.Index(args, "--platform"); i < 0 || args[i+1] != "linux/amd64" {
+		t.Errorf("Expected the platform passed to docker run, got %v", args)
+	}
+
+	t.Cleanup(func() { pullPlatforms = map[string]string{} })
+	pullPlatforms = map[string]string{"mysql:5.7": "linux/amd64"}
+	if got := pullArgs("mysql:5.7"); !slices.Equal(got, []string{"pull", "--platform", "linux/amd64", "mysql:5.7"}) {
+		t.Errorf("Expected the platform passed to docker pull, got %v", got)
+	}
+
+	if err := validatePlatforms(PlateConfig{Services: []ServiceConfig{{Type: "mysql", Name: "db", Platform: "amd64"}}}); err == nil {
+		t.Errorf("Expected an error for a platform without an OS")
+	}
+	err = pullError("Error response from daemon: no matching manifest for linux/arm64/v8 in the manifest list entries")
+	if !strings.Contains(err.Error(), `"platform": "linux/amd64"`) {
+		t.Errorf("Expected a hint to set the platform, got %v", err)
+	}
+}
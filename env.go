@@ -0,0 +1,149 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// --- ENVIRONMENT EXPORT ---
+
+// serviceEnv returns KEY=value pairs with every container service's
+// connection string. The first observability service also sets the standard
+// OpenTelemetry exporter variables, so instrumented apps find it unchanged.
+func serviceEnv(cfg PlateConfig) []string {
+	var env []string
+	otlp := false
+	for _, svc := range cfg.containerServices() {
+		connStr, err := getConnectionString(svc)
+		if err != nil {
+			continue
+		}
+		env = append(env, connectionEnv(svc, connStr)...)
+		if svc.Type == "observability" && !otlp {
+			env = append(env, otlpEnv(svc)...)
+			otlp = true
+		}
+	}
+	return env
+}
+
+// runningEnv is serviceEnv for the services that are running, with the
+// connection strings they were started with.
+func runningEnv(items []item) []string {
+	var env []string
+	otlp := false
+	for _, it := range items {
+		if it.config.isProcess() || it.status != statusRunning || it.connectionString == "" {
+			continue
+		}
+		env = append(env, connectionEnv(it.config, it.connectionString)...)
+		if it.config.Type == "observability" && !otlp {
+			env = append(env, otlpEnv(it.config)...)
+			otlp = true
+		}
+	}
+	return env
+}
+
+// siblingEnv is the environment process services get injected: serviceEnv
+// with the connection strings running services were started with, so
+// processes reach them even where the port differs from the config.
+func siblingEnv(items []list.Item) []string {
+	var env []string
+	otlp := false
+	for _, itm := range items {
+		it := itm.(item)
+		if it.config.isProcess() {
+			continue
+		}
+		connStr := it.connectionString
+		if it.status != statusRunning || connStr == "" {
+			var err error
+			if connStr, err = getConnectionString(it.config); err != nil {
+				continue
+			}
+		}
+		env = append(env, connectionEnv(it.config, connStr)...)
+		if it.config.Type == "observability" && !otlp {
+			env = append(env, otlpEnv(it.config)...)
+			otlp = true
+		}
+	}
+	return env
+}
+
+// formatEnv renders KEY=value pairs for a POSIX shell ("export
+// KEY='value'") or as a dotenv file.
+func formatEnv(env []string, format string) (string, error) {
+	switch format {
+	case "shell":
+		return formatShellEnv(env, "posix")
+	case "dotenv":
+		var b strings.Builder
+		for _, kv := range env {
+			k, v, _ := strings.Cut(kv, "=")
+			b.WriteString(fmt.Sprintf("%s=%q\n", k, v))
+		}
+		return b.String(), nil
+	}
+	return "", fmt.Errorf("unknown format '%s' (use shell or dotenv)", format)
+}
+
+// shellAssignments write one variable assignment in each shell's syntax.
+var shellAssignments = map[string]func(k, v string) string{
+	"posix": func(k, v string) string { return fmt.Sprintf("export %s=%s", k, shellQuote(v)) },
+	// fish only unescapes \\ and \' in single quotes.
+	"fish": func(k, v string) string {
+		return fmt.Sprintf("set -gx %s '%s'", k, strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(v))
+	},
+	"powershell": func(k, v string) string {
+		return fmt.Sprintf("$env:%s = '%s'", k, strings.ReplaceAll(v, "'", "''"))
+	},
+	// The quotes keep & and | literal; a batch file expands %, so it's doubled.
+	"cmd": func(k, v string) string { return fmt.Sprintf(`set "%s=%s"`, k, strings.ReplaceAll(v, "%", "%%")) },
+}
+
+// formatShellEnv renders KEY=value pairs as assignments for shell: posix,
+// fish, powershell, or cmd.
+func formatShellEnv(env []string, shell string) (string, error) {
+	assign, ok := shellAssignments[shell]
+	if !ok {
+		return "", fmt.Errorf("unknown shell '%s' (use posix, fish, powershell, or cmd)", shell)
+	}
+	var b strings.Builder
+	for _, kv := range env {
+		k, v, _ := strings.Cut(kv, "=")
+		b.WriteString(assign(k, v) + "\n")
+	}
+	return b.String(), nil
+}
+
+func handleEnvCmd(args []string) {
+	fs := flag.NewFlagSet("env", flag.ExitOnError)
+	addConfigFlag(fs)
+	format := fs.String("format", "shell", "output format: shell or dotenv")
+	shell := fs.String("shell", "posix", "syntax of the shell format: posix, fish, powershell, or cmd")
+	fs.Parse(args)
+	if *shell != "posix" && *format != "shell" {
+		fmt.Println("Error: --shell only applies to --format shell.")
+		os.Exit(exitUsage)
+	}
+	plateConfig := mustLoadConfig(configPathArg(fs))
+
+	var out string
+	var err error
+	if *format == "shell" {
+		out, err = formatShellEnv(serviceEnv(plateConfig), *shell)
+	} else {
+		out, err = formatEnv(serviceEnv(plateConfig), *format)
+	}
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Print(out)
+}
@@ -0,0 +1,313 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+)
+
+// --- ENVIRONMENT SNAPSHOTS ---
+
+// snapshotsDirName is the directory inside stateDirName holding one
+// directory per named snapshot.
+const snapshotsDirName = "snapshots"
+
+// snapshotManifestName describes a snapshot; the data of each service sits
+// next to it as <service>.tar.gz.
+const snapshotManifestName = "snapshot.json"
+
+// snapshotNamePattern keeps snapshot names usable as directory names.
+var snapshotNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// envSnapshot is a save point of every stateful service.
+type envSnapshot struct {
+	Name       string          `json:"name"`
+	CreatedAt  time.Time       `json:"createdAt"`
+	ConfigHash string          `json:"configHash"`
+	Services   []lockedService `json:"services"`
+}
+
+func snapshotDir(name string) string {
+	return filepath.Join(stateDir, snapshotsDirName, name)
+}
+
+func validateSnapshotName(name string) error {
+	if !snapshotNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid snapshot name %q: use letters, digits, '.', '_' and '-'", name)
+	}
+	return nil
+}
+
+// statefulServices returns the container services that keep data.
+func statefulServices(cfg PlateConfig) []ServiceConfig {
+	var services []ServiceConfig
+	for _, svc := range cfg.containerServices() {
+		if spec, err := getServiceSpec(svc); err == nil && spec.DataDir != "" {
+			services = append(services, svc)
+		}
+	}
+	return services
+}
+
+// readEnvSnapshot loads the manifest of a named snapshot.
+func readEnvSnapshot(name string) (envSnapshot, error) {
+	var snap envSnapshot
+	data, err := os.ReadFile(filepath.Join(snapshotDir(name), snapshotManifestName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return snap, fmt.Errorf("no snapshot named '%s' (see 'plate snapshot list')", name)
+	}
+	if err != nil {
+		return snap, err
+	}
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return snap, fmt.Errorf("could not parse snapshot '%s': %w", name, err)
+	}
+	return snap, nil
+}
+
+// listEnvSnapshots returns every snapshot, newest first.
+func listEnvSnapshots() ([]envSnapshot, error) {
+	entries, err := os.ReadDir(filepath.Join(stateDir, snapshotsDirName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var snaps []envSnapshot
+	for _, e := range entries {
+		if !e.IsDir() || validateSnapshotName(e.Name()) != nil {
+			continue
+		}
+		if snap, err := readEnvSnapshot(e.Name()); err == nil {
+			snaps = append(snaps, snap)
+		}
+	}
+	sort.Slice(snaps, func(i, j int) bool { return snaps[i].CreatedAt.After(snaps[j].CreatedAt) })
+	return snaps, nil
+}
+
+// createEnvSnapshot snapshots the data of every stateful service, along with
+// the config hash, under name. An existing snapshot is only replaced with
+// force. The snapshot is written aside and moved into place once complete.
+func createEnvSnapshot(cfg PlateConfig, name string, force bool, out io.Writer) error {
+	if err := validateSnapshotName(name); err != nil {
+		return err
+	}
+	dir := snapshotDir(name)
+	if _, err := os.Stat(dir); err == nil && !force {
+		return fmt.Errorf("snapshot '%s' already exists, pass --force to replace it", name)
+	}
+	containers, err := listPlateContainers(cfg.Project)
+	if err != nil {
+		return err
+	}
+	matched := serviceContainers(cfg, containers)
+
+	if err := os.MkdirAll(filepath.Dir(dir), 0755); err != nil {
+		return err
+	}
+	tmp, err := os.MkdirTemp(filepath.Dir(dir), "."+name+"-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	snap := envSnapshot{Name: name, CreatedAt: time.Now().UTC(), ConfigHash: configHash(cfg)}
+	for _, svc := range statefulServices(cfg) {
+		locked := lockedService{Name: svc.Name, Image: imageName(svc), State: "missing"}
+		if ctr, ok := matched[svc.Name]; ok && ctr.managed() {
+			locked.State = ctr.State
+			locked.Digest = imageDigest(imageName(svc))
+			fmt.Fprintf(out, "Snapshotting %s...\n", svc.Name)
+			if err := writeServiceSnapshot(svc, ctr.ID, filepath.Join(tmp, svc.Name+".tar.gz")); err != nil {
+				return err
+			}
+			locked.HasData = true
+		}
+		snap.Services = append(snap.Services, locked)
+	}
+	data, _ := json.MarshalIndent(snap, "", "  ")
+	if err := os.WriteFile(filepath.Join(tmp, snapshotManifestName), data, 0644); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	return os.Rename(tmp, dir)
+}
+
+func writeServiceSnapshot(svc ServiceConfig, containerID, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return snapshotServiceData(svc, containerID, f)
+}
+
+// restoreEnvSnapshot resets every service in the snapshot to its data, and
+// starts the ones that were running when it was taken. Containers that are
+// gone are recreated first. A snapshot of a different config is only
+// restored with force.
+func restoreEnvSnapshot(cfg PlateConfig, name string, force bool, out io.Writer) error {
+	if err := validateSnapshotName(name); err != nil {
+		return err
+	}
+	snap, err := readEnvSnapshot(name)
+	if err != nil {
+		return err
+	}
+	if hash := configHash(cfg); hash != snap.ConfigHash && !force {
+		return fmt.Errorf("the config changed since snapshot '%s' was taken (%s, now %s), pass --force to restore it anyway", name, snap.ConfigHash, hash)
+	}
+	containers, err := listPlateContainers(cfg.Project)
+	if err != nil {
+		return err
+	}
+	matched := serviceContainers(cfg, containers)
+	services := map[string]ServiceConfig{}
+	for _, svc := range cfg.containerServices() {
+		services[svc.Name] = svc
+	}
+
+	for _, locked := range snap.Services {
+		svc, ok := services[locked.Name]
+		switch {
+		case !ok:
+			fmt.Fprintf(out, "%s %s: no longer in the config, skipped\n", stoppedStyle.Render("-"), locked.Name)
+			continue
+		case !locked.HasData:
+			fmt.Fprintf(out, "%s %s: had no container, left as is\n", stoppedStyle.Render("-"), locked.Name)
+			continue
+		}
+		err := restoreServiceSnapshot(svc, matched[svc.Name], filepath.Join(snapshotDir(name), svc.Name+".tar.gz"), locked.State == "running")
+		recordAction("restore", svc.Name, containerName(svc), "plate snapshot restore "+name, err)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "%s %s: restored\n", successStyle.Render("✓"), svc.Name)
+	}
+	return nil
+}
+
+// restoreServiceSnapshot replaces the data of a service's container, which
+// is created if ctr is nil, and starts it again if start is set.
+func restoreServiceSnapshot(svc ServiceConfig, ctr *containerInfo, path string, start bool) error {
+	var containerID string
+	switch {
+	case ctr == nil:
+		if !hasImage(svc) {
+			if err := obtainImage(svc, io.Discard); err != nil {
+				return err
+			}
+		}
+		id, err := createServiceContainer(svc)
+		if err != nil {
+			return err
+		}
+		containerID = id
+	case !ctr.managed():
+		return fmt.Errorf("%s: container %s isn't managed by Plate", svc.Name, ctr.Name)
+	default:
+		containerID = ctr.ID
+		if ctr.State == "running" {
+			if output, err := dockerCommand("stop", containerID).CombinedOutput(); err != nil {
+				return fmt.Errorf("could not stop %s: %s", svc.Name, output)
+			}
+		}
+	}
+
+	data, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer data.Close()
+	if err := restoreServiceData(svc, containerID, data); err != nil {
+		return err
+	}
+	if start {
+		if err := dockerCommand("start", containerID).Run(); err != nil {
+			return fmt.Errorf("could not start %s: %w", svc.Name, err)
+		}
+	}
+	return nil
+}
+
+// handleSnapshotCmd manages named save points of the whole environment.
+func handleSnapshotCmd(args []string) {
+	usage := "Usage: plate snapshot [create [--force] <name> | restore [--force] <name> | list | delete <name>]"
+	sub := "list"
+	if len(args) > 0 {
+		sub, args = args[0], args[1:]
+	}
+	fs := flag.NewFlagSet("snapshot "+sub, flag.ExitOnError)
+	force := fs.Bool("force", false, "replace an existing snapshot, or restore one taken with a different config")
+	addConfigFlag(fs)
+	fs.Parse(args)
+
+	switch sub {
+	case "list":
+		snaps, err := listEnvSnapshots()
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		if len(snaps) == 0 {
+			fmt.Println("No snapshots yet. Take one with 'plate snapshot create <name>'.")
+			return
+		}
+		for _, snap := range snaps {
+			fmt.Printf("%s  %s  %s\n", detailAttrStyle.Render(snap.Name), snap.CreatedAt.Local().Format("2006-01-02 15:04"), stoppedStyle.Render("config "+snap.ConfigHash))
+		}
+	case "create", "restore":
+		if fs.NArg() != 1 {
+			fmt.Println(usage)
+			os.Exit(exitUsage)
+		}
+		name := fs.Arg(0)
+		plateConfig := mustResolveBuilds(mustLoadConfig(configPathArg(fs)))
+		mustHaveDockerAccess(plateConfig)
+		if sub == "create" {
+			if err := createEnvSnapshot(plateConfig, name, *force, os.Stdout); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("✅ Snapshot '%s' created. Go back to it with 'plate snapshot restore %s'.\n", name, name)
+			return
+		}
+		lock := mustAcquireLock(*force)
+		defer lock.release()
+		if err := restoreEnvSnapshot(plateConfig, name, *force, os.Stdout); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			lock.release()
+			os.Exit(exitCodeFor(err))
+		}
+		fmt.Printf("✅ Restored snapshot '%s'.\n", name)
+	case "delete":
+		if fs.NArg() != 1 || validateSnapshotName(fs.Arg(0)) != nil {
+			fmt.Println(usage)
+			os.Exit(exitUsage)
+		}
+		if _, err := readEnvSnapshot(fs.Arg(0)); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		if err := os.RemoveAll(snapshotDir(fs.Arg(0))); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Deleted snapshot '%s'.\n", fs.Arg(0))
+	default:
+		fmt.Println(usage)
+		os.Exit(exitUsage)
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateSnapshotName(t *testing.T) {
+	tests := []struct {
+		name  string
+		valid bool
+	}{
+		{"before-migration", true},
+		{"v1.2_rc", true},
+		{"", false},
+		{".hidden", false},
+		{"..", false},
+		{"a/b", false},
+		{"with space", false},
+	}
+	for _, tt := range tests {
+		if err := validateSnapshotName(tt.name); (err == nil) != tt.valid {
+			t.Errorf("%q: Expected valid=%v, got %v", tt.name, tt.valid, err)
+		}
+	}
+}
+
+func writeTestSnapshot(t *testing.T, snap envSnapshot) {
+	t.Helper()
+	os.MkdirAll(snapshotDir(snap.Name), 0755)
+	data, _ := json.Marshal(snap)
+	if err := os.WriteFile(filepath.Join(snapshotDir(snap.Name), snapshotManifestName), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListEnvSnapshots(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	if snaps, err := listEnvSnapshots(); err != nil || len(snaps) != 0 {
+		t.Fatalf("Expected no snapshots, got %v, %v", snaps, err)
+	}
+	now := time.Now()
+	writeTestSnapshot(t, envSnapshot{Name: "old", CreatedAt: now.Add(-time.Hour)})
+	writeTestSnapshot(t, envSnapshot{Name: "new", CreatedAt: now})
+	os.MkdirAll(filepath.Join(stateDirName, snapshotsDirName, ".new-123"), 0755) // unfinished
+
+	snaps, err := listEnvSnapshots()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(snaps) != 2 || snaps[0].Name != "new" || snaps[1].Name != "old" {
+		t.Errorf("Expected new then old, got %+v", snaps)
+	}
+}
+
+func TestRestoreEnvSnapshotChecksConfig(t *testing.T) {
+	t.Chdir(t.TempDir())
+	cfg := PlateConfig{Project: "demo", Services: []ServiceConfig{{Type: "postgres", Name: "main-db", Version: "16", Port: 5432}}}
+
+	if err := restoreEnvSnapshot(cfg, "missing", false, io.Discard); err == nil || !strings.Contains(err.Error(), "no snapshot named") {
+		t.Errorf("Expected a missing snapshot error, got %v", err)
+	}
+
+	writeTestSnapshot(t, envSnapshot{Name: "before", ConfigHash: "0123456789ab"})
+	err := restoreEnvSnapshot(cfg, "before", false, io.Discard)
+	if err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Errorf("Expected a changed config to need --force, got %v", err)
+	}
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	mathrand "math/rand/v2"
+	"os"
+	"strings"
+	"time"
+)
+
+// --- EPHEMERAL ENVIRONMENTS ---
+
+const (
+	// defaultEphemeralTTL is how long an ephemeral environment lives without --ttl.
+	defaultEphemeralTTL = 2 * time.Hour
+	// ephemeralPortBase and ephemeralPortSpread bound the random port an
+	// ephemeral environment starts looking for free ports from.
+	ephemeralPortBase   = 20000
+	ephemeralPortSpread = 20000
+)
+
+// newEphemeralID returns a short random id that keeps an ephemeral
+// environment's containers apart from every other's.
+func newEphemeralID() string {
+	b := make([]byte, 3)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// ephemeralServices returns copies of the config's container services for
+// the ephemeral environment id: uniquely named, labelled with their own
+// project so no other Plate instance treats them as its own, on free ports
+// from a random start, and labelled to expire at expires.
+func ephemeralServices(cfg PlateConfig, id string, expires time.Time, firstPort int, inUse func(port int) bool) []ServiceConfig {
+	services := throwawayServices(cfg, "plate-eph-"+id+"-{type}-{name}", firstPort, inUse)
+	for i := range services {
+		services[i].project = cfg.Project + "-eph-" + id
+		services[i].expires = expires
+	}
+	return services
+}
+
+// expired reports whether an ephemeral container's time is up. Containers
+// without a readable expiry are never reaped.
+func expired(ctr containerInfo, now time.Time) bool {
+	at, err := time.Parse(time.RFC3339, ctr.Labels[labelExpires])
+	return err == nil && !now.Before(at)
+}
+
+// reapEphemeral removes ephemeral containers, and their volumes, whose time
+// is up, plus every one of project when that is set. It returns the number
+// it couldn't remove.
+func reapEphemeral(now time.Time, project string, out io.Writer) (int, error) {
+	output, err := dockerCommand("ps", "-a", "-q", "--no-trunc", "--filter", "label="+labelExpires).Output()
+	if err != nil {
+		return 0, fmt.Errorf("could not list containers: %w", err)
+	}
+	containers, err := inspectContainers(strings.Fields(string(output))...)
+	if err != nil {
+		return 0, err
+	}
+	failures := 0
+	for _, ctr := range containers {
+		ofProject := project != "" && strings.HasPrefix(ctr.Labels[labelProject], project+"-eph-")
+		if !ctr.managed() || (!ofProject && !expired(ctr, now)) {
+			continue
+		}
+		args := []string{"rm", "-f", "-v", ctr.ID}
+		err := dockerCommand(args...).Run()
+		recordAction("reap", ctr.Labels[labelService], ctr.Name, dockerCommandLine(args...), err)
+		if err != nil {
+			failures++
+			reportFailure(out, ctr.Name, err)
+			continue
+		}
+		fmt.Fprintf(out, "%s Removed ephemeral %s\n", stoppedStyle.Render("🧹"), ctr.Name)
+	}
+	return failures, nil
+}
+
+// upEphemeral creates and starts the services and waits until each passes
+// its health check, removing them all again if one fails.
+func upEphemeral(services []ServiceConfig, out io.Writer, events *progressEmitter) error {
+	for i, svc := range services {
+		fmt.Fprintf(out, "Starting %s...\n", svc.Name)
+		err := upEphemeralService(svc, events)
+		recordAction("create", svc.Name, containerName(svc), "plate up --ephemeral", err)
+		if err != nil {
+			events.emit(progressEvent{Phase: phaseFailed, Service: svc.Name, Error: err.Error()})
+			for _, created := range services[:i+1] {
+				dockerCommand("rm", "-f", "-v", containerName(created)).Run()
+			}
+			return fmt.Errorf("%s: %w", svc.Name, err)
+		}
+	}
+	return nil
+}
+
+func upEphemeralService(svc ServiceConfig, events *progressEmitter) error {
+	switch {
+	case hasImage(svc):
+	case svc.Build != nil:
+		events.emit(progressEvent{Phase: phaseBuilding, Service: svc.Name, Image: imageName(svc)})
+	case events != nil:
+		// Pulled here rather than by createService, to report its progress.
+		if err := pullWithEvents(svc, events); err != nil {
+			return err
+		}
+	}
+	events.emit(progressEvent{Phase: phaseStarting, Service: svc.Name})
+	if err := createService(svc); err != nil {
+		return err
+	}
+	if err := waitForService(svc, containerName(svc), defaultIntervals.health); err != nil {
+		return err
+	}
+	events.emit(progressEvent{Phase: phaseHealthy, Service: svc.Name})
+	return nil
+}
+
+// handleUpCmd brings up a throwaway copy of the environment next to the
+// real one.
+func handleUpCmd(args []string) {
+	fs := flag.NewFlagSet("up", flag.ExitOnError)
+	addConfigFlag(fs)
+	ephemeral := fs.Bool("ephemeral", false, "start a throwaway copy of the services on random ports")
+	ttl := fs.Duration("ttl", defaultEphemeralTTL, "how long the ephemeral environment lives")
+	progress := fs.String("progress", "text", "how to report progress: text, or json for one event per line")
+	addOutputFlags(fs)
+	fs.Parse(args)
+	if *progress != "text" && *progress != "json" {
+		fmt.Println("Error: --progress must be text or json.")
+		os.Exit(exitUsage)
+	}
+	if !*ephemeral {
+		fmt.Println("Usage: plate up --ephemeral [--ttl 2h] [--progress json]")
+		fmt.Println("Run 'plate' or 'plate apply' to bring up the environment itself.")
+		os.Exit(exitUsage)
+	}
+	if *ttl <= 0 {
+		fmt.Println("Error: --ttl must be positive.")
+		os.Exit(exitUsage)
+	}
+	plateConfig := mustResolveBuilds(mustLoadConfig(configPathArg(fs)))
+	mustHaveDockerAccess(plateConfig)
+	// With --progress json, stdout is only events, and errors go to stderr.
+	out, errOut := progressOut(), io.Writer(os.Stdout)
+	var events *progressEmitter
+	if *progress == "json" {
+		out, errOut, events = io.Discard, os.Stderr, newProgressEmitter(os.Stdout)
+	}
+	if _, err := reapEphemeral(time.Now(), "", out); err != nil {
+		fmt.Fprintf(errOut, "Error: %v\n", err)
+		os.Exit(exitDocker)
+	}
+
+	id := newEphemeralID()
+	expires := time.Now().Add(*ttl).Truncate(time.Second)
+	firstPort := ephemeralPortBase + mathrand.IntN(ephemeralPortSpread)
+	services := ephemeralServices(plateConfig, id, expires, firstPort, func(port int) bool { return !portFree(port) })
+	if len(services) == 0 {
+		fmt.Fprintln(out, "No container services to start.")
+		events.emit(progressEvent{Phase: phaseDone})
+		return
+	}
+	if err := upEphemeral(services, out, events); err != nil {
+		fmt.Fprintf(errOut, "Error: %v\n", err)
+		os.Exit(exitCodeFor(err))
+	}
+
+	env := map[string]string{}
+	for _, svc := range services {
+		env[envVarName(svc)], _ = getConnectionString(svc)
+	}
+	if events != nil {
+		events.emit(progressEvent{Phase: phaseDone, Env: env})
+		return
+	}
+	// Quiet output is only the variables, ready for eval or a .env file.
+	fmt.Fprintf(out, "\n✅ Ephemeral environment %s is up until %s:\n\n", detailAttrStyle.Render(id), expires.Format("15:04 (Jan 2)"))
+	for _, svc := range services {
+		fmt.Printf("%s=%s\n", envVarName(svc), env[envVarName(svc)])
+	}
+	fmt.Fprintln(out, "\nOnce it expires, the next 'plate', 'plate up' or 'plate reap' removes it; 'plate reap --all' removes it right away.")
+}
+
+// handleReapCmd removes expired ephemeral environments.
+func handleReapCmd(args []string) {
+	fs := flag.NewFlagSet("reap", flag.ExitOnError)
+	addConfigFlag(fs)
+	all := fs.Bool("all", false, "also remove this project's ephemeral environments that haven't expired")
+	fs.Parse(args)
+	plateConfig := mustLoadConfig(configPathArg(fs))
+	mustHaveDockerAccess(plateConfig)
+	project := ""
+	if *all {
+		project = plateConfig.Project
+	}
+	failures, err := reapEphemeral(time.Now(), project, os.Stdout)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitDocker)
+	}
+	if failures > 0 {
+		fmt.Printf("\n%d container(s) could not be removed.\n", failures)
+		os.Exit(exitPartial)
+	}
+}
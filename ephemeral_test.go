@@ -0,0 +1,59 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEphemeralServices(t *testing.T) {
+	cfg := PlateConfig{Project: "shop", Services: []ServiceConfig{
+		{Type: "postgres", Name: "db", Version: "16", Port: 5432},
+		{Type: "redis", Name: "cache", Version: "7", Port: 6379},
+		{Type: "process", Name: "web", Command: "npm start"},
+	}}
+	expires := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	bound := map[int]bool{25000: true}
+	services := ephemeralServices(cfg, "a1b2c3", expires, 25000, func(port int) bool { return bound[port] })
+
+	if len(services) != 2 {
+		t.Fatalf("Expected 2 container services, got %d", len(services))
+	}
+	if services[0].Port != 25001 || services[1].Port != 25002 {
+		t.Errorf("Expected ports 25001 and 25002, got %d and %d", services[0].Port, services[1].Port)
+	}
+	if name := containerName(services[0]); name != "plate-eph-a1b2c3-postgres-db" {
+		t.Errorf("Expected a unique container name, got %s", name)
+	}
+	_, args, err := getDockerRunArgs(services[0], containerName(services[0]))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	joined := strings.Join(args, " ")
+	for _, label := range []string{labelExpires + "=2026-10-17T12:00:00Z", labelProject + "=shop-eph-a1b2c3"} {
+		if !strings.Contains(joined, label) {
+			t.Errorf("Expected the label %s, got %s", label, joined)
+		}
+	}
+}
+
+func TestExpired(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		label string
+		want  bool
+	}{
+		{"2026-10-17T11:59:59Z", true},
+		{"2026-10-17T12:00:00Z", true},
+		{"2026-10-17T14:00:00+02:00", true},
+		{"2026-10-17T12:00:01Z", false},
+		{"", false},
+		{"soon", false},
+	}
+	for _, tt := range tests {
+		ctr := containerInfo{Labels: map[string]string{labelExpires: tt.label}}
+		if got := expired(ctr, now); got != tt.want {
+			t.Errorf("%q: Expected %v, got %v", tt.label, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// --- DOCKER EVENTS ---
+
+// While `docker events` streams, changes to containers reach the TUI as
+// they happen, and reconciling on a timer is only a safety net.
+const (
+	// eventsReconcileInterval is the reconcile interval while events stream.
+	eventsReconcileInterval = time.Minute
+	// eventsRetryDelay is how long Plate waits before subscribing again
+	// after the stream ended, e.g. because the daemon restarted.
+	eventsRetryDelay = 5 * time.Second
+)
+
+// containerEventActions are the container events the TUI reacts to.
+var containerEventActions = []string{"start", "die", "stop", "oom", "destroy"}
+
+// containerEventMsg is a docker event for a container.
+type containerEventMsg struct {
+	id     string
+	action string
+}
+
+// containerEventsMsg reports that the event stream started or ended.
+type containerEventsMsg struct {
+	live bool
+}
+
+// parseContainerEvent reads one line of `docker events --format
+// '{{json .}}'`. Older daemons only fill in status and id.
+func parseContainerEvent(line string) (containerEventMsg, bool) {
+	var ev struct {
+		Status string `json:"status"`
+		ID     string `json:"id"`
+		Action string `json:"Action"`
+		Actor  struct {
+			ID string `json:"ID"`
+		} `json:"Actor"`
+	}
+	if err := json.Unmarshal([]byte(line), &ev); err != nil {
+		return containerEventMsg{}, false
+	}
+	msg := containerEventMsg{id: firstNonEmpty(ev.Actor.ID, ev.ID), action: firstNonEmpty(ev.Action, ev.Status)}
+	return msg, msg.id != "" && msg.action != ""
+}
+
+// eventsArgs returns the docker arguments that stream container events.
+func eventsArgs() []string {
+	args := []string{"events", "--format", "{{json .}}", "--filter", "type=container"}
+	for _, action := range containerEventActions {
+		args = append(args, "--filter", "event="+action)
+	}
+	return args
+}
+
+// watchContainerEvents forwards container events to the program until stop
+// is called, subscribing again whenever the stream ends.
+func watchContainerEvents(p *tea.Program) (stop func()) {
+	done := make(chan struct{})
+	var mu sync.Mutex
+	var current *os.Process
+	go func() {
+		for {
+			cmd := dockerCommand(eventsArgs()...)
+			stdout, err := cmd.StdoutPipe()
+			if err == nil {
+				err = cmd.Start()
+			}
+			if err == nil {
+				mu.Lock()
+				current = cmd.Process
+				select {
+				case <-done:
+					// stop ran while this stream started.
+					current.Kill()
+					mu.Unlock()
+					return
+				default:
+				}
+				mu.Unlock()
+				p.Send(containerEventsMsg{live: true})
+				scanner := bufio.NewScanner(stdout)
+				for scanner.Scan() {
+					if msg, ok := parseContainerEvent(strings.TrimSpace(scanner.Text())); ok {
+						p.Send(msg)
+					}
+				}
+				cmd.Wait()
+			}
+			select {
+			case <-done:
+				return
+			default:
+			}
+			p.Send(containerEventsMsg{live: false})
+			select {
+			case <-done:
+				return
+			case <-time.After(eventsRetryDelay):
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		mu.Lock()
+		defer mu.Unlock()
+		if current != nil {
+			current.Kill()
+		}
+	}
+}
+
+// reconcileInterval is how often containers are reconciled on a timer:
+// rarely while events stream, since they report changes right away.
+func (m model) reconcileInterval() time.Duration {
+	if m.eventsLive {
+		return max(m.intervals.reconcile, eventsReconcileInterval)
+	}
+	return m.intervals.reconcile
+}
+
+// handleContainerEvent re-reads the state of a service's container that
+// docker reported a change for. Events for other containers are ignored.
+func (m model) handleContainerEvent(msg containerEventMsg) tea.Cmd {
+	for _, itm := range m.items {
+		if it := itm.(item); it.containerID != "" && it.containerID == msg.id {
+			return reconcileEventCmd(msg.id)
+		}
+	}
+	return nil
+}
+
+// handleContainerEvents notes whether events stream. When the stream ends,
+// the containers are reconciled right away, since events may have been
+// missed.
+func (m *model) handleContainerEvents(msg containerEventsMsg) tea.Cmd {
+	m.eventsLive = msg.live
+	if msg.live {
+		return nil
+	}
+	var ids []string
+	for _, itm := range m.items {
+		if it := itm.(item); it.containerID != "" && (it.status == statusRunning || it.status == statusStopped) {
+			ids = append(ids, it.containerID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	return reconcileEventCmd(ids...)
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+func TestParseContainerEvent(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected containerEventMsg
+		ok       bool
+	}{
+		{`{"status":"die","id":"abc","Type":"container","Action":"die","Actor":{"ID":"abc","Attributes":{"exitCode":"137"}}}`, containerEventMsg{id: "abc", action: "die"}, true},
+		{`{"status":"start","id":"def"}`, containerEventMsg{id: "def", action: "start"}, true},
+		{`{"Type":"container"}`, containerEventMsg{}, false},
+		{`not json`, containerEventMsg{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseContainerEvent(tt.line)
+		if ok != tt.ok || got != tt.expected {
+			t.Errorf("%s: Expected %+v (%v), got %+v (%v)", tt.line, tt.expected, tt.ok, got, ok)
+		}
+	}
+}
+
+func TestContainerEvents(t *testing.T) {
+	m := model{
+		items:     []list.Item{item{config: ServiceConfig{Name: "db"}, status: statusRunning, containerID: "abc"}},
+		intervals: pollIntervals{reconcile: 10 * time.Second},
+	}
+	if m.handleContainerEvent(containerEventMsg{id: "abc", action: "die"}) == nil {
+		t.Errorf("Expected an event for a service's container to reconcile it")
+	}
+	if m.handleContainerEvent(containerEventMsg{id: "other", action: "die"}) != nil {
+		t.Errorf("Expected events for other containers to be ignored")
+	}
+
+	m.handleContainerEvents(containerEventsMsg{live: true})
+	if got := m.reconcileInterval(); got != eventsReconcileInterval {
+		t.Errorf("Expected reconciling every %s while events stream, got %s", eventsReconcileInterval, got)
+	}
+	if m.handleContainerEvents(containerEventsMsg{live: false}) == nil {
+		t.Errorf("Expected a reconcile when the stream ends")
+	}
+	if got := m.reconcileInterval(); got != 10*time.Second {
+		t.Errorf("Expected the configured interval without events, got %s", got)
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// --- EXIT CODES ---
+
+// Exit codes of the commands other than the TUI, so scripts can tell what
+// went wrong. They are documented in the README; don't renumber them.
+const (
+	exitError    = 1 // anything not covered below
+	exitUsage    = 2 // bad arguments, the same code the flag package uses
+	exitConfig   = 3 // the config is missing or invalid
+	exitDocker   = 4 // docker isn't running, or Plate may not use it
+	exitPartial  = 5 // some services or containers failed, the others are done
+	exitNotReady = 6 // a service didn't pass its health check in time
+	exitLocked   = 7 // another Plate instance holds the project's lock
+)
+
+// notReadyError is a service that didn't pass its health check in time.
+type notReadyError struct {
+	service string
+	after   time.Duration
+}
+
+func (e *notReadyError) Error() string {
+	return fmt.Sprintf("%s wasn't ready after %s", e.service, e.after)
+}
+
+// exitCodeFor returns the exit code for an error that ends a command.
+func exitCodeFor(err error) int {
+	var notReady *notReadyError
+	if errors.As(err, &notReady) {
+		return exitNotReady
+	}
+	return exitError
+}
+
+// failuresExitCode returns the exit code for a command some of whose
+// services failed: exitNotReady if one of them timed out, else exitPartial.
+func failuresExitCode(errs []error) int {
+	for _, err := range errs {
+		if exitCodeFor(err) == exitNotReady {
+			return exitNotReady
+		}
+	}
+	return exitPartial
+}
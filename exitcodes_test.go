@@ -0,0 +1,30 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestFailuresExitCode(t *testing.T) {
+	notReady := fmt.Errorf("databases: %w", &notReadyError{service: "main-db", after: readyTimeout})
+	tests := []struct {
+		name     string
+		errs     []error
+		expected int
+	}{
+		{"failures", []error{errors.New("port is taken")}, exitPartial},
+		{"timeout", []error{errors.New("port is taken"), notReady}, exitNotReady},
+	}
+	for _, tt := range tests {
+		if code := failuresExitCode(tt.errs); code != tt.expected {
+			t.Errorf("%s: Expected exit code %d, got %d", tt.name, tt.expected, code)
+		}
+	}
+	if code := exitCodeFor(notReady); code != exitNotReady {
+		t.Errorf("Expected exit code %d for a timeout, got %d", exitNotReady, code)
+	}
+	if msg := notReady.Error(); msg != "databases: main-db wasn't ready after 1m0s" {
+		t.Errorf("Expected the message to be unchanged, got %s", msg)
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// --- EXIT STATUS ---
+
+// oomExitCode is what a container killed with SIGKILL exits with, which is
+// what the kernel's OOM killer sends.
+const oomExitCode = 137
+
+// memoryPattern matches docker's memory sizes, such as 512m or 2g.
+var memoryPattern = regexp.MustCompile(`^[0-9]+[bkmgBKMG]?$`)
+
+// containerExit is how a container's last run ended.
+type containerExit struct {
+	code      int
+	oomKilled bool
+}
+
+// exitOf reads how a stopped container ended.
+func exitOf(ctr containerInfo) containerExit {
+	return containerExit{code: ctr.ExitCode, oomKilled: ctr.OOMKilled}
+}
+
+// String describes the exit, e.g. "Exited (137) — killed: out of memory".
+func (e containerExit) String() string {
+	s := fmt.Sprintf("Exited (%d)", e.code)
+	switch {
+	case e.oomKilled:
+		s += " — killed: out of memory"
+	case e.code == oomExitCode:
+		s += " — killed"
+	}
+	return s
+}
+
+// failed reports whether the exit was anything but a clean one.
+func (e containerExit) failed() bool {
+	return e.code != 0 || e.oomKilled
+}
+
+// memoryHint tells what to do about a service that ran out of memory: raise
+// its limit, or, without one, give docker more.
+func memoryHint(config ServiceConfig) string {
+	if config.Memory != "" {
+		return fmt.Sprintf("It hit its memory limit of %s. Raise \"memory\" in the config, then press b to start it again.", config.Memory)
+	}
+	return "Docker ran out of memory. Give Docker Desktop or colima more memory, or set a \"memory\" limit on the services that need less, then press b to start it again."
+}
+
+// validateMemory checks every service's memory limit.
+func validateMemory(cfg PlateConfig) error {
+	for _, svc := range cfg.Services {
+		if svc.Memory == "" {
+			continue
+		}
+		if svc.isProcess() {
+			return fmt.Errorf("service %s: memory is for container services", svc.Name)
+		}
+		if !memoryPattern.MatchString(svc.Memory) {
+			return fmt.Errorf("service %s: memory %q must be a size like 512m or 2g", svc.Name, svc.Memory)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestContainerExit(t *testing.T) {
+	infos, err := parseInspectOutput([]byte(`[{"Id": "abc", "Name": "/plate-postgres-db", "State": {"Status": "exited", "ExitCode": 137, "OOMKilled": true}}]`))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	tests := []struct {
+		exit     containerExit
+		expected string
+	}{
+		{exitOf(infos[0]), "Exited (137) — killed: out of memory"},
+		{containerExit{code: 137}, "Exited (137) — killed"},
+		{containerExit{code: 1}, "Exited (1)"},
+		{containerExit{}, "Exited (0)"},
+	}
+	for _, tt := range tests {
+		if got := tt.exit.String(); got != tt.expected {
+			t.Errorf("Expected %q, got %q", tt.expected, got)
+		}
+	}
+
+	it := item{config: ServiceConfig{Name: "db"}, status: statusStopped, exit: &tests[0].exit}
+	if got := it.Description(); !strings.Contains(got, "killed: out of memory") {
+		t.Errorf("Expected the OOM kill in the description, got %q", got)
+	}
+	if hint := memoryHint(ServiceConfig{Memory: "512m"}); !strings.Contains(hint, "512m") {
+		t.Errorf("Expected the hint to name the limit, got %q", hint)
+	}
+}
+
+func TestServiceMemory(t *testing.T) {
+	svc := ServiceConfig{Type: "postgres", Name: "db", Version: "16", Port: 5433, Memory: "512m"}
+	_, args, err := getDockerRunArgs(svc, "plate-postgres-db")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if i := slices.Index(args, "--memory"); i < 0 || args[i+1] != "512m" {
+		t.Errorf("Expected the memory limit passed to docker run, got %v", args)
+	}
+	for _, memory := range []string{"512", "2g", "1024M"} {
+		if err := validateMemory(PlateConfig{Services: []ServiceConfig{{Type: "postgres", Name: "db", Memory: memory}}}); err != nil {
+			t.Errorf("%s: Expected no error, got %v", memory, err)
+		}
+	}
+	if err := validateMemory(PlateConfig{Services: []ServiceConfig{{Type: "postgres", Name: "db", Memory: "lots"}}}); err == nil {
+		t.Errorf("Expected an error for a memory limit that isn't a size")
+	}
+}
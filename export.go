@@ -0,0 +1,368 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// --- EXPORTS ---
+
+// handleExportCmd dispatches `plate export <target>`.
+func handleExportCmd(args []string) {
+	if len(args) == 0 {
+		fmt.Println("Usage: plate export <devcontainer|gha|procfile|mprocs|tableplus|dbeaver|images> [flags]")
+		os.Exit(exitUsage)
+	}
+	switch args[0] {
+	case "devcontainer":
+		handleExportDevcontainerCmd(args[1:])
+	case "gha":
+		handleExportGHACmd(args[1:])
+	case "procfile":
+		handleExportProcessesCmd("procfile", "Procfile", renderProcfile, args[1:])
+	case "mprocs":
+		handleExportProcessesCmd("mprocs", "mprocs.yaml", renderMprocs, args[1:])
+	case "tableplus":
+		handleExportClientCmd("tableplus", "plate.tableplusconnection", tablePlusDrivers, renderTablePlus, args[1:])
+	case "dbeaver":
+		handleExportClientCmd("dbeaver", ".dbeaver/data-sources.json", dbeaverDrivers, renderDBeaver, args[1:])
+	case "images":
+		handleExportImagesCmd(args[1:])
+	default:
+		fmt.Printf("Error: Unknown export target '%s'.\n", args[0])
+		os.Exit(exitUsage)
+	}
+}
+
+// yamlQuote quotes a string for YAML. JSON strings are valid YAML scalars.
+func yamlQuote(s string) string {
+	return strconv.Quote(s)
+}
+
+// composeQuote quotes a string for a compose file, where "$" would start a
+// variable that compose fills in from the host's environment.
+func composeQuote(s string) string {
+	return yamlQuote(strings.ReplaceAll(s, "$", "$$"))
+}
+
+// writeExportFile writes a generated file, refusing to overwrite unless force is set.
+func writeExportFile(path string, data []byte, force bool) error {
+	if _, err := os.Stat(path); err == nil && !force {
+		return fmt.Errorf("'%s' already exists, pass --force to overwrite it", path)
+	} else if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// handleExportDevcontainerCmd writes a docker-compose file and a
+// devcontainer.json that bring the same services up in a dev container.
+func handleExportDevcontainerCmd(args []string) {
+	fs := flag.NewFlagSet("export devcontainer", flag.ExitOnError)
+	addConfigFlag(fs)
+	dir := fs.String("o", ".devcontainer", "directory to write the files to")
+	force := fs.Bool("force", false, "overwrite existing files")
+	fs.Parse(args)
+	plateConfig := mustLoadConfig(configPathArg(fs))
+
+	compose, err := renderDevcontainerCompose(plateConfig)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	files := []struct {
+		path string
+		data []byte
+	}{
+		{filepath.Join(*dir, "docker-compose.plate.yml"), compose},
+		{filepath.Join(*dir, "devcontainer.json"), renderDevcontainerJSON(plateConfig)},
+	}
+	for _, f := range files {
+		if err := writeExportFile(f.path, f.data, *force); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("✅ Wrote '%s'.\n", f.path)
+	}
+}
+
+// renderDevcontainerCompose renders a compose file with every service plus
+// an "app" workspace container that gets their connection strings.
+func renderDevcontainerCompose(cfg PlateConfig) ([]byte, error) {
+	var b strings.Builder
+	b.WriteString("# Generated by 'plate export devcontainer'. Re-run it after changing the Plate config.\n")
+	b.WriteString("services:\n")
+	b.WriteString("  app:\n")
+	b.WriteString("    image: \"mcr.microsoft.com/devcontainers/base:ubuntu\"\n")
+	b.WriteString("    command: \"sleep infinity\"\n")
+	b.WriteString("    volumes:\n")
+	b.WriteString(fmt.Sprintf("      - %s\n", composeQuote("..:/workspaces/"+cfg.Project+":cached")))
+
+	services := cfg.containerServices()
+	if len(services) > 0 {
+		b.WriteString("    environment:\n")
+	}
+	for _, svc := range services {
+		spec, err := getServiceSpec(svc)
+		if err != nil {
+			return nil, err
+		}
+		// The compose services run without TLS or a replica set.
+		svc.TLS, svc.ReplicaSet = false, false
+		connStr, _ := connectionStringFor(svc, svc.Name, spec.ContainerPort)
+		b.WriteString(fmt.Sprintf("      %s: %s\n", envVarName(svc), composeQuote(connStr)))
+	}
+	if len(services) > 0 {
+		b.WriteString("    depends_on:\n")
+		for _, svc := range services {
+			b.WriteString(fmt.Sprintf("      - %s\n", yamlQuote(svc.Name)))
+		}
+	}
+
+	var volumes []string
+	for _, svc := range services {
+		svc.TLS, svc.ReplicaSet = false, false
+		spec, _ := getServiceSpec(svc)
+		b.WriteString(fmt.Sprintf("  %s:\n", yamlQuote(svc.Name)))
+		b.WriteString(fmt.Sprintf("    image: %s\n", yamlQuote(spec.Image)))
+		if svc.Build != nil {
+			writeComposeBuild(&b, *svc.Build)
+		}
+		if len(spec.Args) > 0 {
+			b.WriteString("    command:\n")
+			for _, arg := range spec.Args {
+				b.WriteString(fmt.Sprintf("      - %s\n", composeQuote(arg)))
+			}
+		}
+		b.WriteString("    restart: unless-stopped\n")
+		if len(spec.Env) > 0 {
+			b.WriteString("    environment:\n")
+			for _, kv := range spec.Env {
+				k, v, _ := strings.Cut(kv, "=")
+				b.WriteString(fmt.Sprintf("      %s: %s\n", k, composeQuote(v)))
+			}
+		}
+		if spec.DataDir != "" {
+			volume := svc.Name + "-data"
+			volumes = append(volumes, volume)
+			b.WriteString("    volumes:\n")
+			b.WriteString(fmt.Sprintf("      - %s\n", yamlQuote(volume+":"+spec.DataDir)))
+		}
+		if target := dirMountTarget(svc); target != "" {
+			// Relative to .devcontainer, like build contexts.
+			dir := svc.Dir
+			if !filepath.IsAbs(dir) {
+				dir = filepath.ToSlash(filepath.Join("..", dir))
+			}
+			b.WriteString("    volumes:\n")
+			b.WriteString(fmt.Sprintf("      - %s\n", composeQuote(dir+":"+target+":ro")))
+		}
+	}
+	if len(volumes) > 0 {
+		b.WriteString("volumes:\n")
+		for _, v := range volumes {
+			b.WriteString(fmt.Sprintf("  %s: {}\n", yamlQuote(v)))
+		}
+	}
+	return []byte(b.String()), nil
+}
+
+// renderDevcontainerJSON renders a devcontainer.json using the compose file.
+func renderDevcontainerJSON(cfg PlateConfig) []byte {
+	var forwardPorts []string
+	for _, svc := range cfg.containerServices() {
+		if spec, err := getServiceSpec(svc); err == nil {
+			forwardPorts = append(forwardPorts, fmt.Sprintf("%s:%d", svc.Name, spec.ContainerPort))
+			if spec.UIPort != 0 && spec.UIPort != spec.ContainerPort {
+				forwardPorts = append(forwardPorts, fmt.Sprintf("%s:%d", svc.Name, spec.UIPort))
+			}
+		}
+	}
+	devcontainer := map[string]any{
+		"name":              cfg.Project,
+		"dockerComposeFile": []string{"docker-compose.plate.yml"},
+		"service":           "app",
+		"workspaceFolder":   "/workspaces/" + cfg.Project,
+		"forwardPorts":      forwardPorts,
+	}
+	data, _ := json.MarshalIndent(devcontainer, "", "  ")
+	return append(data, '\n')
+}
+
+// handleExportGHACmd prints (or writes) a GitHub Actions job snippet with a
+// service container for every service.
+func handleExportGHACmd(args []string) {
+	fs := flag.NewFlagSet("export gha", flag.ExitOnError)
+	addConfigFlag(fs)
+	output := fs.String("o", "", "file to write instead of printing to stdout")
+	force := fs.Bool("force", false, "overwrite an existing output file")
+	fs.Parse(args)
+	plateConfig := mustLoadConfig(configPathArg(fs))
+
+	snippet, err := renderGHAServices(plateConfig)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if *output == "" {
+		fmt.Print(string(snippet))
+		return
+	}
+	if err := writeExportFile(*output, snippet, *force); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("✅ Wrote '%s'.\n", *output)
+}
+
+// writeComposeBuild writes a compose build section. The compose file lives
+// in .devcontainer, so relative paths get a "../" prefix.
+func writeComposeBuild(b *strings.Builder, build BuildConfig) {
+	context := build.Context
+	if !filepath.IsAbs(context) {
+		context = filepath.ToSlash(filepath.Join("..", context))
+	}
+	b.WriteString("    build:\n")
+	b.WriteString(fmt.Sprintf("      context: %s\n", composeQuote(context)))
+	if build.Dockerfile != "" {
+		b.WriteString(fmt.Sprintf("      dockerfile: %s\n", composeQuote(build.Dockerfile)))
+	}
+	if len(build.Args) > 0 {
+		b.WriteString("      args:\n")
+		for _, k := range sortedEnvKeys(build.Args) {
+			b.WriteString(fmt.Sprintf("        %s: %s\n", k, composeQuote(build.Args[k])))
+		}
+	}
+}
+
+// renderGHAServices renders the `services:` and `env:` blocks of a GitHub
+// Actions job. Steps run on the runner host, so the env block uses the
+// same localhost connection strings as local development.
+func renderGHAServices(cfg PlateConfig) ([]byte, error) {
+	var b strings.Builder
+	b.WriteString("# Generated by 'plate export gha'. Paste under a job in your workflow.\n")
+	b.WriteString("services:\n")
+	services := cfg.containerServices()
+	for _, svc := range services {
+		// CI runs the services without TLS or a replica set, so their
+		// health checks mustn't expect them either.
+		svc.TLS, svc.ReplicaSet = false, false
+		spec, err := getServiceSpec(svc)
+		if err != nil {
+			return nil, err
+		}
+		if svc.Build != nil {
+			return nil, fmt.Errorf("%s is built from a Dockerfile, which GitHub Actions service containers can't do; push the image to a registry and use that version", svc.Name)
+		}
+		if dirMountTarget(svc) != "" {
+			return nil, fmt.Errorf("%s serves a directory of the repository, which GitHub Actions service containers start before the checkout; serve it from a step instead", svc.Name)
+		}
+		b.WriteString(fmt.Sprintf("  %s:\n", svc.Name))
+		b.WriteString(fmt.Sprintf("    image: %s\n", yamlQuote(spec.Image)))
+		b.WriteString("    ports:\n")
+		b.WriteString(fmt.Sprintf("      - %s\n", yamlQuote(fmt.Sprintf("%d:%d", svc.Port, spec.ContainerPort))))
+		for _, port := range sortedPorts(spec.ExtraPorts) {
+			b.WriteString(fmt.Sprintf("      - %s\n", yamlQuote(fmt.Sprintf("%d:%d", spec.ExtraPorts[port], port))))
+		}
+		if len(spec.Env) > 0 {
+			b.WriteString("    env:\n")
+			for _, kv := range spec.Env {
+				k, v, _ := strings.Cut(kv, "=")
+				b.WriteString(fmt.Sprintf("      %s: %s\n", k, yamlQuote(v)))
+			}
+		}
+		if spec.HealthCmd != "" {
+			options := fmt.Sprintf("--health-cmd %s --health-interval 5s --health-timeout 5s --health-retries 20", strconv.Quote(spec.HealthCmd))
+			b.WriteString(fmt.Sprintf("    options: %s\n", yamlQuote(options)))
+		}
+	}
+
+	if len(services) > 0 {
+		b.WriteString("env:\n")
+		for _, svc := range services {
+			// The steps reach the services on the runner itself.
+			svc.TLS, svc.ReplicaSet = false, false
+			connStr, _ := connectionStringFor(svc, "localhost", svc.Port)
+			b.WriteString(fmt.Sprintf("  %s: %s\n", envVarName(svc), yamlQuote(connStr)))
+		}
+	}
+	return []byte(b.String()), nil
+}
+
+// handleExportProcessesCmd writes the process services in the format of a
+// process runner such as foreman, overmind, or mprocs.
+func handleExportProcessesCmd(target, defaultPath string, render func(PlateConfig) []byte, args []string) {
+	fs := flag.NewFlagSet("export "+target, flag.ExitOnError)
+	addConfigFlag(fs)
+	output := fs.String("o", defaultPath, "file to write")
+	force := fs.Bool("force", false, "overwrite an existing file")
+	fs.Parse(args)
+	plateConfig := mustLoadConfig(configPathArg(fs))
+
+	if len(plateConfig.processServices()) == 0 {
+		fmt.Println("Error: The config has no process services to export.")
+		os.Exit(1)
+	}
+	if err := writeExportFile(*output, render(plateConfig), *force); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("✅ Wrote '%s'.\n", *output)
+}
+
+// shellQuote quotes s for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// renderProcfile renders one Procfile line per process service. Procfiles
+// have no fields for the directory or env, so they go into the command.
+func renderProcfile(cfg PlateConfig) []byte {
+	var b strings.Builder
+	for _, svc := range cfg.processServices() {
+		command := svc.Command
+		if len(svc.Env) > 0 {
+			var assignments []string
+			for _, k := range sortedEnvKeys(svc.Env) {
+				assignments = append(assignments, k+"="+shellQuote(svc.Env[k]))
+			}
+			command = "env " + strings.Join(assignments, " ") + " " + command
+		}
+		if svc.Dir != "" {
+			command = "cd " + shellQuote(svc.Dir) + " && " + command
+		}
+		b.WriteString(fmt.Sprintf("%s: %s\n", svc.Name, command))
+	}
+	return []byte(b.String())
+}
+
+// renderMprocs renders an mprocs.yaml with one proc per process service.
+func renderMprocs(cfg PlateConfig) []byte {
+	var b strings.Builder
+	b.WriteString("# Generated by 'plate export mprocs'. Re-run it after changing the Plate config.\n")
+	b.WriteString("procs:\n")
+	for _, svc := range cfg.processServices() {
+		b.WriteString(fmt.Sprintf("  %s:\n", yamlQuote(svc.Name)))
+		b.WriteString(fmt.Sprintf("    shell: %s\n", yamlQuote(svc.Command)))
+		if svc.Dir != "" {
+			b.WriteString(fmt.Sprintf("    cwd: %s\n", yamlQuote(svc.Dir)))
+		}
+		if len(svc.Env) > 0 {
+			b.WriteString("    env:\n")
+			for _, k := range sortedEnvKeys(svc.Env) {
+				b.WriteString(fmt.Sprintf("      %s: %s\n", k, yamlQuote(svc.Env[k])))
+			}
+		}
+	}
+	return []byte(b.String())
+}
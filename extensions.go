@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// --- POSTGRES EXTENSIONS ---
+
+// postgisVersion is the PostGIS release of the postgis/postgis image used
+// when the service's version is a plain Postgres version.
+const postgisVersion = "3.5"
+
+var (
+	extensionNamePattern = regexp.MustCompile(`^[a-z0-9_-]+$`)
+	postgresMajorPattern = regexp.MustCompile(`^(?:pg)?([0-9]+)`)
+	// postgisTagPattern matches postgis/postgis tags such as "16-3.4".
+	postgisTagPattern = regexp.MustCompile(`^[0-9]+-[0-9]`)
+)
+
+// hasExtension reports whether the service enables the extension, or one of
+// its companions such as postgis_topology for postgis.
+func hasExtension(config ServiceConfig, name string) bool {
+	for _, ext := range config.Extensions {
+		if ext == name || strings.HasPrefix(ext, name+"_") {
+			return true
+		}
+	}
+	return false
+}
+
+// postgresImage returns the image of a postgres service. PostGIS and
+// pgvector aren't in the official image, so their extensions swap it for
+// the image that ships them.
+func postgresImage(config ServiceConfig) (string, error) {
+	postgis, vector := hasExtension(config, "postgis"), hasExtension(config, "vector")
+	if !postgis && !vector {
+		return "postgres:" + config.Version, nil
+	}
+	if postgis && vector {
+		return "", fmt.Errorf("service %s: postgis and vector come in different images; use a build to combine them", config.Name)
+	}
+	major := postgresMajorPattern.FindStringSubmatch(config.Version)
+	if postgis {
+		if postgisTagPattern.MatchString(config.Version) {
+			return "postgis/postgis:" + config.Version, nil
+		}
+		if major == nil {
+			return "", fmt.Errorf("service %s: postgis needs a Postgres version like 16, not '%s'", config.Name, config.Version)
+		}
+		return "postgis/postgis:" + major[1] + "-" + postgisVersion, nil
+	}
+	if major == nil {
+		return "", fmt.Errorf("service %s: vector needs a Postgres version like 16, not '%s'", config.Name, config.Version)
+	}
+	return "pgvector/pgvector:pg" + major[1], nil
+}
+
+// validateExtensions checks the extensions of every service.
+func validateExtensions(cfg PlateConfig) error {
+	for _, svc := range cfg.Services {
+		if len(svc.Extensions) == 0 {
+			continue
+		}
+		if svc.Type != "postgres" {
+			return fmt.Errorf("service %s: extensions are supported for postgres, not %s", svc.Name, svc.Type)
+		}
+		for _, ext := range svc.Extensions {
+			if !extensionNamePattern.MatchString(ext) {
+				return fmt.Errorf("service %s: invalid extension name '%s'", svc.Name, ext)
+			}
+		}
+		if svc.Build == nil {
+			if _, err := postgresImage(svc); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// extensionsSQL creates the extensions unless they exist.
+func extensionsSQL(extensions []string) string {
+	var b strings.Builder
+	for _, ext := range extensions {
+		b.WriteString(fmt.Sprintf("CREATE EXTENSION IF NOT EXISTS %q;\n", ext))
+	}
+	return b.String()
+}
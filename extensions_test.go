@@ -0,0 +1,60 @@
+package main
+
+import "testing"
+
+func TestPostgresImage(t *testing.T) {
+	tests := []struct {
+		version    string
+		extensions []string
+		expected   string
+		wantErr    bool
+	}{
+		{"16", nil, "postgres:16", false},
+		{"16", []string{"pgcrypto", "uuid-ossp"}, "postgres:16", false},
+		{"16", []string{"postgis"}, "postgis/postgis:16-" + postgisVersion, false},
+		{"16.3-alpine", []string{"postgis", "postgis_topology"}, "postgis/postgis:16-" + postgisVersion, false},
+		{"15-3.4", []string{"postgis"}, "postgis/postgis:15-3.4", false},
+		{"17", []string{"vector"}, "pgvector/pgvector:pg17", false},
+		{"pg16", []string{"vector"}, "pgvector/pgvector:pg16", false},
+		{"latest", []string{"vector"}, "", true},
+		{"16", []string{"postgis", "vector"}, "", true},
+	}
+	for _, tt := range tests {
+		got, err := postgresImage(ServiceConfig{Type: "postgres", Name: "db", Version: tt.version, Extensions: tt.extensions})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s %v: Expected error %v, got %v", tt.version, tt.extensions, tt.wantErr, err)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("%s %v: Expected %q, got %q", tt.version, tt.extensions, tt.expected, got)
+		}
+	}
+}
+
+func TestValidateExtensions(t *testing.T) {
+	tests := []struct {
+		name    string
+		svc     ServiceConfig
+		wantErr bool
+	}{
+		{"postgres", ServiceConfig{Type: "postgres", Name: "db", Version: "16", Extensions: []string{"pgcrypto"}}, false},
+		{"not postgres", ServiceConfig{Type: "redis", Name: "cache", Version: "7", Extensions: []string{"pgcrypto"}}, true},
+		{"invalid name", ServiceConfig{Type: "postgres", Name: "db", Version: "16", Extensions: []string{`x"; DROP`}}, true},
+		{"conflicting images", ServiceConfig{Type: "postgres", Name: "db", Version: "16", Extensions: []string{"postgis", "vector"}}, true},
+		{"built image", ServiceConfig{Type: "postgres", Name: "db", Version: "16", Extensions: []string{"postgis", "vector"}, Build: &BuildConfig{Context: "."}}, false},
+	}
+	for _, tt := range tests {
+		err := validateExtensions(PlateConfig{Services: []ServiceConfig{tt.svc}})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: Expected error %v, got %v", tt.name, tt.wantErr, err)
+		}
+	}
+}
+
+func TestExtensionsSQL(t *testing.T) {
+	got := extensionsSQL([]string{"pgcrypto", "uuid-ossp"})
+	expected := "CREATE EXTENSION IF NOT EXISTS \"pgcrypto\";\nCREATE EXTENSION IF NOT EXISTS \"uuid-ossp\";\n"
+	if got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
@@ -0,0 +1,335 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// --- FAKE CONTAINER RUNTIME ---
+
+// fakeRuntime is a containerRuntime that keeps containers in memory, for
+// tests and `plate --demo`. Failures are scripted with fail, keyed by
+// operation and container name or image, e.g. "run plate-redis-cache" or
+// "pull redis:7".
+type fakeRuntime struct {
+	mu         sync.Mutex
+	containers []*containerInfo
+	images     map[string]bool
+	fail       map[string]error
+	delays     map[string]time.Duration // how long an operation takes, e.g. "pull"
+	output     map[string][]string      // what a container, by name, logs when it starts
+	followers  map[string][]func()      // container ID -> ends its log streams
+	calls      []string                 // the operations, e.g. "stop plate-redis-cache"
+	created    int
+}
+
+func newFakeRuntime() *fakeRuntime {
+	return &fakeRuntime{
+		images:    map[string]bool{},
+		fail:      map[string]error{},
+		delays:    map[string]time.Duration{},
+		output:    map[string][]string{},
+		followers: map[string][]func(){},
+	}
+}
+
+// do records an operation and returns its scripted failure. The caller
+// holds f.mu.
+func (f *fakeRuntime) do(op, target string) error {
+	f.calls = append(f.calls, op+" "+target)
+	return f.fail[op+" "+target]
+}
+
+// wait takes as long as the operation is scripted to.
+func (f *fakeRuntime) wait(op string) {
+	time.Sleep(f.delays[op])
+}
+
+func (f *fakeRuntime) find(ref string) *containerInfo {
+	for _, c := range f.containers {
+		if c.ID == ref || c.Name == ref {
+			return c
+		}
+	}
+	return nil
+}
+
+// endFollowers ends the log streams of a container that stopped. The
+// caller holds f.mu.
+func (f *fakeRuntime) endFollowers(id string) {
+	for _, end := range f.followers[id] {
+		end()
+	}
+	delete(f.followers, id)
+}
+
+func (f *fakeRuntime) containerIDs(all bool, filters ...string) ([]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var ids []string
+	for _, c := range f.containers {
+		if matchesFilters(*c, all, filters) {
+			ids = append(ids, c.ID)
+		}
+	}
+	return ids, nil
+}
+
+// matchesFilters supports the docker ps filters Plate uses.
+func matchesFilters(c containerInfo, all bool, filters []string) bool {
+	if !all && c.State != "running" {
+		return false
+	}
+	for _, filter := range filters {
+		key, value, _ := strings.Cut(filter, "=")
+		switch key {
+		case "name":
+			if !strings.HasPrefix(c.Name, strings.TrimPrefix(value, "^")) {
+				return false
+			}
+		case "label":
+			label, want, _ := strings.Cut(value, "=")
+			if c.Labels[label] != want {
+				return false
+			}
+		case "publish":
+			port, _ := strconv.Atoi(value)
+			published := false
+			for _, host := range c.Ports {
+				published = published || host == port
+			}
+			if !published {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (f *fakeRuntime) inspect(refs ...string) ([]containerInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var infos []containerInfo
+	for _, ref := range refs {
+		if c := f.find(ref); c != nil {
+			infos = append(infos, *c)
+		}
+	}
+	return infos, nil
+}
+
+func (f *fakeRuntime) imagePresent(image string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.images[image]
+}
+
+func (f *fakeRuntime) pull(image string) error {
+	f.wait("pull")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.do("pull", image); err != nil {
+		return err
+	}
+	f.images[image] = true
+	return nil
+}
+
+func (f *fakeRuntime) run(name string, args []string) (string, error) {
+	f.wait("run")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.do("run", name); err != nil {
+		return "", err
+	}
+	if f.find(name) != nil {
+		return "", fmt.Errorf("Conflict. The container name \"/%s\" is already in use", name)
+	}
+	f.created++
+	c := &containerInfo{ID: fmt.Sprintf("%064d", f.created), Name: name, State: "running", Labels: map[string]string{}}
+	for i, arg := range args {
+		if arg == "--label" && i+1 < len(args) {
+			label, value, _ := strings.Cut(args[i+1], "=")
+			c.Labels[label] = value
+		}
+	}
+	f.containers = append(f.containers, c)
+	return c.ID, nil
+}
+
+// setState runs an operation on a container and moves it to state.
+func (f *fakeRuntime) setState(op, id, state string) error {
+	f.wait(op)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	c := f.find(id)
+	if c == nil {
+		return fmt.Errorf("No such container: %s", id)
+	}
+	if err := f.do(op, c.Name); err != nil {
+		return err
+	}
+	c.State = state
+	if state != "running" {
+		f.endFollowers(c.ID)
+	}
+	return nil
+}
+
+func (f *fakeRuntime) start(id string) error { return f.setState("start", id, "running") }
+
+func (f *fakeRuntime) stop(id string) error { return f.setState("stop", id, "exited") }
+
+func (f *fakeRuntime) remove(id string, force bool) error {
+	f.wait("rm")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	c := f.find(id)
+	if c == nil {
+		return fmt.Errorf("No such container: %s", id)
+	}
+	if err := f.do("rm", c.Name); err != nil {
+		return err
+	}
+	if c.State == "running" && !force {
+		return fmt.Errorf("You cannot remove a running container %s. Stop the container before attempting removal or force remove", c.ID)
+	}
+	f.endFollowers(c.ID)
+	f.containers = slices.DeleteFunc(f.containers, func(other *containerInfo) bool { return other == c })
+	return nil
+}
+
+func (f *fakeRuntime) rename(from, to string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	c := f.find(from)
+	if c == nil {
+		return fmt.Errorf("No such container: %s", from)
+	}
+	if err := f.do("rename", c.Name); err != nil {
+		return err
+	}
+	if f.find(to) != nil {
+		return fmt.Errorf("Conflict. The container name \"/%s\" is already in use", to)
+	}
+	c.Name = to
+	return nil
+}
+
+// running looks up a container that has to be running. The caller holds
+// f.mu.
+func (f *fakeRuntime) running(id string) (*containerInfo, error) {
+	c := f.find(id)
+	if c == nil {
+		return nil, fmt.Errorf("No such container: %s", id)
+	}
+	if c.State != "running" {
+		return nil, fmt.Errorf("container %s is not running", c.ID)
+	}
+	return c, nil
+}
+
+// exec succeeds without output, so health checks pass at once.
+func (f *fakeRuntime) exec(id string, stdin io.Reader, command ...string) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	c, err := f.running(id)
+	if err != nil {
+		return nil, err
+	}
+	return nil, f.do("exec", c.Name)
+}
+
+// stats reports the same modest usage for every running container.
+func (f *fakeRuntime) stats(ids ...string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var b strings.Builder
+	for _, id := range ids {
+		if _, err := f.running(id); err == nil {
+			fmt.Fprintf(&b, "%s\t0.50%%\t48MiB / 7.6GiB\n", id)
+		}
+	}
+	return b.String()
+}
+
+// top shows the entrypoint the official images start with.
+func (f *fakeRuntime) top(id string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.running(id); err != nil {
+		return "", err
+	}
+	return "PID PPID %CPU %MEM ELAPSED COMMAND\n1 0 0.5 0.6 00:42 docker-entrypoint.sh\n", nil
+}
+
+func (f *fakeRuntime) inspectJSON(id string) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	c := f.find(id)
+	if c == nil {
+		return nil, fmt.Errorf("No such container: %s", id)
+	}
+	return json.Marshal([]any{map[string]any{
+		"Id":     c.ID,
+		"Name":   "/" + c.Name,
+		"State":  map[string]any{"Status": c.State, "Running": c.State == "running", "ExitCode": c.ExitCode},
+		"Config": map[string]any{"Image": c.Image, "Labels": c.Labels, "Env": c.Env},
+	}})
+}
+
+// version fails, as there is no daemon to ask.
+func (f *fakeRuntime) version() (string, string, error) {
+	return "", "", fmt.Errorf("no docker daemon")
+}
+
+// platform is unknown, so no image looks emulated.
+func (f *fakeRuntime) platform() string { return "" }
+
+func (f *fakeRuntime) imagePlatform(image string) string { return "" }
+
+func (f *fakeRuntime) logs(id string, lines int) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	c := f.find(id)
+	if c == nil {
+		return ""
+	}
+	output := f.output[c.Name]
+	if len(output) > lines {
+		output = output[len(output)-lines:]
+	}
+	return strings.Join(output, "\n")
+}
+
+// follow writes the container's scripted output, a line per "log" delay,
+// and then waits for the container to stop.
+func (f *fakeRuntime) follow(id string, stdout, stderr io.Writer) (func(), <-chan struct{}, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	c := f.find(id)
+	if c == nil {
+		return nil, nil, fmt.Errorf("No such container: %s", id)
+	}
+	done := make(chan struct{})
+	var once sync.Once
+	end := func() { once.Do(func() { close(done) }) }
+	f.followers[c.ID] = append(f.followers[c.ID], end)
+	go func(lines []string) {
+		for _, line := range lines {
+			select {
+			case <-done:
+				return
+			case <-time.After(f.delays["log"]):
+				fmt.Fprintln(stdout, line)
+			}
+		}
+	}(f.output[c.Name])
+	return end, done, nil
+}
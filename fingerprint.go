@@ -0,0 +1,66 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"runtime"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// --- ENVIRONMENT FINGERPRINT ---
+
+// fingerprint identifies what an environment runs, so two machines can be
+// compared at a glance.
+type fingerprint struct {
+	Plate         string `json:"plate"`
+	ConfigHash    string `json:"configHash"`
+	DockerVersion string `json:"dockerVersion"`
+	Runtime       string `json:"runtime"`
+	Platform      string `json:"platform"`
+}
+
+// configHash returns a short hash of the effective config. Field order is
+// fixed by the structs, so equal configs hash equally on every machine.
+func configHash(cfg PlateConfig) string {
+	data, _ := json.Marshal(cfg)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// collectFingerprint asks docker for its version and runtime. Either is
+// "unavailable" when the daemon can't be reached.
+func collectFingerprint(cfg PlateConfig) fingerprint {
+	fp := fingerprint{
+		Plate:         plateVersion(),
+		ConfigHash:    configHash(cfg),
+		DockerVersion: "unavailable",
+		Runtime:       "unavailable",
+		Platform:      runtime.GOOS + "/" + runtime.GOARCH,
+	}
+	if version, system, err := backend.version(); err == nil {
+		fp.DockerVersion, fp.Runtime = version, system
+	}
+	return fp
+}
+
+// String renders the fingerprint as a single line.
+func (fp fingerprint) String() string {
+	docker := fmt.Sprintf("docker %s (%s)", fp.DockerVersion, fp.Runtime)
+	if fp.Runtime == "unavailable" {
+		docker = "docker unavailable"
+	}
+	return fmt.Sprintf("plate %s • config %s • %s • %s", fp.Plate, fp.ConfigHash, docker, fp.Platform)
+}
+
+type fingerprintMsg struct {
+	fingerprint fingerprint
+}
+
+func fingerprintCmd(cfg PlateConfig) tea.Cmd {
+	return func() tea.Msg {
+		return fingerprintMsg{fingerprint: collectFingerprint(cfg)}
+	}
+}
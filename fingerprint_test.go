@@ -0,0 +1,19 @@
+package main
+
+import "testing"
+
+func TestConfigHash(t *testing.T) {
+	a := PlateConfig{Project: "shop", Services: []ServiceConfig{{Type: "postgres", Name: "main-db", Version: "16", Port: 5433}}}
+	b := PlateConfig{Project: "shop", Services: []ServiceConfig{{Type: "postgres", Name: "main-db", Version: "16", Port: 5433}}}
+	c := PlateConfig{Project: "shop", Services: []ServiceConfig{{Type: "postgres", Name: "main-db", Version: "17", Port: 5433}}}
+
+	if configHash(a) != configHash(b) {
+		t.Errorf("Expected equal configs to hash equally, got %s and %s", configHash(a), configHash(b))
+	}
+	if configHash(a) == configHash(c) {
+		t.Errorf("Expected different versions to change the hash")
+	}
+	if len(configHash(a)) != 12 {
+		t.Errorf("Expected a 12 character hash, got %q", configHash(a))
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// --- FOCUS ---
+
+// While the terminal window is unfocused, Plate stops polling for things
+// only worth seeing: resource stats, container reconciliation, and the
+// refresh of an open log or process view. Logs are still written to disk,
+// and schema watches and tasks keep running.
+
+// pausedPolls records which polling loops stopped while unfocused.
+type pausedPolls struct {
+	stats     bool
+	reconcile bool
+	logView   bool
+	top       bool
+}
+
+// resumePolls restarts the polling loops that stopped while the terminal
+// was unfocused, starting each with an immediate refresh.
+func (m *model) resumePolls() tea.Cmd {
+	var cmds []tea.Cmd
+	if m.paused.stats {
+		cmds = append(cmds, func() tea.Msg { return statsTickMsg{} })
+	}
+	if m.paused.reconcile {
+		cmds = append(cmds, func() tea.Msg { return reconcileTickMsg{} })
+	}
+	if m.paused.logView && m.showingLogs {
+		cmds = append(cmds, func() tea.Msg { return logTickMsg{} })
+	}
+	if m.paused.top && m.showingTop {
+		cmds = append(cmds, containerTopCmd(m.top.session, m.top.containerID))
+	}
+	m.paused = pausedPolls{}
+	return tea.Batch(cmds...)
+}
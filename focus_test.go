@@ -0,0 +1,45 @@
+package main
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestPollingPausesWhileBlurred(t *testing.T) {
+	m := model{}
+	next, _ := m.update(tea.BlurMsg{})
+	m = next.(model)
+
+	for _, msg := range []tea.Msg{statsTickMsg{}, reconcileTickMsg{}} {
+		next, cmd := m.update(msg)
+		m = next.(model)
+		if cmd != nil {
+			t.Errorf("%T: Expected no command while blurred", msg)
+		}
+	}
+	if !m.paused.stats || !m.paused.reconcile {
+		t.Fatalf("Expected stats and reconciliation to be paused, got %+v", m.paused)
+	}
+
+	next, cmd := m.update(tea.FocusMsg{})
+	m = next.(model)
+	if cmd == nil {
+		t.Fatalf("Expected focus to resume polling")
+	}
+	if m.blurred || m.paused != (pausedPolls{}) {
+		t.Errorf("Expected nothing paused after focus, got blurred=%v %+v", m.blurred, m.paused)
+	}
+	resumed := map[string]bool{}
+	for _, c := range cmd().(tea.BatchMsg) {
+		switch c().(type) {
+		case statsTickMsg:
+			resumed["stats"] = true
+		case reconcileTickMsg:
+			resumed["reconcile"] = true
+		}
+	}
+	if !resumed["stats"] || !resumed["reconcile"] {
+		t.Errorf("Expected stats and reconciliation to restart, got %v", resumed)
+	}
+}
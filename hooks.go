@@ -0,0 +1,234 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// --- GIT HOOKS ---
+
+// hookMarker identifies hook scripts Plate wrote, so it only ever replaces
+// or removes its own.
+const hookMarker = "# Installed by 'plate hooks install'"
+
+// hookNames are the hooks that run after the checked-out code changed.
+var hookNames = []string{"post-checkout", "post-merge"}
+
+// hookScript returns the script for a hook. It does nothing where plate
+// isn't on the PATH, e.g. in a GUI git client's environment.
+func hookScript(hook, configPath string, apply bool) string {
+	flags := "--config " + shellQuote(configPath)
+	if apply {
+		flags += " --apply"
+	}
+	return fmt.Sprintf(`#!/bin/sh
+%s; remove with 'plate hooks uninstall'.
+command -v plate >/dev/null 2>&1 || exit 0
+exec plate hooks run %s %s "$@"
+`, hookMarker, flags, hook)
+}
+
+// gitOutput runs git in dir and returns its trimmed output.
+func gitOutput(dir string, args ...string) (string, error) {
+	output, err := exec.Command("git", append([]string{"-C", dir}, args...)...).Output()
+	return strings.TrimSpace(string(output)), err
+}
+
+// hooksDir returns where git looks for the repository's hooks, which
+// honors core.hooksPath.
+func hooksDir(dir string) (string, error) {
+	path, err := gitOutput(dir, "rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", fmt.Errorf("%s is not in a git repository", dir)
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	return path, nil
+}
+
+// installHooks writes Plate's hooks. A hook that exists and isn't Plate's
+// is left alone and reported, unless force replaces it.
+func installHooks(dir, configPath string, apply, force bool) ([]string, error) {
+	hooks, err := hooksDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(hooks, 0755); err != nil {
+		return nil, err
+	}
+	var installed []string
+	for _, hook := range hookNames {
+		path := filepath.Join(hooks, hook)
+		existing, err := os.ReadFile(path)
+		if err == nil && !bytes.Contains(existing, []byte(hookMarker)) && !force {
+			return installed, fmt.Errorf("%s already exists; add 'plate hooks run %s \"$@\"' to it, or pass --force to replace it", path, hook)
+		} else if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return installed, err
+		}
+		if err := os.WriteFile(path, []byte(hookScript(hook, configPath, apply)), 0755); err != nil {
+			return installed, err
+		}
+		installed = append(installed, path)
+	}
+	return installed, nil
+}
+
+// uninstallHooks removes the hooks Plate wrote.
+func uninstallHooks(dir string) ([]string, error) {
+	hooks, err := hooksDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var removed []string
+	for _, hook := range hookNames {
+		path := filepath.Join(hooks, hook)
+		data, err := os.ReadFile(path)
+		if err != nil || !bytes.Contains(data, []byte(hookMarker)) {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			return removed, err
+		}
+		removed = append(removed, path)
+	}
+	return removed, nil
+}
+
+// hookRange returns the commits a hook moved between, and false when there
+// is nothing to compare: a file checkout, a fresh clone, or no move at all.
+func hookRange(dir, hook string, args []string) (string, string, bool) {
+	switch hook {
+	case "post-checkout":
+		// Arguments are the previous HEAD, the new HEAD, and 1 for a
+		// branch checkout or 0 for a file checkout.
+		if len(args) < 3 || args[2] != "1" || strings.Trim(args[0], "0") == "" || args[0] == args[1] {
+			return "", "", false
+		}
+		return args[0], args[1], true
+	case "post-merge":
+		old, err := gitOutput(dir, "rev-parse", "ORIG_HEAD")
+		if err != nil {
+			return "", "", false
+		}
+		return old, "HEAD", true
+	}
+	return "", "", false
+}
+
+// configChanged reports whether the file at path differs between two commits.
+func configChanged(dir, from, to, path string) bool {
+	err := exec.Command("git", "-C", dir, "diff", "--quiet", from, to, "--", path).Run()
+	var exitErr *exec.ExitError
+	return errors.As(err, &exitErr) && exitErr.ExitCode() == 1
+}
+
+// runHook is what an installed hook runs: it warns when the config changed,
+// or applies it.
+func runHook(dir, hook, configPath string, args []string, apply bool, out io.Writer) {
+	from, to, ok := hookRange(dir, hook, args)
+	if !ok || !configChanged(dir, from, to, configPath) {
+		return
+	}
+	if apply {
+		fmt.Fprintf(out, "plate: %s changed, applying it...\n", configPath)
+		cmd := exec.Command(os.Args[0], "apply", "--config", configPath)
+		cmd.Dir, cmd.Stdout, cmd.Stderr = dir, out, out
+		if cmd.Run() != nil {
+			fmt.Fprintln(out, "plate: could not apply the config; run 'plate apply' once the issue is fixed.")
+		}
+		return
+	}
+	fmt.Fprintf(out, "%s plate: %s changed. Run 'plate diff' to see how the environment differs, and 'plate apply' to update it.\n", confirmStyle.Render("⚠️"), configPath)
+}
+
+// handleHooksCmd installs, removes, and runs Plate's git hooks.
+func handleHooksCmd(args []string) {
+	usage := "Usage: plate hooks [install [--apply] [--force] | uninstall]"
+	sub := ""
+	if len(args) > 0 {
+		sub, args = args[0], args[1:]
+	}
+	fs := flag.NewFlagSet("hooks "+sub, flag.ExitOnError)
+	addConfigFlag(fs)
+	apply := fs.Bool("apply", false, "apply the config when it changed, instead of warning")
+	force := fs.Bool("force", false, "replace existing hooks that Plate didn't write")
+	fs.Parse(args)
+
+	switch sub {
+	case "install":
+		configPath, err := repoConfigPath(configPathArg(fs))
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		installed, err := installHooks(".", configPath, *apply, *force)
+		for _, path := range installed {
+			fmt.Printf("%s %s\n", successStyle.Render("✓"), path)
+		}
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		if *apply {
+			fmt.Printf("Switching branches or pulling now applies changes to %s.\n", configPath)
+		} else {
+			fmt.Printf("Switching branches or pulling now warns when %s changed.\n", configPath)
+		}
+	case "uninstall":
+		removed, err := uninstallHooks(".")
+		for _, path := range removed {
+			fmt.Printf("%s removed %s\n", successStyle.Render("✓"), path)
+		}
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		if len(removed) == 0 {
+			fmt.Println("No Plate hooks are installed.")
+		}
+	case "run":
+		if fs.NArg() < 1 {
+			fmt.Println(usage)
+			os.Exit(exitUsage)
+		}
+		// Hooks run from the top of the worktree, which the path is relative to.
+		runHook(".", fs.Arg(0), configPathArg(fs), fs.Args()[1:], *apply, os.Stdout)
+	default:
+		fmt.Println(usage)
+		os.Exit(exitUsage)
+	}
+}
+
+// repoConfigPath returns the config's path relative to the top of the git
+// repository, which is where hooks run.
+func repoConfigPath(configPath string) (string, error) {
+	if !isFileSource(configPath) {
+		return "", fmt.Errorf("hooks watch a config file in the repository, not %s", configPath)
+	}
+	top, err := gitOutput(".", "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", fmt.Errorf("the current directory is not in a git repository")
+	}
+	abs, err := filepath.Abs(configPath)
+	if err != nil {
+		return "", err
+	}
+	// Resolve symlinks on both sides, e.g. macOS's /tmp, before comparing.
+	if resolved, err := filepath.EvalSymlinks(filepath.Dir(abs)); err == nil {
+		abs = filepath.Join(resolved, filepath.Base(abs))
+	}
+	rel, err := filepath.Rel(top, abs)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("%s is outside the git repository", configPath)
+	}
+	return filepath.ToSlash(rel), nil
+}
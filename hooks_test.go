@@ -0,0 +1,77 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInstallHooks(t *testing.T) {
+	dir, git := testRepo(t)
+	git("init", "-q")
+
+	installed, err := installHooks(dir, "plate.config.json", false, false)
+	if err != nil || len(installed) != 2 {
+		t.Fatalf("Expected two hooks, got %v, %v", installed, err)
+	}
+	script, _ := os.ReadFile(filepath.Join(dir, ".git", "hooks", "post-checkout"))
+	if !strings.Contains(string(script), "plate hooks run --config 'plate.config.json' post-checkout") {
+		t.Errorf("Expected the hook to run plate, got %s", script)
+	}
+	// Plate's own hooks are replaced without --force.
+	if _, err := installHooks(dir, "plate.config.json", true, false); err != nil {
+		t.Errorf("Expected to reinstall, got %v", err)
+	}
+
+	removed, err := uninstallHooks(dir)
+	if err != nil || len(removed) != 2 {
+		t.Fatalf("Expected two hooks removed, got %v, %v", removed, err)
+	}
+
+	foreign := filepath.Join(dir, ".git", "hooks", "post-merge")
+	os.WriteFile(foreign, []byte("#!/bin/sh\nmake deps\n"), 0755)
+	if _, err := installHooks(dir, "plate.config.json", false, false); err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Errorf("Expected a foreign hook to be kept, got %v", err)
+	}
+	if removed, _ := uninstallHooks(dir); len(removed) != 1 {
+		t.Errorf("Expected only Plate's hook to be removed, got %v", removed)
+	}
+	if data, _ := os.ReadFile(foreign); string(data) != "#!/bin/sh\nmake deps\n" {
+		t.Errorf("Expected the foreign hook to be untouched, got %s", data)
+	}
+}
+
+func TestRunHook(t *testing.T) {
+	dir, git := testRepo(t)
+	git("init", "-q", "-b", "main")
+	os.WriteFile(filepath.Join(dir, "plate.config.json"), []byte(`{"services": []}`), 0644)
+	git("add", ".")
+	git("commit", "-q", "-m", "config")
+	first := git("rev-parse", "HEAD")
+	os.WriteFile(filepath.Join(dir, "README"), []byte("docs"), 0644)
+	git("add", ".")
+	git("commit", "-q", "-m", "docs")
+	docs := git("rev-parse", "HEAD")
+	os.WriteFile(filepath.Join(dir, "plate.config.json"), []byte(`{"services": [{"name": "db"}]}`), 0644)
+	git("commit", "-q", "-am", "db")
+	changed := git("rev-parse", "HEAD")
+
+	tests := []struct {
+		name string
+		args []string
+		warn bool
+	}{
+		{"config changed", []string{first, changed, "1"}, true},
+		{"config unchanged", []string{first, docs, "1"}, false},
+		{"file checkout", []string{first, changed, "0"}, false},
+		{"clone", []string{strings.Repeat("0", 40), changed, "1"}, false},
+	}
+	for _, tt := range tests {
+		var out strings.Builder
+		runHook(dir, "post-checkout", "plate.config.json", tt.args, false, &out)
+		if got := strings.Contains(out.String(), "plate.config.json changed"); got != tt.warn {
+			t.Errorf("%s: Expected warning=%v, got %q", tt.name, tt.warn, out.String())
+		}
+	}
+}
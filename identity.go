@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// --- IDENTITY PROVIDERS ---
+
+// The "keycloak" and "dex" service types run an OpenID Connect provider.
+// Apps get its issuer URL, next to the connection string, as
+// PLATE_<NAME>_ISSUER_URL.
+const (
+	keycloakPort = 8080
+	// keycloakImportDir is where Keycloak imports realms from on start.
+	keycloakImportDir = "/opt/keycloak/data/import"
+	// keycloakDefaultRealm is the issuer's realm when no realm is imported.
+	keycloakDefaultRealm = "master"
+	// keycloakAdmin is the admin console's user and password.
+	keycloakAdmin = "admin"
+
+	dexPort = 5556
+	// dexConfigPath is where a dex service's config is mounted.
+	dexConfigPath = "/etc/dex/plate.yaml"
+)
+
+// isIdentityProvider reports whether the service is an OpenID Connect
+// provider with an issuer URL.
+func isIdentityProvider(config ServiceConfig) bool {
+	return config.Type == "keycloak" || config.Type == "dex"
+}
+
+// keycloakSpec describes the container of a "keycloak" service, in
+// development mode with the admin user admin/admin. The hostname is fixed
+// to the published address, so tokens carry the same issuer whether they
+// were requested by the browser or by a container.
+func keycloakSpec(config ServiceConfig) serviceSpec {
+	hostname := "http://" + serviceHost(config) + ":" + strconv.Itoa(config.Port)
+	return serviceSpec{
+		Image:         "quay.io/keycloak/keycloak",
+		ContainerPort: keycloakPort,
+		Env: []string{
+			// Keycloak 26 reads the first two, older versions the others.
+			"KC_BOOTSTRAP_ADMIN_USERNAME=" + keycloakAdmin,
+			"KC_BOOTSTRAP_ADMIN_PASSWORD=" + keycloakAdmin,
+			"KEYCLOAK_ADMIN=" + keycloakAdmin,
+			"KEYCLOAK_ADMIN_PASSWORD=" + keycloakAdmin,
+			"KC_HOSTNAME=" + hostname,
+		},
+		Args: []string{"start-dev", "--import-realm"},
+	}
+}
+
+// dexSpec describes the container of a "dex" service, which serves the
+// config from the repository.
+func dexSpec(config ServiceConfig) serviceSpec {
+	return serviceSpec{
+		Image:         "ghcr.io/dexidp/dex",
+		ContainerPort: dexPort,
+		Args:          []string{"dex", "serve", dexConfigPath},
+	}
+}
+
+// identityMount returns the -v value mounting the realm or dex config, or
+// "" when there is none.
+func identityMount(config ServiceConfig) (string, error) {
+	path, target := config.Realm, keycloakImportDir+"/"+filepath.Base(config.Realm)
+	if config.Type == "dex" {
+		path, target = config.DexConfig, dexConfigPath
+	}
+	if path == "" {
+		return "", nil
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	return abs + ":" + target + ":ro", nil
+}
+
+// realmName reads the name of the realm a Keycloak realm export defines.
+func realmName(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	var realm struct {
+		Realm string `json:"realm"`
+	}
+	if err := json.Unmarshal(data, &realm); err != nil {
+		return "", fmt.Errorf("%s is not a realm export: %v", path, err)
+	}
+	if realm.Realm == "" {
+		return "", fmt.Errorf("%s is not a realm export: it has no \"realm\" name", path)
+	}
+	return realm.Realm, nil
+}
+
+// dexIssuer reads the top-level issuer of a dex config.
+func dexIssuer(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		if value, ok := strings.CutPrefix(scanner.Text(), "issuer:"); ok {
+			value, _, _ = strings.Cut(value, " #")
+			return strings.Trim(strings.TrimSpace(value), `"'`), nil
+		}
+	}
+	return "", fmt.Errorf("%s has no issuer", path)
+}
+
+// resolveIdentityProviders checks the identity providers' files and fills
+// in what their issuer URLs are made of.
+func resolveIdentityProviders(cfg *PlateConfig) error {
+	for i := range cfg.Services {
+		svc := &cfg.Services[i]
+		switch {
+		case svc.Realm != "" && svc.Type != "keycloak":
+			return fmt.Errorf("service %s: realm is for keycloak services, not %s", svc.Name, svc.Type)
+		case svc.DexConfig != "" && svc.Type != "dex":
+			return fmt.Errorf("service %s: dexConfig is for dex services, not %s", svc.Name, svc.Type)
+		case svc.Type == "keycloak":
+			svc.realm = keycloakDefaultRealm
+			if svc.Realm == "" {
+				continue
+			}
+			name, err := realmName(svc.Realm)
+			if err != nil {
+				return fmt.Errorf("service %s: %w", svc.Name, err)
+			}
+			svc.realm = name
+		case svc.Type == "dex":
+			if svc.DexConfig == "" {
+				return fmt.Errorf("service %s: a dex service needs its dexConfig file", svc.Name)
+			}
+			issuer, err := dexIssuer(svc.DexConfig)
+			if err != nil {
+				return fmt.Errorf("service %s: %w", svc.Name, err)
+			}
+			u, err := url.Parse(issuer)
+			if err != nil || u.Port() != strconv.Itoa(svc.Port) {
+				return fmt.Errorf("service %s: the issuer %s in %s must be on the service's port, e.g. http://localhost:%d/dex", svc.Name, issuer, svc.DexConfig, svc.Port)
+			}
+			svc.issuer = issuer
+		}
+	}
+	return nil
+}
+
+// issuerURL returns the OpenID Connect issuer of an identity provider
+// reached at connStr.
+func issuerURL(config ServiceConfig, connStr string) string {
+	if config.Type == "dex" {
+		return config.issuer
+	}
+	realm := config.realm
+	if realm == "" {
+		realm = keycloakDefaultRealm
+	}
+	return connStr + "/realms/" + realm
+}
+
+// issuerEnvVarName returns the variable the issuer URL is exported as,
+// e.g. PLATE_AUTH_ISSUER_URL for "auth".
+func issuerEnvVarName(config ServiceConfig) string {
+	return envVarNameFor(config, envKey(config.Name)+"_ISSUER")
+}
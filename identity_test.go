@@ -0,0 +1,56 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestKeycloakService(t *testing.T) {
+	t.Chdir(t.TempDir())
+	os.WriteFile("realm.json", []byte(`{"realm": "shop", "enabled": true}`), 0644)
+	cfg := PlateConfig{Services: []ServiceConfig{{Type: "keycloak", Name: "auth", Port: 8180, Realm: "realm.json"}}}
+	if err := resolveIdentityProviders(&cfg); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	svc := cfg.Services[0]
+
+	connStr, args, err := getDockerRunArgs(svc, "plate-keycloak-auth")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	abs, _ := filepath.Abs("realm.json")
+	if i := slices.Index(args, "-v"); i < 0 || args[i+1] != abs+":"+keycloakImportDir+"/realm.json:ro" {
+		t.Errorf("Expected the realm mounted into the import dir, got %v", args)
+	}
+	if !slices.Contains(args, "8180:8080") || !slices.Contains(args, "--import-realm") {
+		t.Errorf("Expected Keycloak on port 8180 importing realms, got %v", args)
+	}
+	env := connectionEnv(svc, connStr)
+	if !slices.Contains(env, "PLATE_AUTH_ISSUER_URL=http://localhost:8180/realms/shop") {
+		t.Errorf("Expected the realm's issuer in the env, got %v", env)
+	}
+}
+
+func TestDexService(t *testing.T) {
+	t.Chdir(t.TempDir())
+	os.WriteFile("dex.yaml", []byte("issuer: http://localhost:5556/dex # public\nstorage:\n  type: memory\n"), 0644)
+	cfg := PlateConfig{Services: []ServiceConfig{{Type: "dex", Name: "auth", Port: 5556, DexConfig: "dex.yaml"}}}
+	if err := resolveIdentityProviders(&cfg); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := issuerURL(cfg.Services[0], "http://localhost:5556"); got != "http://localhost:5556/dex" {
+		t.Errorf("Expected the config's issuer, got %s", got)
+	}
+
+	cfg.Services[0].Port = 5557
+	if err := resolveIdentityProviders(&cfg); err == nil || !strings.Contains(err.Error(), "service's port") {
+		t.Errorf("Expected an error for an issuer on another port, got %v", err)
+	}
+	cfg = PlateConfig{Services: []ServiceConfig{{Type: "postgres", Name: "db", Realm: "realm.json"}}}
+	if err := resolveIdentityProviders(&cfg); err == nil {
+		t.Errorf("Expected an error for a realm on a postgres service")
+	}
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// --- IMAGE BUNDLES ---
+
+// missingImages returns the images that aren't present locally.
+func missingImages(images []string, present func(image string) bool) []string {
+	var missing []string
+	for _, image := range images {
+		if !present(image) {
+			missing = append(missing, image)
+		}
+	}
+	return missing
+}
+
+// parseLoadedImages reads the image names from docker load output, which
+// prints "Loaded image: postgres:16" per tagged image.
+func parseLoadedImages(output string) []string {
+	var images []string
+	for _, line := range strings.Split(output, "\n") {
+		if image, ok := strings.CutPrefix(strings.TrimSpace(line), "Loaded image: "); ok {
+			images = append(images, image)
+		}
+	}
+	return images
+}
+
+// handleExportImagesCmd writes every image the config uses into one
+// archive with docker save, pulling the ones that aren't present first.
+func handleExportImagesCmd(args []string) {
+	fs := flag.NewFlagSet("export images", flag.ExitOnError)
+	addConfigFlag(fs)
+	force := fs.Bool("force", false, "overwrite an existing archive")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		fmt.Println("Usage: plate export images [--force] [--config path] <bundle.tar>")
+		os.Exit(exitUsage)
+	}
+	configPath := "plate.config.json"
+	if v := fs.Lookup("config").Value.String(); v != "" {
+		configPath = v
+	}
+	plateConfig := mustResolveBuilds(mustLoadConfig(configPath))
+	bundle := fs.Arg(0)
+	if _, err := os.Stat(bundle); err == nil && !*force {
+		fmt.Printf("Error: '%s' already exists, pass --force to overwrite it.\n", bundle)
+		os.Exit(exitUsage)
+	} else if err != nil && !errors.Is(err, os.ErrNotExist) {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+	if len(plateConfig.containerServices()) == 0 {
+		fmt.Println("Error: The config has no container services.")
+		os.Exit(exitConfig)
+	}
+	mustHaveDockerAccess(plateConfig)
+	if err := exportImages(plateConfig, bundle); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitDocker)
+	}
+}
+
+// exportImages pulls and builds what the bundle needs and saves it. Its
+// errors all come from docker.
+func exportImages(cfg PlateConfig, bundle string) error {
+	images := configImages(cfg)
+	if missing := missingImages(images, imagePresent); len(missing) > 0 {
+		if prefetchImages(missing, newDockerSemaphores(cfg.Concurrency).pulls, os.Stdout, isTerminal(os.Stdout)) > 0 {
+			return fmt.Errorf("could not pull every image, nothing was written")
+		}
+	}
+	// Built images go into the bundle too, so the other machine needn't
+	// build them.
+	for _, svc := range cfg.containerServices() {
+		if svc.Build == nil {
+			continue
+		}
+		if !hasImage(svc) {
+			fmt.Printf("Building %s...\n", svc.Name)
+			if err := buildImage(svc, os.Stdout); err != nil {
+				return fmt.Errorf("%s: %w", svc.Name, err)
+			}
+		}
+		images = append(images, imageName(svc))
+	}
+
+	fmt.Printf("Saving %d image(s) to '%s'...\n", len(images), bundle)
+	output, err := dockerCommand(append([]string{"save", "-o", bundle}, images...)...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("docker save failed: %s", strings.TrimSpace(string(output)))
+	}
+	for _, image := range images {
+		fmt.Printf("%s %s\n", successStyle.Render("✓"), image)
+	}
+	if info, err := os.Stat(bundle); err == nil {
+		fmt.Printf("✅ Wrote '%s' (%s). Load it with 'plate load images %s'.\n", bundle, formatByteSize(float64(info.Size())), bundle)
+	}
+	return nil
+}
+
+// handleLoadCmd dispatches `plate load <what>`.
+func handleLoadCmd(args []string) {
+	if len(args) == 0 || args[0] != "images" {
+		fmt.Println("Usage: plate load images [--config path] <bundle.tar>")
+		os.Exit(exitUsage)
+	}
+	handleLoadImagesCmd(args[1:])
+}
+
+// handleLoadImagesCmd loads an archive written by `plate export images` and
+// reports the config's images that are still missing afterwards.
+func handleLoadImagesCmd(args []string) {
+	fs := flag.NewFlagSet("load images", flag.ExitOnError)
+	addConfigFlag(fs)
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		fmt.Println("Usage: plate load images [--config path] <bundle.tar>")
+		os.Exit(exitUsage)
+	}
+	bundle := fs.Arg(0)
+	if _, err := os.Stat(bundle); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	// The bundle may be loaded before the project is checked out, so a
+	// missing config is fine.
+	configPath := "plate.config.json"
+	if v := fs.Lookup("config").Value.String(); v != "" {
+		configPath = v
+	}
+	plateConfig, cfgErr := loadConfig(configPath)
+	mustHaveDockerAccess(plateConfig)
+
+	fmt.Printf("Loading images from '%s'...\n", bundle)
+	output, err := dockerCommand("load", "-i", bundle).CombinedOutput()
+	if err != nil {
+		fmt.Printf("Error: docker load failed: %s\n", strings.TrimSpace(string(output)))
+		os.Exit(exitDocker)
+	}
+	for _, image := range parseLoadedImages(string(output)) {
+		fmt.Printf("%s %s\n", successStyle.Render("✓"), image)
+	}
+
+	if cfgErr != nil {
+		return
+	}
+	if missing := missingImages(configImages(plateConfig), imagePresent); len(missing) > 0 {
+		fmt.Println(errorStyle.Render("The config uses images the bundle didn't contain: " + strings.Join(missing, ", ")))
+		os.Exit(exitError)
+	}
+	fmt.Println(successStyle.Render("Every image the config uses is present."))
+}
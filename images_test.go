@@ -0,0 +1,22 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseLoadedImages(t *testing.T) {
+	output := "Loaded image: postgres:16\nLoaded image ID: sha256:0123abcd\nLoaded image: redis:7\n"
+	got := parseLoadedImages(output)
+	if strings.Join(got, ",") != "postgres:16,redis:7" {
+		t.Errorf("Expected [postgres:16 redis:7], got %v", got)
+	}
+}
+
+func TestMissingImages(t *testing.T) {
+	present := map[string]bool{"postgres:16": true}
+	got := missingImages([]string{"postgres:16", "redis:7"}, func(image string) bool { return present[image] })
+	if len(got) != 1 || got[0] != "redis:7" {
+		t.Errorf("Expected [redis:7], got %v", got)
+	}
+}
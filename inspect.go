@@ -0,0 +1,223 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// --- INSPECT VIEW ---
+
+var (
+	jsonKeyStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("39"))
+	jsonStringStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("84"))
+	jsonNumberStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	jsonLiteralStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("213"))
+)
+
+// inspectView is the state of the docker inspect view.
+type inspectView struct {
+	viewport    viewport.Model
+	path        textinput.Model
+	filtering   bool   // the path input has focus
+	service     string // service whose container is shown
+	containerID string
+	data        any // decoded inspect output, nil while loading
+	err         error
+}
+
+func newInspectView() inspectView {
+	path := textinput.New()
+	path.Prompt = "path: "
+	path.Placeholder = ".NetworkSettings.Ports"
+	return inspectView{viewport: viewport.New(0, 0), path: path}
+}
+
+type inspectLoadedMsg struct {
+	containerID string
+	data        any
+	err         error
+}
+
+// inspectContainerCmd runs docker inspect on a container.
+func inspectContainerCmd(containerID string) tea.Cmd {
+	return func() tea.Msg {
+		output, err := backend.inspectJSON(containerID)
+		if err != nil {
+			return inspectLoadedMsg{containerID: containerID, err: fmt.Errorf("docker inspect failed: %v", err)}
+		}
+		var results []any
+		if err := json.Unmarshal(output, &results); err != nil || len(results) == 0 {
+			return inspectLoadedMsg{containerID: containerID, err: fmt.Errorf("could not read docker inspect output")}
+		}
+		return inspectLoadedMsg{containerID: containerID, data: results[0]}
+	}
+}
+
+// lookupPath follows a dotted path such as ".NetworkSettings.Ports" or
+// ".Mounts.0.Source" into decoded JSON. Keys match case-insensitively when
+// there is no exact match.
+func lookupPath(data any, path string) (any, error) {
+	current := data
+	for _, key := range strings.Split(strings.Trim(path, "."), ".") {
+		if key == "" {
+			continue
+		}
+		switch v := current.(type) {
+		case map[string]any:
+			next, ok := v[key]
+			if !ok {
+				for k, val := range v {
+					if strings.EqualFold(k, key) {
+						next, ok = val, true
+						break
+					}
+				}
+			}
+			if !ok {
+				return nil, fmt.Errorf("no key %q", key)
+			}
+			current = next
+		case []any:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, fmt.Errorf("no index %q in a list of %d", key, len(v))
+			}
+			current = v[i]
+		default:
+			return nil, fmt.Errorf("%q is not an object or a list", key)
+		}
+	}
+	return current, nil
+}
+
+// highlightJSON pretty-prints v with keys, strings, numbers and literals in
+// their own colors.
+func highlightJSON(v any) string {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err.Error()
+	}
+	text := string(data)
+	var b strings.Builder
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == '"':
+			end := i + 1
+			for end < len(text) && text[end] != '"' {
+				if text[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			end++
+			style := jsonStringStyle
+			if end < len(text) && text[end] == ':' {
+				style = jsonKeyStyle
+			}
+			b.WriteString(style.Render(text[i:end]))
+			i = end
+		case c == '-' || (c >= '0' && c <= '9'):
+			end := i
+			for end < len(text) && strings.IndexByte("+-.eE0123456789", text[end]) >= 0 {
+				end++
+			}
+			b.WriteString(jsonNumberStyle.Render(text[i:end]))
+			i = end
+		case c == 't' || c == 'f' || c == 'n':
+			end := i
+			for end < len(text) && text[end] >= 'a' && text[end] <= 'z' {
+				end++
+			}
+			b.WriteString(jsonLiteralStyle.Render(text[i:end]))
+			i = end
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return b.String()
+}
+
+// refreshInspectView renders the inspect output below the current path.
+func (m *model) refreshInspectView() {
+	if m.inspect.data == nil {
+		return
+	}
+	v, err := lookupPath(m.inspect.data, m.inspect.path.Value())
+	if err != nil {
+		m.inspect.viewport.SetContent(errorStyle.Render(err.Error()))
+		return
+	}
+	m.inspect.viewport.SetContent(highlightJSON(v))
+	m.inspect.viewport.GotoTop()
+}
+
+// updateInspectView handles keys while the inspect view is open.
+func (m model) updateInspectView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.inspect.filtering {
+		switch msg.String() {
+		case "enter":
+			m.inspect.filtering = false
+			m.inspect.path.Blur()
+			return m, nil
+		case "esc":
+			m.inspect.filtering = false
+			m.inspect.path.Blur()
+			m.inspect.path.SetValue("")
+			m.refreshInspectView()
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.inspect.path, cmd = m.inspect.path.Update(msg)
+		m.refreshInspectView()
+		return m, cmd
+	}
+
+	switch msg.String() {
+	case "i", "q", "esc":
+		m.showingInspect = false
+		return m, nil
+	case "/", ".":
+		m.inspect.filtering = true
+		if msg.String() == "." && m.inspect.path.Value() == "" {
+			m.inspect.path.SetValue(".")
+			m.inspect.path.CursorEnd()
+		}
+		return m, m.inspect.path.Focus()
+	}
+	var cmd tea.Cmd
+	m.inspect.viewport, cmd = m.inspect.viewport.Update(msg)
+	return m, cmd
+}
+
+func (m model) renderInspectView() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Inspect " + m.inspect.service))
+	if p := m.inspect.path.Value(); p != "" && !m.inspect.filtering {
+		b.WriteString("  " + detailValStyle.Render("path: "+p))
+	}
+	b.WriteString("\n\n")
+	switch {
+	case m.inspect.err != nil:
+		b.WriteString(errorStyle.Render(m.inspect.err.Error()))
+	case m.inspect.data == nil:
+		b.WriteString(fmt.Sprintf("%s Inspecting container...", m.spinner.View()))
+	default:
+		b.WriteString(m.inspect.viewport.View())
+	}
+	b.WriteString("\n\n")
+	if m.inspect.filtering {
+		b.WriteString(m.inspect.path.View())
+	} else {
+		b.WriteString(helpStyle.Render("/: filter by path (e.g. .NetworkSettings.Ports) • ↑/↓/pgup/pgdn: scroll • i/q/esc: back"))
+	}
+	return docStyle.Render(b.String())
+}
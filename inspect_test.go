@@ -0,0 +1,54 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestLookupPath(t *testing.T) {
+	var data any
+	raw := `{"Name": "/plate-postgres-db", "NetworkSettings": {"Ports": {"5432/tcp": [{"HostIp": "0.0.0.0", "HostPort": "5433"}]}}, "Mounts": [{"Source": "/data"}]}`
+	if err := json.Unmarshal([]byte(raw), &data); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		path     string
+		expected string
+		wantErr  bool
+	}{
+		{"", raw, false},
+		{".Name", `"/plate-postgres-db"`, false},
+		{".NetworkSettings.Ports", `{"5432/tcp":[{"HostIp":"0.0.0.0","HostPort":"5433"}]}`, false},
+		{".networksettings.ports.5432/tcp.0.HostPort", `"5433"`, false},
+		{".Mounts.0.Source", `"/data"`, false},
+		{".Mounts.1", "", true},
+		{".Name.First", "", true},
+		{".Config", "", true},
+	}
+	for _, tt := range tests {
+		v, err := lookupPath(data, tt.path)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%q: Expected error %v, got %v", tt.path, tt.wantErr, err)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+		var want any
+		json.Unmarshal([]byte(tt.expected), &want)
+		got, _ := json.Marshal(v)
+		expected, _ := json.Marshal(want)
+		if string(got) != string(expected) {
+			t.Errorf("%q: Expected %s, got %s", tt.path, expected, got)
+		}
+	}
+}
+
+func TestHighlightJSON(t *testing.T) {
+	v := map[string]any{"Running": true, "Pid": 42, "Status": "running \"ok\"", "Error": nil}
+	expected, _ := json.MarshalIndent(v, "", "  ")
+	// Without a terminal, styles render as plain text.
+	if got := highlightJSON(v); got != string(expected) {
+		t.Errorf("Expected %s, got %s", expected, got)
+	}
+}
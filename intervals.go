@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// --- POLLING INTERVALS ---
+
+// IntervalsConfig sets how often Plate polls in the background, as
+// durations such as "10s". Unset values use the defaults.
+type IntervalsConfig struct {
+	// Reconcile is how often container states are re-read, to notice
+	// containers stopped or started outside Plate.
+	Reconcile string `json:"reconcile,omitempty"`
+	// Stats is how often CPU and memory are sampled.
+	Stats string `json:"stats,omitempty"`
+	// Health is how often a health command is retried while waiting for a
+	// service to accept connections.
+	Health string `json:"health,omitempty"`
+	// Watch is how often watched schema files are checked for changes.
+	Watch string `json:"watch,omitempty"`
+}
+
+// pollIntervals are the resolved background polling intervals.
+type pollIntervals struct {
+	reconcile time.Duration
+	stats     time.Duration
+	health    time.Duration
+	watch     time.Duration
+}
+
+var (
+	defaultIntervals = pollIntervals{reconcile: 10 * time.Second, stats: 5 * time.Second, health: time.Second, watch: time.Second}
+	// lowPowerIntervals are the shortest intervals --low-power allows.
+	lowPowerIntervals = pollIntervals{reconcile: time.Minute, stats: 30 * time.Second, health: 3 * time.Second, watch: 5 * time.Second}
+)
+
+// resolveIntervals applies the configured intervals over the defaults. With
+// lowPower set, no interval is shorter than lowPowerIntervals.
+func resolveIntervals(cfg *IntervalsConfig, lowPower bool) (pollIntervals, error) {
+	intervals := defaultIntervals
+	if cfg != nil {
+		for _, field := range []struct {
+			name  string
+			value string
+			dest  *time.Duration
+		}{
+			{"reconcile", cfg.Reconcile, &intervals.reconcile},
+			{"stats", cfg.Stats, &intervals.stats},
+			{"health", cfg.Health, &intervals.health},
+			{"watch", cfg.Watch, &intervals.watch},
+		} {
+			if field.value == "" {
+				continue
+			}
+			d, err := time.ParseDuration(field.value)
+			if err != nil || d <= 0 {
+				return intervals, fmt.Errorf("intervals: invalid %s interval '%s' (use a duration like 10s)", field.name, field.value)
+			}
+			*field.dest = d
+		}
+	}
+	if lowPower {
+		intervals.reconcile = max(intervals.reconcile, lowPowerIntervals.reconcile)
+		intervals.stats = max(intervals.stats, lowPowerIntervals.stats)
+		intervals.health = max(intervals.health, lowPowerIntervals.health)
+		intervals.watch = max(intervals.watch, lowPowerIntervals.watch)
+	}
+	return intervals, nil
+}
+
+type reconcileTickMsg struct{}
+
+// containersReconciledMsg maps the checked container IDs to their current
+// state, or "" for containers that no longer exist, and the stopped ones to
+// how they ended.
+type containersReconciledMsg struct {
+	states map[string]string
+	exits  map[string]containerExit
+	err    error
+	event  bool // a one-off for a docker event, outside the reconcile loop
+}
+
+// reconcileTickCmd schedules the next reconciliation.
+func reconcileTickCmd(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(time.Time) tea.Msg { return reconcileTickMsg{} })
+}
+
+// reconcileCmd reads the current state of the given containers.
+func reconcileCmd(ids []string) tea.Cmd {
+	return func() tea.Msg {
+		infos, err := inspectContainers(ids...)
+		if err != nil {
+			return containersReconciledMsg{err: err}
+		}
+		states := map[string]string{}
+		exits := map[string]containerExit{}
+		for _, id := range ids {
+			states[id] = ""
+		}
+		for _, info := range infos {
+			states[info.ID] = info.State
+			if info.State != "running" {
+				exits[info.ID] = exitOf(info)
+			}
+		}
+		return containersReconciledMsg{states: states, exits: exits}
+	}
+}
+
+// reconcileEventCmd reads the current state of the given containers once,
+// without scheduling the next reconciliation.
+func reconcileEventCmd(ids ...string) tea.Cmd {
+	reconcile := reconcileCmd(ids)
+	return func() tea.Msg {
+		msg := reconcile().(containersReconciledMsg)
+		msg.event = true
+		return msg
+	}
+}
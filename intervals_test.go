@@ -0,0 +1,35 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResolveIntervals(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      *IntervalsConfig
+		lowPower bool
+		expected pollIntervals
+		wantErr  bool
+	}{
+		{"defaults", nil, false, defaultIntervals, false},
+		{"configured", &IntervalsConfig{Reconcile: "30s", Health: "500ms"}, false,
+			pollIntervals{reconcile: 30 * time.Second, stats: 5 * time.Second, health: 500 * time.Millisecond, watch: time.Second}, false},
+		{"low power", nil, true, lowPowerIntervals, false},
+		{"low power keeps longer intervals", &IntervalsConfig{Stats: "2m"}, true,
+			pollIntervals{reconcile: time.Minute, stats: 2 * time.Minute, health: 3 * time.Second, watch: 5 * time.Second}, false},
+		{"invalid", &IntervalsConfig{Watch: "often"}, false, pollIntervals{}, true},
+		{"not positive", &IntervalsConfig{Stats: "0s"}, false, pollIntervals{}, true},
+	}
+	for _, tt := range tests {
+		got, err := resolveIntervals(tt.cfg, tt.lowPower)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: Expected error %v, got %v", tt.name, tt.wantErr, err)
+			continue
+		}
+		if !tt.wantErr && got != tt.expected {
+			t.Errorf("%s: Expected %+v, got %+v", tt.name, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// --- KEY BINDINGS ---
+
+// keyBinding documents an in-app key for the help bar and the help screen.
+type keyBinding struct {
+	label        string   // how the key is shown to the user
+	keys         []string // tea.KeyMsg strings that trigger it
+	short        string   // help bar text, empty to keep it off the bar
+	long         string   // help screen text
+	readOnlyLong string   // help screen text in read-only mode, if different
+	mutating     bool     // disabled and hidden in read-only mode
+}
+
+var keyBindings = []keyBinding{
+	{label: "↑/↓", short: "navigate", long: "Navigate the list of services."},
+	{label: "h", keys: []string{"h"}, short: "help", long: "Show/hide this help screen."},
+	{label: "x", keys: []string{"x"}, long: "Dismiss the announcement banner."},
+	{label: "q/ctrl+c", keys: []string{"q", "ctrl+c"}, short: "quit", long: "Quit the application (stops running containers and processes, after listing them for confirmation).", readOnlyLong: "Quit the application (containers keep running)."},
+	{label: "p", keys: []string{"p"}, long: "Pin a service, so quitting and 'plate down' leave it running (remembered in .plate/state.json).", mutating: true},
+	{label: "X", keys: []string{"X"}, long: "Quit, leaving containers running (processes stop); prints what is still running.", mutating: true},
+	{label: "s", keys: []string{"s"}, short: "stop", long: "Stop a running service.", mutating: true},
+	{label: "b", keys: []string{"b"}, short: "boot", long: "Boot/start a stopped service.", mutating: true},
+	{label: "r", keys: []string{"r"}, short: "reset", long: "Reset a service (stops, removes, and recreates it).", mutating: true},
+	{label: "d", keys: []string{"d"}, short: "delete", long: "Delete a service (stops and removes its container).", mutating: true},
+	{label: "c", keys: []string{"c"}, short: "copy", long: "Copy connection string for a running service."},
+	{label: "C", keys: []string{"C"}, long: "Copy the docker run command Plate uses for a service."},
+	{label: "Q", keys: []string{"Q"}, long: "Show a running service's connection string as a QR code, for a device on the same network."},
+	{label: "E", keys: []string{"E"}, long: "Copy every running service's connection string as .env lines."},
+	{label: "D", keys: []string{"D"}, short: "diff", long: "Show how the containers differ from the config."},
+	{label: "L", keys: []string{"L"}, short: "logs", long: "Show the combined, searchable logs of all services."},
+	{label: "i", keys: []string{"i"}, short: "inspect", long: "Show the docker inspect output of a service's container, filterable by path."},
+	{label: "H", keys: []string{"H"}, long: "Show the latest changes made to the services, by whom and with what result (from .plate/audit.log)."},
+	{label: "t", keys: []string{"t"}, short: "top", long: "Show the processes running in a service's container, refreshed every few seconds."},
+	{label: "enter", long: "Expand or collapse a stack (s/b on its header stop or boot every member)."},
+	{label: "enter", keys: []string{"enter"}, long: "Resolve an external container (adopt it, rename it out of the way, or abort).", mutating: true},
+}
+
+// isMutatingKey reports whether key triggers an action that changes containers.
+func isMutatingKey(key string) bool {
+	for _, b := range keyBindings {
+		for _, k := range b.keys {
+			if k == key {
+				return b.mutating
+			}
+		}
+	}
+	return false
+}
+
+// helpBarText renders the one-line key summary under the main view.
+func helpBarText(readOnly bool) string {
+	var parts []string
+	for _, b := range keyBindings {
+		if b.short == "" || (readOnly && b.mutating) {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s: %s", b.label, b.short))
+	}
+	if readOnly {
+		parts = append(parts, "read-only")
+	}
+	return strings.Join(parts, " • ")
+}
+
+// helpScreenText renders the key section of the full help screen.
+func helpScreenText(readOnly bool) string {
+	var b strings.Builder
+	for _, kb := range keyBindings {
+		if readOnly && kb.mutating {
+			continue
+		}
+		long := kb.long
+		if readOnly && kb.readOnlyLong != "" {
+			long = kb.readOnlyLong
+		}
+		b.WriteString(fmt.Sprintf("%s: %s\n", detailAttrStyle.Render(kb.label), long))
+	}
+	return b.String()
+}
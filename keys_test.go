@@ -0,0 +1,83 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// keyMsg returns the message a key press sends.
+func keyMsg(key string) tea.KeyMsg {
+	if key == "enter" {
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+}
+
+func TestReadOnlyIgnoresMutatingKeys(t *testing.T) {
+	t.Chdir(t.TempDir())
+	cfg := PlateConfig{Project: "shop", Services: []ServiceConfig{{Type: "redis", Name: "cache", Version: "7", Port: 6380}}}
+	running := item{config: cfg.Services[0], status: statusRunning, containerID: "abc"}
+	stopped := item{config: cfg.Services[0], status: statusStopped, containerID: "abc"}
+	conflicted := item{config: cfg.Services[0], status: statusExternal, external: &containerInfo{Name: "redis"}, conflict: conflictName}
+
+	tests := []struct {
+		key  string
+		item item
+	}{
+		{"s", running},
+		{"b", stopped},
+		{"r", running},
+		{"d", running},
+		{"p", running},
+		{"X", running},
+		{"enter", conflicted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if !isMutatingKey(tt.key) {
+				t.Fatalf("Expected %s to be a mutating key", tt.key)
+			}
+			for _, readOnly := range []bool{false, true} {
+				m := initialModel(cfg, readOnly)
+				m.items[0] = tt.item
+				m.list.SetItems(m.visibleItems())
+				next, cmd := m.Update(keyMsg(tt.key))
+				after := next.(model).items[0].(item)
+				changed := cmd != nil || after.confirming != tt.item.confirming || after.pinned != tt.item.pinned ||
+					after.status != tt.item.status || next.(model).quitting
+				if readOnly && changed {
+					t.Errorf("Expected %s to do nothing in read-only mode", tt.key)
+				}
+				if !readOnly && !changed {
+					t.Errorf("Expected %s to act outside read-only mode", tt.key)
+				}
+			}
+		})
+	}
+	for _, key := range []string{"c", "D", "L", "i", "t", "h"} {
+		if isMutatingKey(key) {
+			t.Errorf("Expected %s to be allowed in read-only mode", key)
+		}
+	}
+}
+
+func TestHelpBarText(t *testing.T) {
+	mutating := []string{"s: stop", "b: boot", "r: reset", "d: delete"}
+	normal := helpBarText(false)
+	for _, part := range mutating {
+		if !strings.Contains(normal, part) {
+			t.Errorf("Expected %q in the help bar, got %q", part, normal)
+		}
+	}
+	readOnly := helpBarText(true)
+	for _, part := range mutating {
+		if strings.Contains(readOnly, part) {
+			t.Errorf("Expected %q to be hidden in read-only mode, got %q", part, readOnly)
+		}
+	}
+	if !strings.HasSuffix(readOnly, "read-only") || !strings.Contains(readOnly, "c: copy") {
+		t.Errorf("Expected the read-only bar to keep the other keys and say read-only, got %q", readOnly)
+	}
+}
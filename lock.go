@@ -0,0 +1,120 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// --- INSTANCE LOCKING ---
+
+// stateDirName is the per-project directory where Plate keeps its local state.
+const stateDirName = ".plate"
+
+// stateDir is where the project's state is kept: stateDirName next to its
+// config, or in the working directory for remote configs and stdin.
+var stateDir = stateDirName
+
+// useStateDirOf keeps the state next to the config at source, so every
+// instance started on the project shares its lock and state, whichever
+// directory it was started from.
+func useStateDirOf(source string) {
+	if isFileSource(source) {
+		stateDir = filepath.Join(filepath.Dir(source), stateDirName)
+	}
+}
+
+// lockFileName is the file inside stateDirName that is locked while Plate mutates containers.
+const lockFileName = "plate.lock"
+
+// instanceLock is a held per-project lock. The zero value represents a lock
+// that was skipped with --force.
+type instanceLock struct {
+	file *os.File
+}
+
+// lockedError is returned when another Plate instance holds the lock.
+type lockedError struct {
+	pid int
+}
+
+func (e *lockedError) Error() string {
+	if e.pid == 0 {
+		return "another plate instance is running"
+	}
+	return fmt.Sprintf("another plate instance is running (PID %d)", e.pid)
+}
+
+// acquireLock takes the project lock so that only one Plate instance mutates
+// containers at a time. With force, a held lock is ignored.
+func acquireLock(force bool) (*instanceLock, error) {
+	if err := os.MkdirAll(stateDir, 0755); err != nil {
+		return nil, fmt.Errorf("could not create %s: %w", stateDir, err)
+	}
+	f, err := os.OpenFile(filepath.Join(stateDir, lockFileName), os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("could not open lock file: %w", err)
+	}
+
+	if err := tryLockFile(f); err != nil {
+		holder := readLockHolder(f)
+		f.Close()
+		if force {
+			return &instanceLock{}, nil
+		}
+		return nil, &lockedError{pid: holder}
+	}
+
+	f.Truncate(0)
+	f.WriteAt([]byte(strconv.Itoa(os.Getpid())), 0)
+	return &instanceLock{file: f}, nil
+}
+
+// release gives up the lock.
+func (l *instanceLock) release() {
+	if l == nil || l.file == nil {
+		return
+	}
+	l.file.Truncate(0)
+	unlockFile(l.file)
+	l.file.Close()
+}
+
+// runningInstance returns the PID of the Plate instance that holds the
+// project lock, or errNoInstance when none does.
+func runningInstance() (int, error) {
+	return runningInstanceIn(stateDir)
+}
+
+// runningInstanceIn is runningInstance for the project whose state
+// directory is dir.
+func runningInstanceIn(dir string) (int, error) {
+	f, err := os.OpenFile(filepath.Join(dir, lockFileName), os.O_RDWR, 0644)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, errNoInstance
+	}
+	if err != nil {
+		return 0, fmt.Errorf("could not open lock file: %w", err)
+	}
+	defer f.Close()
+	if err := tryLockFile(f); err == nil {
+		unlockFile(f)
+		return 0, errNoInstance
+	}
+	if pid := readLockHolder(f); pid != 0 {
+		return pid, nil
+	}
+	return 0, fmt.Errorf("the running plate instance didn't record its PID")
+}
+
+// readLockHolder returns the PID recorded in the lock file, or 0.
+func readLockHolder(f *os.File) int {
+	data := make([]byte, 32)
+	n, _ := f.ReadAt(data, 0)
+	pid, _ := strconv.Atoi(strings.TrimSpace(string(data[:n])))
+	return pid
+}
@@ -0,0 +1,84 @@
+//go:build unix
+
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAcquireLock(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	lock, err := acquireLock(false)
+	if err != nil {
+		t.Fatalf("Expected first lock to succeed, got %v", err)
+	}
+
+	_, err = acquireLock(false)
+	var locked *lockedError
+	if !errors.As(err, &locked) {
+		t.Fatalf("Expected lockedError, got %v", err)
+	}
+	if locked.pid != os.Getpid() {
+		t.Errorf("Expected holder PID %d, got %d", os.Getpid(), locked.pid)
+	}
+
+	if _, err := acquireLock(true); err != nil {
+		t.Errorf("Expected forced lock to succeed, got %v", err)
+	}
+
+	lock.release()
+	again, err := acquireLock(false)
+	if err != nil {
+		t.Fatalf("Expected lock after release to succeed, got %v", err)
+	}
+	again.release()
+}
+
+func TestRunningInstance(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	if _, err := runningInstance(); !errors.Is(err, errNoInstance) {
+		t.Fatalf("Expected errNoInstance without a lock file, got %v", err)
+	}
+	lock, err := acquireLock(false)
+	if err != nil {
+		t.Fatalf("Expected lock to succeed, got %v", err)
+	}
+	if pid, err := runningInstance(); err != nil || pid != os.Getpid() {
+		t.Errorf("Expected PID %d, got %d (%v)", os.Getpid(), pid, err)
+	}
+	lock.release()
+	if _, err := runningInstance(); !errors.Is(err, errNoInstance) {
+		t.Errorf("Expected errNoInstance after release, got %v", err)
+	}
+}
+
+func TestLockFollowsConfig(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	os.Mkdir("app", 0755)
+	t.Cleanup(func() { stateDir = stateDirName })
+
+	// One instance started in the project, one from its parent with --config.
+	t.Chdir(filepath.Join(dir, "app"))
+	useStateDirOf("plate.config.json")
+	lock, err := acquireLock(false)
+	if err != nil {
+		t.Fatalf("Expected the first lock to succeed, got %v", err)
+	}
+	defer lock.release()
+
+	t.Chdir(dir)
+	useStateDirOf(filepath.Join("app", "plate.config.json"))
+	var locked *lockedError
+	if _, err := acquireLock(false); !errors.As(err, &locked) {
+		t.Errorf("Expected the second instance to find the lock, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join("app", stateDirName, lockFileName)); err != nil {
+		t.Errorf("Expected the lock next to the config, got %v", err)
+	}
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// --- LOG EXPORT ---
+
+// parseTimeBound parses a --since/--until value: a duration before now
+// (e.g. "30m") or an RFC 3339 timestamp.
+func parseTimeBound(s string, now time.Time) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q, use a duration like 30m or an RFC 3339 timestamp", s)
+}
+
+// inTimeRange reports whether t lies within [since, until]; zero bounds are open.
+func inTimeRange(t, since, until time.Time) bool {
+	return (since.IsZero() || !t.Before(since)) && (until.IsZero() || !t.After(until))
+}
+
+// readPersistedLogs returns a service's persisted log lines between since
+// and until, oldest first. Each line starts with its RFC 3339 timestamp.
+func readPersistedLogs(service string, since, until time.Time) ([]string, error) {
+	path := serviceLogPath(service)
+	rotated, _ := filepath.Glob(path + ".*")
+	generation := func(file string) int {
+		n, _ := strconv.Atoi(strings.TrimPrefix(file, path+"."))
+		return n
+	}
+	// Higher suffixes are older, so read them first.
+	sort.Slice(rotated, func(i, j int) bool { return generation(rotated[i]) > generation(rotated[j]) })
+	files := append(rotated, path)
+
+	var lines []string
+	found := false
+	for _, file := range files {
+		f, err := os.Open(file)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		found = true
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			line := scanner.Text()
+			stamp, _, _ := strings.Cut(line, " ")
+			if t, err := time.Parse(time.RFC3339, stamp); err == nil && !inTimeRange(t, since, until) {
+				continue
+			}
+			lines = append(lines, line)
+		}
+		f.Close()
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+	if !found {
+		return nil, fs.ErrNotExist
+	}
+	return lines, nil
+}
+
+// dockerLogs returns a container's logs with timestamps, for services whose
+// logs weren't persisted.
+func dockerLogs(containerID string, since, until time.Time) ([]string, error) {
+	args := []string{"logs", "--timestamps"}
+	if !since.IsZero() {
+		args = append(args, "--since", since.Format(time.RFC3339))
+	}
+	if !until.IsZero() {
+		args = append(args, "--until", until.Format(time.RFC3339))
+	}
+	output, err := dockerCommand(append(args, containerID)...).CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("could not read logs: %s", strings.TrimSpace(string(output)))
+	}
+	return strings.Split(strings.TrimRight(string(output), "\n"), "\n"), nil
+}
+
+// handleLogsCmd prints or exports a service's logs.
+func handleLogsCmd(args []string) {
+	fs := flag.NewFlagSet("logs", flag.ExitOnError)
+	addConfigFlag(fs)
+	export := fs.String("export", "", "write the logs to this file instead of printing them")
+	sinceFlag := fs.String("since", "", "only logs newer than a duration (e.g. 30m) or RFC 3339 time")
+	untilFlag := fs.String("until", "", "only logs older than a duration (e.g. 5m) or RFC 3339 time")
+	fs.Parse(args)
+	if fs.NArg() < 1 {
+		fmt.Println("Usage: plate logs [--since 30m] [--until 5m] [--export file] <service>")
+		os.Exit(exitUsage)
+	}
+	configPath := "plate.config.json"
+	if v := fs.Lookup("config").Value.String(); v != "" {
+		configPath = v
+	}
+	plateConfig := mustLoadConfig(configPath)
+
+	now := time.Now()
+	since, err := parseTimeBound(*sinceFlag, now)
+	if err == nil {
+		var until time.Time
+		if until, err = parseTimeBound(*untilFlag, now); err == nil {
+			err = printServiceLogs(plateConfig, fs.Arg(0), since, until, *export)
+		}
+	}
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// printServiceLogs writes a service's logs to stdout or the export file.
+// Persisted logs are preferred; otherwise the container's own logs are used.
+func printServiceLogs(cfg PlateConfig, name string, since, until time.Time, export string) error {
+	var svc *ServiceConfig
+	for i := range cfg.Services {
+		if cfg.Services[i].Name == name {
+			svc = &cfg.Services[i]
+		}
+	}
+	if svc == nil {
+		return fmt.Errorf("no service named '%s' in the config", name)
+	}
+
+	lines, err := readPersistedLogs(name, since, until)
+	if errors.Is(err, fs.ErrNotExist) {
+		if svc.isProcess() {
+			return fmt.Errorf("no logs recorded for '%s' yet", name)
+		}
+		containers, listErr := listPlateContainers(cfg.Project)
+		if listErr != nil {
+			return listErr
+		}
+		ctr, ok := serviceContainers(cfg, containers)[name]
+		if !ok {
+			return fmt.Errorf("no logs recorded for '%s' and it has no container", name)
+		}
+		lines, err = dockerLogs(ctr.ID, since, until)
+	}
+	if err != nil {
+		return err
+	}
+
+	text := strings.Join(lines, "\n")
+	if len(lines) > 0 {
+		text += "\n"
+	}
+	if export == "" {
+		fmt.Print(text)
+		return nil
+	}
+	if err := os.WriteFile(export, []byte(text), 0644); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Exported %d line(s) of '%s' logs to '%s'.\n", len(lines), name, export)
+	return nil
+}
+
+// exportLogLines writes lines from the log view to a timestamped file in the
+// current directory and returns its name.
+func exportLogLines(lines []logLine, now time.Time) (string, error) {
+	name := fmt.Sprintf("plate-logs-%s.log", now.Format("20060102-150405"))
+	var b strings.Builder
+	for _, l := range lines {
+		b.WriteString(fmt.Sprintf("%s %s %s\n", l.time.Format(time.RFC3339), l.service, l.text))
+	}
+	return name, os.WriteFile(name, []byte(b.String()), 0644)
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// --- STRUCTURED LOGS ---
+
+// Field names structured loggers commonly use for the same thing.
+var (
+	logLevelKeys   = []string{"level", "lvl", "severity", "log.level"}
+	logMessageKeys = []string{"msg", "message", "event"}
+	logTimeKeys    = []string{"time", "ts", "timestamp", "@timestamp"}
+)
+
+// parseJSONLog returns the fields of a JSON object log line, or nil if the
+// line isn't one.
+func parseJSONLog(text string) map[string]any {
+	trimmed := strings.TrimSpace(text)
+	if !strings.HasPrefix(trimmed, "{") {
+		return nil
+	}
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(trimmed), &fields); err != nil {
+		return nil
+	}
+	return fields
+}
+
+// formatLogValue renders a JSON value compactly.
+func formatLogValue(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case nil:
+		return "null"
+	case map[string]any, []any:
+		data, _ := json.Marshal(v)
+		return string(data)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// logField looks up a field by name, ignoring case. "level" and "msg" also
+// match their common aliases.
+func logField(fields map[string]any, key string) (string, bool) {
+	keys := []string{key}
+	switch strings.ToLower(key) {
+	case "level", "lvl", "severity":
+		keys = logLevelKeys
+	case "msg", "message":
+		keys = logMessageKeys
+	}
+	for _, k := range keys {
+		for name, v := range fields {
+			if strings.EqualFold(name, k) {
+				return formatLogValue(v), true
+			}
+		}
+	}
+	return "", false
+}
+
+// logLevel returns the level of a line: its level field for structured
+// lines, otherwise a guess from the text.
+func logLevel(l logLine) string {
+	if level, ok := logField(l.fields, "level"); ok {
+		return strings.ToLower(level)
+	}
+	upper := strings.ToUpper(l.text)
+	switch {
+	case strings.Contains(upper, "ERROR"), strings.Contains(upper, "FATAL"), strings.Contains(upper, "PANIC"):
+		return "error"
+	case strings.Contains(upper, "WARN"):
+		return "warn"
+	}
+	return ""
+}
+
+// prettyLogText renders a structured line as "LEVEL message key=value ...",
+// leaving other lines unchanged.
+func prettyLogText(l logLine) string {
+	if l.fields == nil {
+		return l.text
+	}
+	skip := map[string]bool{}
+	for _, group := range [][]string{logLevelKeys, logMessageKeys, logTimeKeys} {
+		for _, k := range group {
+			skip[k] = true
+		}
+	}
+
+	var parts []string
+	if level, ok := logField(l.fields, "level"); ok {
+		parts = append(parts, strings.ToUpper(level))
+	}
+	if msg, ok := logField(l.fields, "msg"); ok {
+		parts = append(parts, msg)
+	}
+	keys := make([]string, 0, len(l.fields))
+	for k := range l.fields {
+		if !skip[strings.ToLower(k)] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%s", k, formatLogValue(l.fields[k])))
+	}
+	return strings.Join(parts, " ")
+}
+
+// logQuery is a parsed log search: free text plus key=value field filters.
+type logQuery struct {
+	text   string
+	fields map[string]string
+}
+
+// parseLogQuery splits a search into field filters (level=error,
+// service=main-db) and free text.
+func parseLogQuery(query string) logQuery {
+	q := logQuery{fields: map[string]string{}}
+	var words []string
+	for _, word := range strings.Fields(query) {
+		if k, v, ok := strings.Cut(word, "="); ok && k != "" {
+			q.fields[strings.ToLower(k)] = v
+			continue
+		}
+		words = append(words, word)
+	}
+	q.text = strings.Join(words, " ")
+	return q
+}
+
+// matches reports whether a line satisfies the query. The service filter
+// applies to every line; other field filters only match structured lines.
+func (q logQuery) matches(l logLine) bool {
+	for k, want := range q.fields {
+		var got string
+		switch {
+		case k == "service":
+			got = l.service
+		case k == "level":
+			got = logLevel(l)
+		default:
+			v, ok := logField(l.fields, k)
+			if !ok {
+				return false
+			}
+			got = v
+		}
+		if !strings.EqualFold(got, want) {
+			return false
+		}
+	}
+	if q.text == "" {
+		return true
+	}
+	t := strings.ToLower(q.text)
+	return strings.Contains(strings.ToLower(l.text), t) || strings.Contains(strings.ToLower(l.service), t)
+}
@@ -0,0 +1,272 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// --- LOG PERSISTENCE ---
+
+// logsDirName is the directory inside stateDirName holding service logs.
+const logsDirName = "logs"
+
+// Defaults for LogConfig.
+const (
+	defaultLogMaxSizeMB = 10
+	defaultLogMaxFiles  = 3
+)
+
+// LogConfig controls how service logs are persisted under .plate/logs.
+type LogConfig struct {
+	Disabled  bool `json:"disabled,omitempty"`
+	MaxSizeMB int  `json:"maxSizeMB,omitempty"` // size at which a log file is rotated
+	MaxFiles  int  `json:"maxFiles,omitempty"`  // rotated files kept next to the current one
+}
+
+// serviceLogPath returns the current log file of a service.
+func serviceLogPath(service string) string {
+	return filepath.Join(stateDir, logsDirName, service, service+".log")
+}
+
+// rotatingFile is a log file that is renamed to .1, .2, ... once it
+// reaches maxSize, keeping at most maxFiles old files.
+type rotatingFile struct {
+	path     string
+	maxSize  int64
+	maxFiles int
+	f        *os.File
+	size     int64
+}
+
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	if r.f == nil {
+		if err := r.open(); err != nil {
+			return 0, err
+		}
+	}
+	if r.size > 0 && r.size+int64(len(p)) > r.maxSize {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.f.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// open opens the current log file for appending.
+func (r *rotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	r.f, r.size = f, info.Size()
+	return nil
+}
+
+// rotate shifts the full log file to .1 (and older ones up by one) and
+// starts a new one.
+func (r *rotatingFile) rotate() error {
+	r.f.Close()
+	r.f = nil
+	os.Remove(fmt.Sprintf("%s.%d", r.path, r.maxFiles))
+	for i := r.maxFiles - 1; i >= 1; i-- {
+		os.Rename(fmt.Sprintf("%s.%d", r.path, i), fmt.Sprintf("%s.%d", r.path, i+1))
+	}
+	if err := os.Rename(r.path, r.path+".1"); err != nil {
+		return err
+	}
+	return r.open()
+}
+
+func (r *rotatingFile) Close() error {
+	if r.f == nil {
+		return nil
+	}
+	return r.f.Close()
+}
+
+// maxBufferedLogLines is how many recent lines the hub keeps in memory for
+// the log view.
+const maxBufferedLogLines = 5000
+
+// logLine is one line of a service's output.
+type logLine struct {
+	service string
+	time    time.Time
+	text    string
+	fields  map[string]any // set when the line is a JSON object
+}
+
+// logHub collects the output of every service while the TUI runs. It keeps
+// the most recent lines in memory for the log view and, unless disabled,
+// persists them to rotating files so logs survive container resets.
+type logHub struct {
+	mu        sync.Mutex
+	cfg       LogConfig
+	persist   bool
+	files     map[string]*rotatingFile
+	followers map[string]func() // service -> stops following its logs
+	following map[string]string // service -> followed container ID
+	lines     []logLine         // the recent lines, up to twice maxBufferedLogLines
+	version   int               // incremented on every new line
+}
+
+// newLogHub returns a hub for the config. Lines are only written to disk
+// when persist is set and the config doesn't disable it.
+func newLogHub(cfg PlateConfig, persist bool) *logHub {
+	logCfg := LogConfig{}
+	if cfg.Logs != nil {
+		logCfg = *cfg.Logs
+	}
+	if logCfg.MaxSizeMB <= 0 {
+		logCfg.MaxSizeMB = defaultLogMaxSizeMB
+	}
+	if logCfg.MaxFiles <= 0 {
+		logCfg.MaxFiles = defaultLogMaxFiles
+	}
+	return &logHub{
+		cfg:       logCfg,
+		persist:   persist && !logCfg.Disabled,
+		files:     map[string]*rotatingFile{},
+		followers: map[string]func(){},
+		following: map[string]string{},
+	}
+}
+
+// append records one line of a service's output.
+func (h *logHub) append(service, line string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	now := time.Now()
+	h.lines = append(h.lines, logLine{service: service, time: now, text: line, fields: parseJSONLog(line)})
+	// Dropping old lines copies the buffer, so it is done once per
+	// maxBufferedLogLines lines rather than on every one.
+	if len(h.lines) >= 2*maxBufferedLogLines {
+		h.lines = append([]logLine(nil), h.lines[len(h.lines)-maxBufferedLogLines:]...)
+	}
+	h.version++
+	if !h.persist {
+		return
+	}
+	f, ok := h.files[service]
+	if !ok {
+		f = &rotatingFile{path: serviceLogPath(service), maxSize: int64(h.cfg.MaxSizeMB) << 20, maxFiles: h.cfg.MaxFiles}
+		h.files[service] = f
+	}
+	fmt.Fprintf(f, "%s %s\n", now.Format(time.RFC3339), line)
+}
+
+// snapshot returns the buffered lines and the hub's version.
+func (h *logHub) snapshot() ([]logLine, int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	lines := h.lines
+	if len(lines) > maxBufferedLogLines {
+		lines = lines[len(lines)-maxBufferedLogLines:]
+	}
+	return append([]logLine(nil), lines...), h.version
+}
+
+// writer returns an io.Writer that feeds a service's output into the hub
+// line by line. Use one writer per stream so lines don't interleave.
+func (h *logHub) writer(service string) *lineWriter {
+	return &lineWriter{emit: func(line string) { h.append(service, line) }}
+}
+
+// followContainer streams a container's logs into the hub, unless it is
+// already being followed. Following ends on its own when the container
+// stops.
+func (h *logHub) followContainer(service, containerID string) {
+	h.mu.Lock()
+	if h.following[service] == containerID {
+		h.mu.Unlock()
+		return
+	}
+	if stop := h.followers[service]; stop != nil {
+		stop()
+		delete(h.followers, service)
+	}
+	h.following[service] = containerID
+
+	stop, done, err := backend.follow(containerID, h.writer(service), h.writer(service))
+	if err != nil {
+		h.mu.Unlock()
+		h.append(service, fmt.Sprintf("[plate] could not follow logs: %v", err))
+		return
+	}
+	h.followers[service] = stop
+	h.mu.Unlock()
+	go func() {
+		<-done
+		h.mu.Lock()
+		if h.following[service] == containerID {
+			delete(h.followers, service)
+			delete(h.following, service)
+		}
+		h.mu.Unlock()
+	}()
+}
+
+// sync starts following the containers of running services.
+func (h *logHub) sync(items []list.Item) {
+	if h == nil {
+		return
+	}
+	for _, itm := range items {
+		if i := itm.(item); i.status == statusRunning && i.containerID != "" {
+			h.followContainer(i.config.Name, i.containerID)
+		}
+	}
+}
+
+// close stops every follower and closes the log files.
+func (h *logHub) close() {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, stop := range h.followers {
+		stop()
+	}
+	for _, f := range h.files {
+		f.Close()
+	}
+}
+
+// lineWriter splits written bytes into lines.
+type lineWriter struct {
+	mu   sync.Mutex
+	buf  []byte
+	emit func(line string)
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		w.emit(string(bytes.TrimRight(w.buf[:i], "\r")))
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "db", "db.log")
+	r := &rotatingFile{path: path, maxSize: 10, maxFiles: 2}
+	defer r.Close()
+
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		if _, err := r.Write([]byte(line)); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	expected := map[string]string{
+		path:        "fourth\n",
+		path + ".1": "third\n",
+		path + ".2": "second\n",
+	}
+	for p, want := range expected {
+		got, err := os.ReadFile(p)
+		if err != nil {
+			t.Errorf("Expected %s to exist, got %v", p, err)
+			continue
+		}
+		if string(got) != want {
+			t.Errorf("Expected %s to contain %q, got %q", p, want, got)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); err == nil {
+		t.Errorf("Expected at most 2 rotated files")
+	}
+}
+
+func TestLineWriter(t *testing.T) {
+	var lines []string
+	w := &lineWriter{emit: func(line string) { lines = append(lines, line) }}
+	w.Write([]byte("one\r\ntw"))
+	w.Write([]byte("o\nthree"))
+
+	if got := strings.Join(lines, "|"); got != "one|two" {
+		t.Errorf("Expected one|two, got %s", got)
+	}
+}
+
+func TestLogHubKeepsRecentLines(t *testing.T) {
+	h := newLogHub(PlateConfig{}, false)
+	total := 2*maxBufferedLogLines + 10
+	for i := range total {
+		h.append("cache", strconv.Itoa(i))
+	}
+	lines, version := h.snapshot()
+	if len(lines) != maxBufferedLogLines || version != total {
+		t.Fatalf("Expected %d lines at version %d, got %d at %d", maxBufferedLogLines, total, len(lines), version)
+	}
+	if first, last := lines[0].text, lines[len(lines)-1].text; first != strconv.Itoa(total-maxBufferedLogLines) || last != strconv.Itoa(total-1) {
+		t.Errorf("Expected the most recent lines, got %s to %s", first, last)
+	}
+	if len(h.lines) >= 2*maxBufferedLogLines {
+		t.Errorf("Expected the buffer to be trimmed, it holds %d lines", len(h.lines))
+	}
+}
+
+func TestReadPersistedLogs(t *testing.T) {
+	t.Chdir(t.TempDir())
+	path := serviceLogPath("db")
+	os.MkdirAll(filepath.Dir(path), 0755)
+	os.WriteFile(path+".2", []byte("2026-01-01T10:00:00Z oldest\n"), 0644)
+	os.WriteFile(path+".1", []byte("2026-01-01T11:00:00Z older\n"), 0644)
+	os.WriteFile(path, []byte("2026-01-01T12:00:00Z newest\n"), 0644)
+
+	lines, err := readPersistedLogs("db", time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := strings.Join(lines, "|"); !strings.HasSuffix(got, "oldest|2026-01-01T11:00:00Z older|2026-01-01T12:00:00Z newest") {
+		t.Errorf("Expected lines oldest first, got %s", got)
+	}
+
+	since := time.Date(2026, 1, 1, 10, 30, 0, 0, time.UTC)
+	until := time.Date(2026, 1, 1, 11, 30, 0, 0, time.UTC)
+	lines, _ = readPersistedLogs("db", since, until)
+	if len(lines) != 1 || !strings.HasSuffix(lines[0], "older") {
+		t.Errorf("Expected only the 11:00 line, got %v", lines)
+	}
+}
@@ -0,0 +1,265 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// --- COMBINED LOG VIEW ---
+
+// logRefreshInterval is how often the open log view picks up new lines.
+const logRefreshInterval = 250 * time.Millisecond
+
+// serviceColors are assigned to services in config order.
+var serviceColors = []lipgloss.Color{"39", "213", "214", "84", "141", "203", "45", "227"}
+
+var (
+	logWarnStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("220"))
+	logMatchStyle = lipgloss.NewStyle().Reverse(true)
+)
+
+// logView is the state of the combined log view.
+type logView struct {
+	viewport  viewport.Model
+	search    textinput.Model
+	searching bool      // the search input has focus
+	paused    bool      // new lines don't scroll the view
+	pretty    bool      // JSON lines are shown as "LEVEL message key=value"
+	version   int       // hub version last rendered
+	total     int       // buffered lines
+	shown     []logLine // lines matching the search
+	notice    string    // result of the last export
+}
+
+func newLogView() logView {
+	search := textinput.New()
+	search.Prompt = "/"
+	search.Placeholder = "search"
+	return logView{viewport: viewport.New(0, 0), search: search, version: -1}
+}
+
+type logTickMsg struct{}
+
+// logTickCmd schedules the next refresh of the log view.
+func logTickCmd() tea.Cmd {
+	return tea.Tick(logRefreshInterval, func(time.Time) tea.Msg { return logTickMsg{} })
+}
+
+// logLevelStyle highlights error and warning lines.
+func logLevelStyle(l logLine) lipgloss.Style {
+	switch logLevel(l) {
+	case "error", "err", "fatal", "panic", "critical", "crit":
+		return errorStyle
+	case "warn", "warning":
+		return logWarnStyle
+	}
+	return lipgloss.NewStyle()
+}
+
+// highlightMatches renders text in style with every case-insensitive
+// occurrence of query highlighted.
+func highlightMatches(text, query string, style lipgloss.Style) string {
+	if query == "" {
+		return style.Render(text)
+	}
+	var b strings.Builder
+	for {
+		start, end := indexFold(text, query)
+		if start < 0 {
+			b.WriteString(style.Render(text))
+			return b.String()
+		}
+		b.WriteString(style.Render(text[:start]))
+		b.WriteString(logMatchStyle.Render(text[start:end]))
+		text = text[end:]
+	}
+}
+
+// indexFold returns the byte range of the first case-insensitive match of
+// a non-empty query in text, or -1, -1. It compares rune by rune: lowering
+// can change how many bytes a rune takes, so offsets found in a lowered
+// copy don't fit the original.
+func indexFold(text, query string) (int, int) {
+	for start := range text {
+		i, j := start, 0
+		for i < len(text) && j < len(query) {
+			r, n := utf8.DecodeRuneInString(text[i:])
+			q, m := utf8.DecodeRuneInString(query[j:])
+			if !strings.EqualFold(string(r), string(q)) {
+				break
+			}
+			i, j = i+n, j+m
+		}
+		if j == len(query) {
+			return start, i
+		}
+	}
+	return -1, -1
+}
+
+// filterLogLines returns the lines matching a search, see parseLogQuery.
+func filterLogLines(lines []logLine, query string) []logLine {
+	if query == "" {
+		return lines
+	}
+	q := parseLogQuery(query)
+	var matched []logLine
+	for _, l := range lines {
+		if q.matches(l) {
+			matched = append(matched, l)
+		}
+	}
+	return matched
+}
+
+// renderLogLines formats lines as "time service | text", coloring each
+// service and highlighting levels and search matches. Lines should already
+// be filtered by query. With pretty set, JSON lines are shown as
+// "LEVEL message key=value".
+func renderLogLines(lines []logLine, services []ServiceConfig, query string, pretty bool) string {
+	highlight := parseLogQuery(query).text
+	width := 0
+	colors := map[string]lipgloss.Style{}
+	for i, s := range services {
+		colors[s.Name] = lipgloss.NewStyle().Foreground(serviceColors[i%len(serviceColors)])
+		width = max(width, len(s.Name))
+	}
+
+	var b strings.Builder
+	now := time.Now()
+	for i, l := range lines {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		name := fmt.Sprintf("%-*s", width, l.service)
+		stamp := timeDisplay.clock(l.time)
+		if timeDisplay.relative {
+			stamp = fmt.Sprintf("%8s", relativeTime(l.time, now))
+		}
+		b.WriteString(stoppedStyle.Render(stamp) + " ")
+		b.WriteString(colors[l.service].Render(name) + " │ ")
+		text := l.text
+		if pretty {
+			text = prettyLogText(l)
+		}
+		b.WriteString(highlightMatches(text, highlight, logLevelStyle(l)))
+	}
+	return b.String()
+}
+
+// refreshLogView re-renders the log view if the hub has new lines.
+func (m *model) refreshLogView(force bool) {
+	lines, version := m.logs.snapshot()
+	if version == m.logView.version && !force {
+		return
+	}
+	query := m.logView.search.Value()
+	matched := filterLogLines(lines, query)
+	m.logView.version = version
+	m.logView.total, m.logView.shown = len(lines), matched
+	m.logView.viewport.SetContent(renderLogLines(matched, m.config.enabledServices(), query, m.logView.pretty))
+	if !m.logView.paused {
+		m.logView.viewport.GotoBottom()
+	}
+}
+
+// updateLogView handles keys while the log view is open.
+func (m model) updateLogView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.logView.searching {
+		switch msg.String() {
+		case "enter":
+			m.logView.searching = false
+			m.logView.search.Blur()
+			return m, nil
+		case "esc":
+			m.logView.searching = false
+			m.logView.search.Blur()
+			m.logView.search.SetValue("")
+			m.refreshLogView(true)
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.logView.search, cmd = m.logView.search.Update(msg)
+		m.refreshLogView(true)
+		return m, cmd
+	}
+
+	switch msg.String() {
+	case "L", "q", "esc":
+		m.showingLogs = false
+		return m, nil
+	case "/":
+		m.logView.searching = true
+		return m, m.logView.search.Focus()
+	case "e":
+		name, err := exportLogLines(m.logView.shown, time.Now())
+		if err != nil {
+			m.logView.notice = errorStyle.Render(fmt.Sprintf("Export failed: %v", err))
+		} else {
+			m.logView.notice = successStyle.Render(fmt.Sprintf("Exported %d line(s) to %s", len(m.logView.shown), name))
+		}
+		return m, nil
+	case "J":
+		m.logView.pretty = !m.logView.pretty
+		m.refreshLogView(true)
+		return m, nil
+	case "p", " ":
+		m.logView.paused = !m.logView.paused
+		if !m.logView.paused {
+			m.logView.viewport.GotoBottom()
+		}
+		return m, nil
+	case "up", "k", "pgup", "ctrl+u", "home", "g":
+		m.logView.paused = true
+	case "G", "end":
+		m.logView.paused = false
+	}
+	var cmd tea.Cmd
+	m.logView.viewport, cmd = m.logView.viewport.Update(msg)
+	if msg.String() == "G" || msg.String() == "end" {
+		m.logView.viewport.GotoBottom()
+	}
+	return m, cmd
+}
+
+func (m model) renderLogView() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Logs"))
+	state := successStyle.Render("following")
+	if m.logView.paused {
+		state = pendingStyle.Render("paused")
+	}
+	b.WriteString("  " + state)
+	if m.logView.pretty {
+		b.WriteString("  " + detailValStyle.Render("pretty"))
+	}
+	if q := m.logView.search.Value(); q != "" && !m.logView.searching {
+		b.WriteString("  " + detailValStyle.Render("filter: "+q))
+	}
+	if m.logView.notice != "" {
+		b.WriteString("  " + m.logView.notice)
+	}
+	b.WriteString("\n\n")
+	switch {
+	case m.logView.total == 0:
+		b.WriteString(stoppedStyle.Render("No log lines yet. Output of running services appears here as it is written."))
+	case len(m.logView.shown) == 0:
+		b.WriteString(stoppedStyle.Render("No lines match the search."))
+	default:
+		b.WriteString(m.logView.viewport.View())
+	}
+	b.WriteString("\n\n")
+	if m.logView.searching {
+		b.WriteString(m.logView.search.View())
+	} else {
+		b.WriteString(helpStyle.Render("/: search (text or field=value) • J: pretty JSON • e: export • p/space: pause/follow • ↑/↓/pgup/pgdn: scroll • G: follow • L/q/esc: back"))
+	}
+	return docStyle.Render(b.String())
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestFilterLogLines(t *testing.T) {
+	lines := []logLine{
+		{service: "main-db", text: "database system is ready to accept connections"},
+		{service: "cache", text: "Ready to accept connections tcp"},
+		{service: "web", text: "ERROR: connection refused"},
+	}
+	tests := []struct {
+		query    string
+		expected []string
+	}{
+		{"", []string{"main-db", "cache", "web"}},
+		{"ready", []string{"main-db", "cache"}},
+		{"error", []string{"web"}},
+		{"CACHE", []string{"cache"}},
+		{"timeout", nil},
+	}
+	for _, tt := range tests {
+		got := filterLogLines(lines, tt.query)
+		if len(got) != len(tt.expected) {
+			t.Errorf("%q: Expected %d lines, got %d", tt.query, len(tt.expected), len(got))
+			continue
+		}
+		for i, l := range got {
+			if l.service != tt.expected[i] {
+				t.Errorf("%q: Expected line %d from %s, got %s", tt.query, i, tt.expected[i], l.service)
+			}
+		}
+	}
+}
+
+func TestStructuredLogFilter(t *testing.T) {
+	raw := []struct{ service, text string }{
+		{"api", `{"level":"error","msg":"query failed","table":"orders"}`},
+		{"api", `{"severity":"INFO","message":"listening","port":8080}`},
+		{"main-db", "ERROR:  relation \"orders\" does not exist"},
+		{"cache", "Ready to accept connections tcp"},
+	}
+	var lines []logLine
+	for _, r := range raw {
+		lines = append(lines, logLine{service: r.service, text: r.text, fields: parseJSONLog(r.text)})
+	}
+
+	tests := []struct {
+		query    string
+		expected int
+	}{
+		{"level=error", 2},
+		{"level=info", 1},
+		{"service=api", 2},
+		{"service=api level=error", 1},
+		{"table=orders", 1},
+		{"port=8080", 1},
+		{"level=error orders", 2},
+		{"table=users", 0},
+	}
+	for _, tt := range tests {
+		if got := len(filterLogLines(lines, tt.query)); got != tt.expected {
+			t.Errorf("%q: Expected %d lines, got %d", tt.query, tt.expected, got)
+		}
+	}
+}
+
+func TestPrettyLogText(t *testing.T) {
+	text := `{"time":"2026-01-01T00:00:00Z","level":"warn","msg":"slow query","ms":1200,"table":"orders"}`
+	got := prettyLogText(logLine{text: text, fields: parseJSONLog(text)})
+	if expected := "WARN slow query ms=1200 table=orders"; got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+
+	plain := logLine{text: "not json"}
+	if got := prettyLogText(plain); got != "not json" {
+		t.Errorf("Expected plain lines unchanged, got %q", got)
+	}
+}
+
+func TestHighlightMatches(t *testing.T) {
+	plain := lipgloss.NewStyle()
+	tests := []struct {
+		text, query, expected string
+	}{
+		{"an ERROR here", "error", "an " + logMatchStyle.Render("ERROR") + " here"},
+		{"err, Err", "ERR", logMatchStyle.Render("err") + ", " + logMatchStyle.Render("Err")},
+		// Ⱥ takes 2 bytes and ⱥ 3, so lowering the line changes its length.
+		{"ȺȺȺ err", "err", "ȺȺȺ " + logMatchStyle.Render("err")},
+		{"ȺȺȺ err", "ⱥⱥ", logMatchStyle.Render("ȺȺ") + "Ⱥ err"},
+		{"no match", "xyz", "no match"},
+	}
+	for _, tt := range tests {
+		if got := highlightMatches(tt.text, tt.query, plain); got != tt.expected {
+			t.Errorf("%q in %q: Expected %q, got %q", tt.query, tt.text, tt.expected, got)
+		}
+	}
+}
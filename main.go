@@ -2,8 +2,12 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"io/fs"
 	"os"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -15,52 +19,507 @@ func main() {
 		case "init":
 			handleInitCmd()
 			return
+		case "bug-report":
+			handleBugReportCmd(os.Args[2:])
+			return
+		case "version", "--version":
+			handleVersionCmd(os.Args[2:])
+			return
 		case "help":
 			handleHelpCmd()
 			return
+		case "diff":
+			handleDiffCmd(os.Args[2:])
+			return
+		case "apply":
+			handleApplyCmd(os.Args[2:])
+			return
+		case "down":
+			handleDownCmd(os.Args[2:])
+			return
+		case "adopt":
+			handleAdoptCmd(os.Args[2:])
+			return
+		case "config":
+			handleConfigCmd(os.Args[2:])
+			return
+		case "share":
+			handleShareCmd(os.Args[2:])
+			return
+		case "restore-env":
+			handleRestoreEnvCmd(os.Args[2:])
+			return
+		case "snapshot":
+			handleSnapshotCmd(os.Args[2:])
+			return
+		case "up":
+			handleUpCmd(os.Args[2:])
+			return
+		case "reap":
+			handleReapCmd(os.Args[2:])
+			return
+		case "hooks":
+			handleHooksCmd(os.Args[2:])
+			return
+		case "prompts":
+			handlePromptsCmd(os.Args[2:])
+			return
+		case "export":
+			handleExportCmd(os.Args[2:])
+			return
+		case "logs":
+			handleLogsCmd(os.Args[2:])
+			return
+		case "status":
+			handleStatusCmd(os.Args[2:])
+			return
+		case "doctor":
+			handleDoctorCmd(os.Args[2:])
+			return
+		case "stats":
+			handleStatsCmd(os.Args[2:])
+			return
+		case "tour":
+			runTUI(os.Args[2:], true)
+			return
+		case "statusline":
+			handleStatuslineCmd(os.Args[2:])
+			return
+		case "ports":
+			handlePortsCmd(os.Args[2:])
+			return
+		case "ca":
+			handleCACmd(os.Args[2:])
+			return
+		case "env":
+			handleEnvCmd(os.Args[2:])
+			return
+		case "direnv":
+			handleDirenvCmd(os.Args[2:])
+			return
+		case "render":
+			handleRenderCmd(os.Args[2:])
+			return
+		case "add":
+			handleAddCmd(os.Args[2:])
+			return
+		case "tags":
+			handleTagsCmd(os.Args[2:])
+			return
+		case "bench":
+			handleBenchCmd(os.Args[2:])
+			return
+		case "pull":
+			handlePullCmd(os.Args[2:])
+			return
+		case "load":
+			handleLoadCmd(os.Args[2:])
+			return
+		case "reload":
+			handleReloadCmd(os.Args[2:])
+			return
 		}
 	}
 
 	// Default behavior: start the TUI
-	configPath := "plate.config.json"
-	if len(os.Args) > 1 {
-		configPath = os.Args[1]
+	runTUI(os.Args[1:], false)
+}
+
+// runTUI starts the interactive UI. The onboarding tour is shown when
+// requested or when this user hasn't seen it yet.
+func runTUI(args []string, tour bool) {
+	fs := flag.NewFlagSet("plate", flag.ExitOnError)
+	force := fs.Bool("force", false, "run even if another plate instance holds the lock")
+	readOnly := fs.Bool("read-only", false, "observe services without changing any containers")
+	inline := fs.Bool("inline", false, "draw a compact status block instead of taking over the terminal")
+	lowPower := fs.Bool("low-power", false, "poll less often in the background to save battery")
+	prefetch := fs.Bool("prefetch", false, "pull every image the config uses before starting")
+	keepRunning := fs.Bool("keep-running", false, "leave containers running when quitting")
+	perBranch := fs.Bool("per-branch", false, "give the checked-out git branch containers of its own")
+	demo := fs.Bool("demo", false, "explore Plate on a built-in project with simulated containers, no docker needed")
+	addConfigFlag(fs)
+	fs.Parse(args)
+	configPath := configPathArg(fs)
+	var plateConfig PlateConfig
+	if *demo {
+		cfg, cleanup, err := startDemo()
+		if err != nil {
+			fmt.Printf("Error: Could not set up the demo: %v\n", err)
+			os.Exit(exitError)
+		}
+		defer cleanup()
+		plateConfig, configPath = cfg, "plate.config.json"
+	} else {
+		if configPath == stdinSource {
+			fmt.Println("Error: The TUI reads keys from stdin, so it can't read the config from there. Use --config - with 'plate up --ephemeral', 'plate apply' or 'plate diff'.")
+			os.Exit(exitUsage)
+		}
+		plateConfig = mustLoadConfig(configPath)
+	}
+	if !*readOnly {
+		plateConfig = mustAnswerPrompts(plateConfig)
+	}
+	plateConfig = mustResolveBuilds(plateConfig)
+	if *perBranch && !plateConfig.PerBranch {
+		plateConfig.PerBranch = true
+		if err := applyPerBranch(&plateConfig, "."); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(exitConfig)
+		}
+	}
+	if !*demo {
+		mustHaveDockerAccess(plateConfig)
+	}
+	// The demo's containers are simulated: it has no real ones to reap or
+	// images to pull, and its scratch project needs no lock.
+	if !*readOnly && !*demo {
+		// Expired ephemeral environments are reaped whenever Plate starts.
+		reapEphemeral(time.Now(), "", os.Stdout)
+		lock := mustAcquireLock(*force)
+		defer lock.release()
+		// Failed pulls are retried, and reported, per service in the TUI.
+		if *prefetch {
+			prefetchConfigImages(plateConfig, os.Stdout)
+		}
+	}
+
+	m := initialModel(plateConfig, *readOnly)
+	m.inline = *inline
+	m.keepRunning = *keepRunning
+	m.configPath = configPath
+	if *demo {
+		m.list.Title += " (demo)"
+	}
+	m.intervals, _ = resolveIntervals(plateConfig.Intervals, *lowPower)
+	// The inline block is too small for the tour, unless it was asked for.
+	m.touring = tour || (!*readOnly && !*inline && !tourDone())
+	if !*readOnly && !*inline {
+		m.announcement = takeAnnouncement(plateConfig, time.Now())
 	}
+	// Signals are handled below, so closing the terminal cleans up like q.
+	opts := []tea.ProgramOption{tea.WithReportFocus(), tea.WithoutSignalHandler()}
+	if !*inline {
+		opts = append(opts, tea.WithAltScreen())
+	}
+	p := tea.NewProgram(m, opts...)
+	signaled, stopSignals := notifyShutdown(p)
+	stopEvents := func() {}
+	if !*demo {
+		stopEvents = watchContainerEvents(p)
+	}
+	stopReload := notifyReload(p)
+	final, err := p.Run()
+	stopReload()
+	stopEvents()
+	stopSignals()
+	if m, ok := final.(model); ok {
+		// A signal, or the terminal going away, ends the program without
+		// the cleanup q runs.
+		if (signaled.Load() || err != nil) && !*readOnly {
+			cleanupAfterSignal(m.items, m.keepRunning, signalCleanupTimeout)
+		}
+		m.logs.close()
+		if !*readOnly {
+			fmt.Print(renderLeftRunning(m.items, m.keepRunning))
+		}
+	}
+	if !*readOnly {
+		removeLiveStatus()
+	}
+	if err != nil {
+		fmt.Printf("Alas, there's been an error: %v", err)
+		os.Exit(1)
+	}
+}
 
-	configFile, err := os.ReadFile(configPath)
+// mustLoadConfig loads the config at configPath or exits with a helpful message.
+func mustLoadConfig(configPath string) PlateConfig {
+	// The config's prompt answers are kept in its state, so find that first.
+	useStateDirOf(configPath)
+	plateConfig, err := loadConfig(configPath)
 	if err != nil {
-		if os.IsNotExist(err) {
+		if errors.Is(err, fs.ErrNotExist) {
 			fmt.Println("Error: Config file not found.")
 			fmt.Println("Run 'plate init' to create a default config file, or 'plate help' for more options.")
-			os.Exit(1)
+			os.Exit(exitConfig)
 		}
-		fmt.Printf("Error: Could not read '%s'. %v\n", configPath, err)
-		os.Exit(1)
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitConfig)
 	}
+	printConfigWarnings(plateConfig)
+	return plateConfig
+}
+
+// mustHaveDockerAccess exits with guidance when Plate runs under sudo or
+// may not use the docker socket, instead of failing service by service. A
+// stopped runtime is offered to be started.
+func mustHaveDockerAccess(cfg PlateConfig) {
+	if sudoInvoked() {
+		fmt.Println("Error: Plate is running through sudo, which leaves root-owned files in .plate.")
+		fmt.Println(`Run it as yourself; if docker needs root here, set "docker": {"sudo": true} in the config instead.`)
+		os.Exit(exitDocker)
+	}
+	if err := primeDockerSudo(); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitDocker)
+	}
+	if err := checkDockerAccess(); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitDocker)
+	}
+	if _, err := offerRuntimeStart(cfg, dockerReachable, askOnTerminal(os.Stdin)); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitDocker)
+	}
+}
+
+// mustAcquireLock takes the project lock or exits explaining who holds it.
+func mustAcquireLock(force bool) *instanceLock {
+	lock, err := acquireLock(force)
+	if err != nil {
+		fmt.Printf("Error: %v.\n", err)
+		var locked *lockedError
+		if errors.As(err, &locked) {
+			fmt.Println("Close the other instance first, or pass --force to run anyway.")
+		}
+		os.Exit(exitLocked)
+	}
+	return lock
+}
+
+// addConfigFlag registers the --config flag, which accepts a path, an
+// http(s) URL, a git+ssh/git+https reference, or - for stdin.
+func addConfigFlag(fs *flag.FlagSet) {
+	fs.String("config", "", "config file path, URL, git+ssh reference, or - for stdin (default \"plate.config.json\")")
+	fs.BoolVar(&strictConfig, "strict", false, "reject unknown config keys instead of warning about them")
+}
+
+// configPathArg returns the config source given with --config or as the
+// first positional argument, falling back to plate.config.json.
+func configPathArg(fs *flag.FlagSet) string {
+	if f := fs.Lookup("config"); f != nil && f.Value.String() != "" {
+		return f.Value.String()
+	}
+	if fs.NArg() > 0 {
+		return fs.Arg(0)
+	}
+	return "plate.config.json"
+}
+
+// handleDiffCmd prints the differences between the config and the running containers.
+func handleDiffCmd(args []string) {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	addConfigFlag(fs)
+	fs.Parse(args)
+	plateConfig := mustResolveBuilds(mustLoadConfig(configPathArg(fs)))
+	mustHaveDockerAccess(plateConfig)
+
+	containers, err := listPlateContainers(plateConfig.Project)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitDocker)
+	}
+	fmt.Println(renderDiff(computeDiff(plateConfig, containers)))
+}
+
+// handleApplyCmd converges the containers to the config in one shot.
+func handleApplyCmd(args []string) {
+	fs := flag.NewFlagSet("apply", flag.ExitOnError)
+	prune := fs.Bool("prune", false, "remove orphaned containers that are no longer in the config")
+	force := fs.Bool("force", false, "run even if another plate instance holds the lock")
+	addConfigFlag(fs)
+	fs.Parse(args)
+	plateConfig := mustResolveBuilds(mustAnswerPrompts(mustLoadConfig(configPathArg(fs))))
+	mustHaveDockerAccess(plateConfig)
+	lock := mustAcquireLock(*force)
+	defer lock.release()
+
+	containers, err := listPlateContainers(plateConfig.Project)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitDocker)
+	}
+	changes := computeDiff(plateConfig, containers)
+	fmt.Println(renderDiff(changes))
+	fmt.Println()
+	if errs := applyChanges(changes, *prune, os.Stdout); len(errs) > 0 {
+		fmt.Printf("\n%d service(s) could not be applied.\n", len(errs))
+		lock.release()
+		os.Exit(failuresExitCode(errs))
+	}
+	fmt.Println("\n✅ Environment matches the config.")
+}
+
+// handleDownCmd stops every running container that belongs to the config.
+func handleDownCmd(args []string) {
+	fs := flag.NewFlagSet("down", flag.ExitOnError)
+	force := fs.Bool("force", false, "run even if another plate instance holds the lock")
+	includePinned := fs.Bool("include-pinned", false, "stop pinned services too")
+	addConfigFlag(fs)
+	addOutputFlags(fs)
+	fs.Parse(args)
+	plateConfig := mustLoadConfig(configPathArg(fs))
+	mustHaveDockerAccess(plateConfig)
+	lock := mustAcquireLock(*force)
+	defer lock.release()
+
+	containers, err := listPlateContainers(plateConfig.Project)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		lock.release()
+		os.Exit(exitDocker)
+	}
+	st, _ := loadState()
+	pinned := func(svc ServiceConfig) bool { return !*includePinned && isPinned(svc, st) }
+	if failures := stopServices(plateConfig, containers, pinned, progressOut()); failures > 0 {
+		fmt.Fprintf(progressOut(), "\n%d service(s) could not be stopped.\n", failures)
+		lock.release()
+		os.Exit(exitPartial)
+	}
+}
+
+// handleAdoptCmd marks a pre-existing container as the container of a service.
+func handleAdoptCmd(args []string) {
+	fs := flag.NewFlagSet("adopt", flag.ExitOnError)
+	addConfigFlag(fs)
+	force := fs.Bool("force", false, "run even if another plate instance holds the lock")
+	fs.Parse(args)
+	if fs.NArg() < 1 {
+		fmt.Println("Usage: plate adopt [--config path] <service> [container]")
+		os.Exit(exitUsage)
+	}
+	configPath := "plate.config.json"
+	if v := fs.Lookup("config").Value.String(); v != "" {
+		configPath = v
+	}
+	plateConfig := mustResolveBuilds(mustLoadConfig(configPath))
+	mustHaveDockerAccess(plateConfig)
+	lock := mustAcquireLock(*force)
+	defer lock.release()
+
+	var svc *ServiceConfig
+	for i := range plateConfig.Services {
+		if plateConfig.Services[i].Name == fs.Arg(0) {
+			svc = &plateConfig.Services[i]
+		}
+	}
+	if svc == nil {
+		fmt.Printf("Error: No service named '%s' in the config.\n", fs.Arg(0))
+		lock.release()
+		os.Exit(exitUsage)
+	}
+	if svc.isProcess() {
+		fmt.Printf("Error: '%s' is a process service and has no container.\n", svc.Name)
+		lock.release()
+		os.Exit(exitUsage)
+	}
+
+	var ctr containerInfo
+	if fs.NArg() > 1 {
+		infos, err := inspectContainers(fs.Arg(1))
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			lock.release()
+			os.Exit(exitDocker)
+		}
+		if len(infos) == 0 {
+			fmt.Printf("Error: No container named '%s'.\n", fs.Arg(1))
+			lock.release()
+			os.Exit(exitUsage)
+		}
+		ctr = infos[0]
+		if err := validateAdoption(*svc, ctr); err != nil {
+			fmt.Printf("Error: Cannot adopt: %v.\n", err)
+			lock.release()
+			os.Exit(exitError)
+		}
+	} else {
+		candidates, err := findAdoptionCandidates(*svc)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			lock.release()
+			os.Exit(exitDocker)
+		}
+		switch len(candidates) {
+		case 0:
+			fmt.Printf("No unmanaged container runs %s on port %d.\n", imageRepository(imageName(*svc)), svc.Port)
+			lock.release()
+			os.Exit(exitError)
+		case 1:
+			ctr = candidates[0]
+		default:
+			fmt.Printf("Several containers could back '%s'. Pick one with 'plate adopt %s <container>':\n", svc.Name, svc.Name)
+			for _, c := range candidates {
+				fmt.Printf("  %s (%s, %s)\n", c.Name, c.Image, c.State)
+			}
+			lock.release()
+			os.Exit(exitUsage)
+		}
+	}
+
+	err := adoptContainer(*svc, ctr.ID)
+	recordAction("adopt", svc.Name, ctr.Name, "plate adopt", err)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		lock.release()
+		os.Exit(exitError)
+	}
+	fmt.Printf("✅ Adopted '%s' as service '%s'.\n", ctr.Name, svc.Name)
+	if ctr.Image != imageName(*svc) {
+		fmt.Printf("Note: it runs %s while the config asks for %s, so 'plate diff' will report drift.\n", ctr.Image, imageName(*svc))
+	}
+}
+
+// handleConfigCmd implements the `plate config` subcommands.
+func handleConfigCmd(args []string) {
+	if len(args) > 0 && args[0] == "migrate" {
+		handleConfigMigrateCmd(args[1:])
+		return
+	}
+	if len(args) == 0 || args[0] != "show" {
+		fmt.Println("Usage: plate config show [--effective] [config] | migrate [--dry-run] [config]")
+		os.Exit(exitUsage)
+	}
+	fs := flag.NewFlagSet("config show", flag.ExitOnError)
+	effective := fs.Bool("effective", false, "show the config after merging local overrides")
+	addConfigFlag(fs)
+	fs.Parse(args[1:])
+	configPath := configPathArg(fs)
 
 	var plateConfig PlateConfig
-	if err = json.Unmarshal(configFile, &plateConfig); err != nil {
-		fmt.Printf("Error: Could not parse '%s'. %v\n", configPath, err)
-		os.Exit(1)
+	var err error
+	if *effective {
+		useStateDirOf(configPath)
+		plateConfig, err = loadConfig(configPath)
+	} else {
+		plateConfig, err = loadConfigFile(configPath)
 	}
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitConfig)
+	}
+	printConfigWarnings(plateConfig)
 
-	p := tea.NewProgram(initialModel(plateConfig), tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
-		fmt.Printf("Alas, there's been an error: %v", err)
-		os.Exit(1)
+	data, _ := json.MarshalIndent(plateConfig, "", "  ")
+	fmt.Println(string(data))
+	if *effective {
+		if _, err := os.Stat(localConfigPath(configPath)); err == nil {
+			fmt.Fprintf(os.Stderr, "\nMerged '%s' over '%s'.\n", localConfigPath(configPath), configPath)
+		}
 	}
 }
 
 // handleInitCmd creates a boilerplate plate.config.json.
 func handleInitCmd() {
 	const defaultConfig = `{
+		"version": %d,
 		"services": [
 				{
 						"type": "postgres",
 						"name": "main-db",
 						"version": "14-alpine",
 						"port": 5433
-				},
+				}
 		]
 }`
 	configPath := "plate.config.json"
@@ -69,7 +528,7 @@ func handleInitCmd() {
 		os.Exit(1)
 	}
 
-	err := os.WriteFile(configPath, []byte(defaultConfig), 0644)
+	err := os.WriteFile(configPath, []byte(fmt.Sprintf(defaultConfig, currentConfigVersion())), 0644)
 	if err != nil {
 		fmt.Printf("Error writing config file: %v\n", err)
 		os.Exit(1)
@@ -85,9 +544,96 @@ func handleHelpCmd() {
 Usage:
 		plate                  - Start the TUI with 'plate.config.json' in the current directory.
 		plate [path/to/config] - Start the TUI with a specific config file.
+		plate --force          - Start the TUI even if another plate instance holds the project lock.
+		plate --read-only      - Observe services without starting, stopping, or removing anything.
+		plate --inline         - Draw a compact live status block instead of a full-screen UI (for tmux splits).
+		plate --demo           - Explore Plate on a built-in project with simulated containers, no docker needed.
+		plate --low-power      - Poll containers, stats, and health checks less often to save battery.
+		plate --prefetch       - Pull every image the config uses before starting the TUI.
+		plate --keep-running   - Leave containers running when quitting, and print what still runs.
+		plate --per-branch     - Give the checked-out git branch containers of its own.
+		plate --config <src>   - Use a config from a path, an https:// URL, or a git+ssh:// reference.
+		                         Every command that reads the config accepts --config; - reads it from stdin.
+		                         --strict rejects unknown config keys instead of warning about them.
+		plate tour             - Start the TUI with the onboarding tour.
 		plate init             - Create a default 'plate.config.json' in the current directory.
+		plate add [--name name] [--local] [--pick-version] [recipe]
+		                       - Append a service from a recipe on a free port; without a recipe, list them.
+		plate tags [--local] <service>
+		                       - Pick a service's version from its image's registry tags.
+		plate diff [config]    - Show how the running containers differ from the config.
+		plate apply [--prune] [config]
+		                       - Create, recreate, and (with --prune) remove containers to match the config.
+		plate down [--include-pinned] [config]
+		                       - Stop every running container in the config, except pinned ones.
+		plate pull [config]    - Download every image the config uses ahead of time.
+		plate reload [config]  - Make the running TUI read its config again and apply the changes.
+		plate status [--json] [config]
+		                       - Show the environment fingerprint and the state of every service.
+		plate adopt <service> [container]
+		                       - Manage an existing container (right image and port) as a service.
+		plate config show [--effective] [config]
+		                       - Print the config, optionally with local overrides merged in.
+		plate config migrate [--dry-run] [config]
+		                       - Upgrade the config and its local override to the current schema version.
+		plate share [-o file] [--with-data]
+		                       - Bundle the config, pinned images, and optionally data into an archive.
+		plate restore-env [--force] [--ignore-lock] <bundle>
+		                       - Recreate an environment from a 'plate share' archive.
+		plate snapshot [create|restore [--force] <name> | list | delete <name>]
+		                       - Save the data of every stateful service, and go back to it later.
+		plate up --ephemeral [--ttl 2h] [--progress json]
+		                       - Start a throwaway copy of the services on random ports, removed once it expires.
+		plate reap [--all]     - Remove expired ephemeral environments (--all: this project's, expired or not).
+		plate hooks install [--apply] [--force] | uninstall
+		                       - Warn (or apply) after a checkout or pull that changed the config.
+		plate prompts [list | reset [service]]
+		                       - Show your answers to the config's prompts, or forget them to be asked again.
+		plate export devcontainer [-o dir]
+		                       - Write a docker-compose file and devcontainer.json for the services.
+		plate export gha [-o file]
+		                       - Print a GitHub Actions 'services:' block matching the local environment.
+		plate export procfile [-o file]
+		                       - Write a Procfile (for foreman/overmind) with the process services.
+		plate export mprocs [-o file]
+		                       - Write an mprocs.yaml with the process services.
+		plate export tableplus | dbeaver [-o file]
+		                       - Write connections to import into TablePlus, or DBeaver's data-sources.json.
+		plate export images [--force] <bundle.tar>
+		                       - Save every image the config uses into one archive (docker save).
+		plate load images <bundle.tar>
+		                       - Load an image archive on a machine with restricted network access.
+		plate logs [--since 30m] [--until 5m] [--export file] <service>
+		                       - Print or export a service's logs for a time range.
+		plate ports [--local] [--yes]
+		                       - Find port conflicts and write a consistent remapping to the config.
+		plate env [--format shell|dotenv] [--shell posix|fish|powershell|cmd]
+		                       - Print every service's connection string (and OTLP settings) as env vars.
+		plate direnv [--write] [--function | --no-function]
+		                       - Print (or add to .envrc) a 'use plate' that loads them when you cd in.
+		plate render [-o file] [--force] <template>
+		                       - Render a Go template with the services' hosts, ports, and credentials.
+		plate ca [cert <name> [host...] | install | uninstall]
+		                       - Manage the local CA: issue certificates and trust it on this machine.
+		plate doctor [--fix]   - Diagnose the environment and, with --fix, apply safe repairs.
+		plate statusline [--plain] [-C dir]
+		                       - Print a one-line summary like 'plate: 3✅ 1🛑' for tmux or wezterm.
+		plate stats [--days 90] - Report local usage: starts, time-to-ready, and common errors.
+		plate bench [--runs 5] [--cold]
+		                       - Bring the environment up repeatedly and report p50/p95 time-to-ready.
+		plate bug-report [-o file]
+		                       - Bundle redacted diagnostics (config, docker info, logs, errors) for an issue.
+		plate version [--json] - Print the version, commit, build date, and Go version.
 		plate help             - Show this help message.
 
+Output:
+		up, down, status and pull take -q/--quiet to print only errors (on stderr) and
+		results in a form scripts can parse, or -v/--verbose to print every docker command.
+
+Exit Codes:
+		0 success, 1 other error, 2 bad arguments, 3 invalid config, 4 docker unavailable,
+		5 some services failed, 6 health check timed out, 7 another instance holds the lock.
+
 In-App Commands:
 		Press 'h' inside the app to see a list of interactive commands.`)
 }
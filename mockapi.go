@@ -0,0 +1,28 @@
+package main
+
+// --- MOCK API SERVICE ---
+
+// The "mock-api" service type runs WireMock with stubs from the repository.
+// Its dir holds WireMock's usual layout: mappings/ with the stub JSON files
+// and __files/ with response bodies.
+const (
+	wiremockPort = 8080
+	// wiremockRoot is WireMock's root directory in its image.
+	wiremockRoot = "/home/wiremock"
+)
+
+// wiremockSpec describes the container of a "mock-api" service. Response
+// templating is on, so stubs can echo parts of the request.
+func wiremockSpec(config ServiceConfig) serviceSpec {
+	return serviceSpec{
+		Image:         "wiremock/wiremock",
+		ContainerPort: wiremockPort,
+		Args:          []string{"--global-response-templating", "--disable-banner"},
+	}
+}
+
+// wiremockAdminURL returns the address of WireMock's admin API, which lists
+// and resets the stubs and the requests received.
+func wiremockAdminURL(connStr string) string {
+	return connStr + "/__admin"
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestMockAPIService(t *testing.T) {
+	t.Chdir(t.TempDir())
+	os.MkdirAll("stubs/mappings", 0755)
+	svc := ServiceConfig{Type: "mock-api", Name: "payments", Version: "3.9.1", Port: 8089, Dir: "stubs"}
+
+	connStr, args, err := getDockerRunArgs(svc, "plate-mock-api-payments")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	abs, _ := filepath.Abs("stubs")
+	if i := slices.Index(args, "-v"); i < 0 || args[i+1] != abs+":"+wiremockRoot+":ro" {
+		t.Errorf("Expected the stubs mounted into WireMock's root, got %v", args)
+	}
+	if !slices.Contains(args, "wiremock/wiremock:3.9.1") || !slices.Contains(args, "8089:8080") {
+		t.Errorf("Expected the WireMock image on port 8089, got %v", args)
+	}
+	if connStr != "http://localhost:8089" {
+		t.Errorf("Expected an http URL, got %s", connStr)
+	}
+	if err := validateStatic(PlateConfig{Services: []ServiceConfig{{Type: "mock-api", Name: "payments"}}}); err == nil {
+		t.Errorf("Expected an error for a mock-api service without a dir")
+	}
+}
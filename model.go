@@ -19,6 +19,26 @@ type item struct {
 	connectionString string
 	containerID      string
 	confirming       confirmationAction
+	external         *containerInfo // container Plate didn't create that is in the way
+	conflict         conflictKind
+	process          *runningProcess // set while a process service runs
+	stopping         bool            // the process was asked to stop, so its exit isn't an error
+	restarting       bool            // start the process again once it has stopped
+	index            int             // position in model.items, for setItem
+	watchSum         string          // last hash of the watched schema files
+	pendingMigrate   bool            // run the migrate command once the reset service is up
+	migration        string          // outcome of the last migration
+	migratedAt       time.Time       // when the last migration succeeded, shown if migration is empty
+	setup            string          // outcome of creating the databases and extensions
+	emulated         string          // the image's platform, when docker runs it under emulation
+	crashes          int             // crashes in a row right after a start, see handleStartChecked
+	checkingStart    bool            // the container was just started and is checked for a crash
+	exit             *containerExit  // how the container last ended, when it stopped on its own
+	crashLog         []string        // last log lines of the last crash
+	pinned           bool            // kept running on quit, see isPinned
+	upBefore         bool            // the container has run, so starting it again is a restart
+	restartDeps      bool            // restart the processes with restartWith once the service is ready
+	removed          bool            // gone from the config since a reload, see applyReload
 }
 
 func (i item) Title() string {
@@ -28,8 +48,28 @@ func (i item) Title() string {
 		icon = "🐘"
 	case "redis":
 		icon = "🟥"
+	case "process":
+		icon = "⚙️"
+	case "observability":
+		icon = "🔭"
+	case "proxy":
+		icon = "🔀"
+	case "static":
+		icon = "📁"
+	case "mock-api":
+		icon = "🎭"
+	case "keycloak", "dex":
+		icon = "🔑"
+	case "mailpit":
+		icon = "📬"
+	case "webhook-capture":
+		icon = "🪝"
 	}
-	return fmt.Sprintf("%s %s", icon, i.config.Name)
+	title := fmt.Sprintf("%s %s", icon, i.config.Name)
+	if i.pinned {
+		title += " 📌"
+	}
+	return title
 }
 
 func (i item) Description() string {
@@ -39,16 +79,43 @@ func (i item) Description() string {
 	if i.confirming == actionDelete {
 		return confirmStyle.Render("Confirm Delete? (y/n)")
 	}
+	if i.confirming == actionWatchReset {
+		if i.config.Watch.Migrate != "" {
+			return confirmStyle.Render("Schema changed. Reset & migrate? (y/n)")
+		}
+		return confirmStyle.Render("Schema changed. Reset? (y/n)")
+	}
+	if i.confirming == actionResolveExternal {
+		switch i.conflict {
+		case conflictCandidate:
+			return confirmStyle.Render("Existing container found: (a)dopt • (x) create new")
+		case conflictRenamed:
+			return confirmStyle.Render(fmt.Sprintf("Renamed from %s? (l)ink its data • (x) create new", i.external.Labels[labelService]))
+		case conflictPort:
+			return confirmStyle.Render("External container: (a)dopt • (x) abort")
+		}
+		return confirmStyle.Render("External container: (a)dopt • (r)ename • (x) abort")
+	}
+	if i.removed {
+		return stoppedStyle.Render("Removed from the config")
+	}
 	statusStr := i.status.String()
 	switch i.status {
-	case statusError:
+	case statusError, statusCrashLooping:
 		return errorStyle.Render(fmt.Sprintf("%s: %s", statusStr, i.statusText))
 	case statusRunning:
 		return successStyle.Render(statusStr)
-	case statusDownloading:
+	case statusDownloading, statusBuilding:
 		return downloadingStyle.Render(statusStr)
 	case statusStopped:
+		if i.exit != nil && i.exit.failed() {
+			return errorStyle.Render("🛑 " + i.exit.String())
+		} else if i.exit != nil {
+			return stoppedStyle.Render("🛑 " + i.exit.String())
+		}
 		return stoppedStyle.Render(statusStr)
+	case statusExternal:
+		return confirmStyle.Render(statusStr)
 	default:
 		return pendingStyle.Render(statusStr)
 	}
@@ -57,20 +124,60 @@ func (i item) FilterValue() string { return i.config.Name }
 
 // --- MAIN MODEL ---
 type model struct {
-	list        list.Model
-	spinner     spinner.Model
-	err         error
-	quitting    bool
-	showCopied  bool
-	showingHelp bool // New state for showing the help view
+	config         PlateConfig
+	items          []list.Item     // every service; list only shows the ones not collapsed into a stack
+	collapsed      map[string]bool // stacks whose members are hidden
+	list           list.Model
+	spinner        spinner.Model
+	err            error
+	quitting       bool
+	keepRunning    bool          // quitting leaves containers running and prints where they are
+	confirmingQuit bool          // asking before quitting stops anything
+	copying        string        // what is being copied: copyURL or copyCommand, empty otherwise
+	copied         *clipboardMsg // how the copy went, nil while it runs
+	showingHelp    bool          // New state for showing the help view
+	showingDiff    bool
+	diff           *diffLoadedMsg // nil while the diff is loading
+	readOnly       bool           // observation mode: nothing that changes containers is allowed
+	tasks          []taskState
+	ready          bool    // every service has been running at once; see lifecycleEvents
+	logs           *logHub // only persists to disk outside read-only mode
+	showingLogs    bool
+	logView        logView
+	showingInspect bool
+	inspect        inspectView
+	showingTop     bool
+	top            topView
+	showingQR      bool
+	qr             qrView
+	showingHistory bool
+	history        historyView
+	stats          map[string][]resourceSample // recent CPU and memory samples per running service
+	intervals      pollIntervals
+	slots          dockerSemaphores // limit concurrent pulls and starts
+	blurred        bool             // the terminal window lost focus
+	paused         pausedPolls      // polling loops stopped while blurred
+	eventsLive     bool             // docker events stream, see watchContainerEvents
+	fingerprint    string           // one-line environment fingerprint, empty until collected
+	usage          *usageTracker    // nil in read-only mode
+	touring        bool             // the onboarding tour is shown
+	announcement   string           // the config's announcement, until dismissed
+	clock          containerClock   // the selected service's container clock, read with the stats
+	tourStep       int
+	inline         bool   // compact rendering without the alternate screen
+	configPath     string // where the config is read again from on a reload, empty if it can't be
 }
 
-func initialModel(cfg PlateConfig) model {
-	items := make([]list.Item, len(cfg.Services))
-	for i, s := range cfg.Services {
+func initialModel(cfg PlateConfig, readOnly bool) model {
+	services := cfg.enabledServices()
+	st, _ := loadState()
+	items := make([]list.Item, len(services))
+	for i, s := range services {
 		items[i] = item{
 			config: s,
 			status: statusPending,
+			index:  i,
+			pinned: isPinned(s, st),
 		}
 	}
 
@@ -83,39 +190,166 @@ func initialModel(cfg PlateConfig) model {
 	delegate.Styles.SelectedTitle = selectedStyle
 	delegate.Styles.SelectedDesc = selectedStyle.Copy().Foreground(lipgloss.Color("250")).Faint(true)
 
-	l := list.New(items, delegate, 0, 0)
+	l := list.New(nil, delegate, 0, 0)
 	l.Title = "Plate Dev Environment"
+	if readOnly {
+		l.Title += " (read-only)"
+	}
 	l.Styles.Title = titleStyle
 	l.SetShowHelp(false)
+	// Long enough to read config warnings and reload results.
+	l.StatusMessageLifetime = 10 * time.Second
 
 	s := spinner.New(spinner.WithSpinner(spinner.Dot), spinner.WithStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("205"))))
 
-	return model{list: l, spinner: s}
+	m := model{config: cfg, items: items, collapsed: map[string]bool{}, list: l, spinner: s, readOnly: readOnly, tasks: newTaskStates(cfg), logs: newLogHub(cfg, !readOnly), logView: newLogView(), inspect: newInspectView(), top: newTopView(), stats: map[string][]resourceSample{}}
+	m.intervals, _ = resolveIntervals(cfg.Intervals, false) // validated when the config was loaded
+	m.slots = newDockerSemaphores(cfg.Concurrency)
+	m.list.SetItems(m.visibleItems())
+	if !readOnly {
+		m.usage = newUsageTracker()
+	}
+	return m
 }
 
 // --- BUBBLE TEA LOGIC ---
 func (m model) Init() tea.Cmd {
-	cmds := make([]tea.Cmd, len(m.list.Items()))
-	for i, itm := range m.list.Items() {
-		currentItem := itm.(item)
-		currentItem.status = statusChecking
-		m.list.SetItem(i, currentItem)
-		cmds[i] = checkContainerCmd(i, currentItem.config)
+	cmds := make([]tea.Cmd, len(m.items))
+	for i := range m.items {
+		cmds[i] = m.bootItem(i)
+	}
+	cmds = append(cmds, fingerprintCmd(m.config), statsTickCmd(m.intervals.stats), reconcileTickCmd(m.intervals.reconcile))
+	if warnings := m.config.warnings; len(warnings) > 0 {
+		cmds = append(cmds, func() tea.Msg { return configWarningsMsg{warnings: warnings} })
+	}
+	if !m.readOnly {
+		for _, itm := range m.items {
+			if svc := itm.(item).config; svc.Watch != nil {
+				cmds = append(cmds, watchCmd(svc.Name, svc.Watch.Path, m.intervals.watch))
+			}
+		}
+		for i, t := range m.tasks {
+			cmds = append(cmds, scheduleTaskCmd(i, t.interval))
+		}
 	}
 	return tea.Batch(append(cmds, m.spinner.Tick)...)
 }
 
-//nolint:cyclop
+// bootItem starts a process service, or checks on a container service's
+// container, which then comes up if it isn't already.
+func (m model) bootItem(i int) tea.Cmd {
+	currentItem := m.items[i].(item)
+	if currentItem.config.isProcess() {
+		if m.readOnly {
+			currentItem.status = statusAbsent
+			return m.setItem(i, currentItem)
+		}
+		currentItem.status = statusStarting
+		m.setItem(i, currentItem)
+		return startProcessCmd(currentItem.config.Name, currentItem.config, siblingEnv(m.items), m.logs)
+	}
+	currentItem.status = statusChecking
+	m.setItem(i, currentItem)
+	return checkContainerCmd(currentItem.config.Name, m.config, currentItem.config, true)
+}
+
+// Update handles a message, follows the logs of running containers, records
+// usage stats, publishes the status for `plate statusline`, and sends
+// webhook notifications for the lifecycle changes it caused.
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	// If showing help, only listen for keys that hide it.
+	before := append([]list.Item(nil), m.items...)
+	next, cmd := m.update(msg)
+	updated, ok := next.(model)
+	if !ok {
+		return next, cmd
+	}
+	cmd = tea.Batch(cmd, updated.list.SetItems(updated.visibleItems()))
+	updated.logs.sync(updated.items)
+	if updated.usage != nil {
+		recordUsage(updated.usage.observe(before, updated.items, time.Now()))
+	}
+	if !m.readOnly && statusesChanged(before, updated.items) {
+		writeLiveStatus(updated.items)
+	}
+	if m.readOnly || m.config.Notifications == nil {
+		return updated, cmd
+	}
+	events, ready := lifecycleEvents(m.config.Project, before, updated.items, updated.ready)
+	updated.ready = ready
+	cmds := []tea.Cmd{cmd}
+	for _, ev := range events {
+		if m.config.Notifications.wants(ev.Event) {
+			cmds = append(cmds, sendWebhookCmd(*m.config.Notifications, ev))
+		}
+	}
+	return updated, tea.Batch(cmds...)
+}
+
+//nolint:cyclop
+func (m model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// runTUI cleans up after the program ends, as the terminal may be gone.
+	if _, ok := msg.(shutdownSignalMsg); ok {
+		return m, tea.Quit
+	}
+
+	// If showing help, only listen for keys that hide it. Command results
+	// and task ticks keep flowing.
 	if m.showingHelp {
 		if key, ok := msg.(tea.KeyMsg); ok {
 			switch key.String() {
 			case "h", "q", "esc":
 				m.showingHelp = false
 			}
+			return m, nil
+		}
+	}
+
+	// Like the diff panel, the log view only captures keys.
+	if m.showingLogs {
+		if key, ok := msg.(tea.KeyMsg); ok {
+			return m.updateLogView(key)
+		}
+	}
+
+	if m.showingInspect {
+		if key, ok := msg.(tea.KeyMsg); ok {
+			return m.updateInspectView(key)
+		}
+	}
+
+	if m.showingTop {
+		if key, ok := msg.(tea.KeyMsg); ok {
+			return m.updateTopView(key)
+		}
+	}
+
+	if m.showingQR {
+		if key, ok := msg.(tea.KeyMsg); ok {
+			return m.updateQRView(key)
+		}
+	}
+
+	if m.showingHistory {
+		if key, ok := msg.(tea.KeyMsg); ok {
+			return m.updateHistoryView(key)
+		}
+	}
+
+	if m.confirmingQuit {
+		if key, ok := msg.(tea.KeyMsg); ok {
+			return m.updateQuitConfirm(key)
+		}
+	}
+
+	// The diff panel only captures keys; command results keep flowing.
+	if m.showingDiff {
+		if key, ok := msg.(tea.KeyMsg); ok {
+			switch key.String() {
+			case "D", "q", "esc":
+				m.showingDiff = false
+			}
+			return m, nil
 		}
-		return m, nil
 	}
 
 	if m.quitting {
@@ -132,104 +366,516 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		h, v := docStyle.GetFrameSize()
 		listWidth := int(float32(msg.Width-h) * 0.45)
 		m.list.SetSize(listWidth, msg.Height-v-3)
+		m.logView.viewport.Width = msg.Width - h
+		m.logView.viewport.Height = msg.Height - v - 4
+		m.inspect.viewport.Width = msg.Width - h
+		m.inspect.viewport.Height = msg.Height - v - 4
+		m.top.viewport.Width = msg.Width - h
+		m.top.viewport.Height = msg.Height - v - 4
 
 	case tea.KeyMsg:
 		// When in confirmation mode, we only want to handle y/n/esc.
-		if m.list.SelectedItem() != nil && m.list.SelectedItem().(item).confirming != actionNone {
-			selectedItem, _ := m.list.SelectedItem().(item)
-			selectedIndex := m.list.Index()
+		if selectedItem, ok := m.list.SelectedItem().(item); ok && selectedItem.confirming != actionNone {
+			selectedIndex := selectedItem.index
+			if selectedItem.confirming == actionResolveExternal {
+				return m.updateExternalPrompt(msg, selectedItem, selectedIndex)
+			}
 			switch msg.String() {
 			case "y", "Y":
 				switch selectedItem.confirming {
 				case actionReset:
 					selectedItem.status = statusResetting
 					selectedItem.confirming = actionNone
-					return m, tea.Batch(m.list.SetItem(selectedIndex, selectedItem), removeContainerCmd(selectedIndex, selectedItem.containerID, true))
+					return m, tea.Batch(m.setItem(selectedIndex, selectedItem), removeContainerCmd(selectedItem.config.Name, selectedItem.config, selectedItem.containerID, true))
+				case actionWatchReset:
+					selectedItem.status = statusResetting
+					selectedItem.confirming = actionNone
+					selectedItem.pendingMigrate = true
+					return m, tea.Batch(m.setItem(selectedIndex, selectedItem), removeContainerCmd(selectedItem.config.Name, selectedItem.config, selectedItem.containerID, true))
 				case actionDelete:
 					selectedItem.status = statusDeleting
 					selectedItem.confirming = actionNone
-					return m, tea.Batch(m.list.SetItem(selectedIndex, selectedItem), removeContainerCmd(selectedIndex, selectedItem.containerID, false))
+					return m, tea.Batch(m.setItem(selectedIndex, selectedItem), removeContainerCmd(selectedItem.config.Name, selectedItem.config, selectedItem.containerID, false))
 				}
 			case "n", "N", "esc":
 				selectedItem.confirming = actionNone
-				return m, m.list.SetItem(selectedIndex, selectedItem)
+				return m, m.setItem(selectedIndex, selectedItem)
 			}
 			return m, nil
 		}
 		// Handle regular key presses.
+		var tourCmd tea.Cmd
+		if m.touring {
+			m, tourCmd = m.advanceTour(msg.String())
+			if tourCmd != nil || msg.String() == "tab" {
+				return m, tourCmd
+			}
+		}
+		if header, ok := m.list.SelectedItem().(groupItem); ok && msg.String() == "enter" {
+			m.collapsed[header.name] = !m.collapsed[header.name]
+			return m, nil
+		}
+		if m.readOnly && isMutatingKey(msg.String()) {
+			return m, nil
+		}
 		switch msg.String() {
 		case "h":
 			m.showingHelp = true
+		case "x":
+			m.announcement = ""
+		case "enter":
+			if selectedItem, ok := m.list.SelectedItem().(item); ok && selectedItem.status == statusExternal {
+				selectedItem.confirming = actionResolveExternal
+				return m, m.setItem(selectedItem.index, selectedItem)
+			}
+		case "L":
+			m.showingLogs = true
+			m.refreshLogView(true)
+			return m, logTickCmd()
+		case "i":
+			if selectedItem, ok := m.list.SelectedItem().(item); ok && selectedItem.containerID != "" {
+				m.showingInspect = true
+				m.inspect.service, m.inspect.containerID = selectedItem.config.Name, selectedItem.containerID
+				m.inspect.data, m.inspect.err = nil, nil
+				return m, inspectContainerCmd(selectedItem.containerID)
+			}
+		case "t":
+			if selectedItem, ok := m.list.SelectedItem().(item); ok && selectedItem.containerID != "" && selectedItem.status == statusRunning {
+				m.showingTop = true
+				m.top.service, m.top.containerID = selectedItem.config.Name, selectedItem.containerID
+				m.top.processes, m.top.err, m.top.updated = nil, nil, time.Time{}
+				m.top.session++
+				return m, containerTopCmd(m.top.session, selectedItem.containerID)
+			}
+		case "H":
+			m.showingHistory = true
+			m.history = historyView{}
+			return m, loadHistoryCmd()
+		case "D":
+			m.showingDiff = true
+			m.diff = nil
+			return m, loadDiffCmd(m.config)
 		case "q", "ctrl+c":
-			m.quitting = true
-			return m, stopAllContainersOnExit(m.list.Items())
-		case "s":
-			if selectedItem, ok := m.list.SelectedItem().(item); ok && selectedItem.status == statusRunning {
-				m.list.SetItem(m.list.Index(), selectedItem)
-				return m, stopContainerCmd(m.list.Index(), selectedItem.containerID)
+			if m.readOnly {
+				return m, tea.Quit
 			}
-		case "b":
-			if selectedItem, ok := m.list.SelectedItem().(item); ok && selectedItem.status == statusStopped {
-				m.list.SetItem(m.list.Index(), selectedItem)
-				return m, restartContainerCmd(m.list.Index(), selectedItem.config, selectedItem.containerID)
+			return m.startQuit()
+		case "p":
+			if selectedItem, ok := m.list.SelectedItem().(item); ok && !selectedItem.config.isProcess() {
+				selectedItem.pinned = !selectedItem.pinned
+				return m, tea.Batch(m.setItem(selectedItem.index, selectedItem), savePinCmd(selectedItem.config, selectedItem.pinned))
 			}
+		case "X":
+			m.quitting, m.keepRunning = true, true
+			return m, stopAllContainersOnExit(m.items, true)
+		case "s":
+			return m, m.forSelected(m.stopService)
+		case "b":
+			return m, m.forSelected(m.startService)
 		case "r":
 			if selectedItem, ok := m.list.SelectedItem().(item); ok && selectedItem.containerID != "" {
 				selectedItem.confirming = actionReset
-				return m, m.list.SetItem(m.list.Index(), selectedItem)
+				return m, m.setItem(selectedItem.index, selectedItem)
 			}
 		case "d":
 			if selectedItem, ok := m.list.SelectedItem().(item); ok && selectedItem.containerID != "" {
 				selectedItem.confirming = actionDelete
-				return m, m.list.SetItem(m.list.Index(), selectedItem)
+				return m, m.setItem(selectedItem.index, selectedItem)
 			}
 		case "c":
 			if selectedItem, ok := m.list.SelectedItem().(item); ok && selectedItem.status == statusRunning && selectedItem.connectionString != "" {
-				m.showCopied = true
-				return m, tea.Batch(copyToClipboardCmd(selectedItem.connectionString), tea.Tick(2*time.Second, func(t time.Time) tea.Msg { return copiedToClipboardMsg{} }))
+				m.copying, m.copied = copyURL, nil
+				return m, copyToClipboardCmd(selectedItem.connectionString)
+			}
+		case "Q":
+			if selectedItem, ok := m.list.SelectedItem().(item); ok && selectedItem.status == statusRunning && selectedItem.connectionString != "" {
+				m.showingQR = true
+				m.qr = newQRView(selectedItem.config, selectedItem.connectionString)
+			}
+		case "E":
+			items := make([]item, len(m.items))
+			for i, itm := range m.items {
+				items[i] = itm.(item)
+			}
+			if env := runningEnv(items); len(env) > 0 {
+				out, _ := formatEnv(env, "dotenv")
+				m.copying, m.copied = copyAll, nil
+				return m, copyToClipboardCmd(out)
+			}
+		case "C":
+			if selectedItem, ok := m.list.SelectedItem().(item); ok && !selectedItem.config.isProcess() {
+				if command, err := dockerRunCommand(selectedItem.config); err == nil {
+					m.copying, m.copied = copyCommand, nil
+					return m, copyToClipboardCmd(command)
+				}
 			}
 		}
 
+	case clipboardMsg:
+		m.copied = &msg
+		// Errors stay up longer, so there's time to read them.
+		d := 2 * time.Second
+		if msg.err != nil {
+			d = 5 * time.Second
+		}
+		return m, tea.Tick(d, func(t time.Time) tea.Msg { return copiedToClipboardMsg{} })
+
 	case copiedToClipboardMsg:
-		m.showCopied = false
+		m.copying, m.copied = "", nil
+		return m, nil
+
+	case historyLoadedMsg:
+		m.history = historyView{entries: msg.entries, err: msg.err, loaded: true}
 		return m, nil
 
+	case diffLoadedMsg:
+		m.diff = &msg
+		return m, nil
+
+	case statsTickMsg:
+		if m.blurred {
+			m.paused.stats = true
+			return m, nil
+		}
+		items := make([]item, len(m.items))
+		for i, itm := range m.items {
+			items[i] = itm.(item)
+		}
+		cmds := []tea.Cmd{sampleStatsCmd(items)}
+		if selected, ok := m.list.SelectedItem().(item); ok && selected.status == statusRunning && selected.process == nil && selected.containerID != "" {
+			cmds = append(cmds, readContainerClockCmd(selected.config.Name, selected.containerID))
+		}
+		return m, tea.Batch(cmds...)
+
+	case containerClockMsg:
+		m.clock = containerClock(msg)
+		return m, nil
+
+	case statsSampledMsg:
+		recordSamples(m.stats, msg.samples, msg.running)
+		return m, statsTickCmd(m.intervals.stats)
+
+	case reconcileTickMsg:
+		if m.blurred {
+			m.paused.reconcile = true
+			return m, nil
+		}
+		var ids []string
+		for _, itm := range m.items {
+			if it := itm.(item); it.containerID != "" && (it.status == statusRunning || it.status == statusStopped) {
+				ids = append(ids, it.containerID)
+			}
+		}
+		if len(ids) == 0 {
+			return m, reconcileTickCmd(m.reconcileInterval())
+		}
+		return m, reconcileCmd(ids)
+
+	case configWarningsMsg:
+		return m, m.list.NewStatusMessage(confirmStyle.Render(configWarning(msg.warnings)))
+	case configReloadMsg:
+		if m.configPath == "" || m.quitting {
+			return m, nil
+		}
+		return m, reloadConfigCmd(m.configPath, m.config.PerBranch)
+	case configReloadedMsg:
+		if msg.err != nil {
+			return m, m.list.NewStatusMessage(errorStyle.Render(fmt.Sprintf("Config not reloaded: %v", msg.err)))
+		}
+		cmd, summary := m.applyReload(msg.cfg)
+		if len(msg.cfg.warnings) > 0 {
+			return m, tea.Batch(cmd, m.list.NewStatusMessage(confirmStyle.Render(summary+". "+configWarning(msg.cfg.warnings))))
+		}
+		return m, tea.Batch(cmd, m.list.NewStatusMessage(successStyle.Render(summary)))
+
+	case containerEventMsg:
+		return m, m.handleContainerEvent(msg)
+	case containerEventsMsg:
+		cmd := m.handleContainerEvents(msg)
+		return m, cmd
+
+	case containersReconciledMsg:
+		var cmds []tea.Cmd
+		if !msg.event {
+			cmds = append(cmds, reconcileTickCmd(m.reconcileInterval()))
+		}
+		for i, itm := range m.items {
+			it := itm.(item)
+			state, checked := msg.states[it.containerID]
+			idle := it.status == statusRunning || it.status == statusStopped
+			if !checked || !idle || it.confirming != actionNone {
+				continue
+			}
+			switch {
+			case state == "":
+				// Removed outside Plate: check it like at startup.
+				it.status, it.containerID = statusChecking, ""
+				cmds = append(cmds, m.setItem(i, it), checkContainerCmd(it.config.Name, m.config, it.config, false))
+			case state == "running" && it.status == statusStopped:
+				it.status, it.exit = statusRunning, nil
+				it.connectionString, _ = getConnectionString(it.config)
+				cmds = append(cmds, m.setItem(i, it))
+			case state != "running" && it.status == statusRunning:
+				it.status = statusStopped
+				if exit, ok := msg.exits[it.containerID]; ok {
+					it.exit = &exit
+				}
+				cmds = append(cmds, m.setItem(i, it))
+			}
+		}
+		return m, tea.Batch(cmds...)
+
+	case topTickMsg:
+		if !m.showingTop || msg.session != m.top.session {
+			return m, nil
+		}
+		if m.blurred {
+			m.paused.top = true
+			return m, nil
+		}
+		return m, containerTopCmd(msg.session, msg.containerID)
+
+	case topLoadedMsg:
+		if !m.showingTop || msg.session != m.top.session {
+			return m, nil
+		}
+		m.top.titles, m.top.processes, m.top.err = msg.titles, msg.processes, msg.err
+		m.top.updated = time.Now()
+		m.top.viewport.SetContent(renderTop(msg.titles, msg.processes))
+		return m, topTickCmd(msg.session, m.top.containerID)
+
+	case inspectLoadedMsg:
+		if msg.containerID != m.inspect.containerID {
+			return m, nil
+		}
+		m.inspect.data, m.inspect.err = msg.data, msg.err
+		m.refreshInspectView()
+		return m, nil
+
+	case fingerprintMsg:
+		m.fingerprint = msg.fingerprint.String()
+		return m, nil
+
+	case tea.BlurMsg:
+		m.blurred = true
+		return m, nil
+
+	case tea.FocusMsg:
+		m.blurred = false
+		return m, m.resumePolls()
+
+	case logTickMsg:
+		if !m.showingLogs {
+			return m, nil
+		}
+		if m.blurred {
+			m.paused.logView = true
+			return m, nil
+		}
+		m.refreshLogView(false)
+		return m, logTickCmd()
+
+	case taskDueMsg:
+		task := &m.tasks[msg.index]
+		task.running = true
+		containerID := ""
+		for _, itm := range m.items {
+			if i := itm.(item); i.config.Name == task.config.Service && i.status == statusRunning {
+				containerID = i.containerID
+			}
+		}
+		return m, runTaskCmd(msg.index, task.config, containerID)
+	case taskFinishedMsg:
+		task := &m.tasks[msg.index]
+		task.running = false
+		task.lastRun = msg.at
+		task.lastErr = msg.err
+		task.skipped = msg.skipped
+		return m, scheduleTaskCmd(msg.index, task.interval)
+
 	// Handle command results
 	case containerStatusMsg:
-		currentItem := m.list.Items()[msg.index].(item)
+		currentItem, ok := m.lookupItem(msg.service)
+		if !ok {
+			return m, nil
+		}
+		if msg.external != nil {
+			currentItem.status = statusExternal
+			currentItem.external = msg.external
+			currentItem.conflict = msg.conflict
+			if !m.readOnly {
+				currentItem.confirming = actionResolveExternal
+			}
+			return m, m.setItem(currentItem.index, currentItem)
+		}
 		switch msg.status {
 		case "running":
 			currentItem.status = statusRunning
 			currentItem.containerID = msg.containerID
 			currentItem.connectionString, _ = getConnectionString(currentItem.config)
+			currentItem.upBefore = true
 		case "exited":
 			currentItem.status = statusStopped
 			currentItem.containerID = msg.containerID
+			// A clean exit is most likely Plate stopping it on quit.
+			if msg.exit.failed() {
+				currentItem.exit = &msg.exit
+			}
 		default:
+			if m.readOnly {
+				currentItem.status = statusAbsent
+				break
+			}
 			currentItem.status = statusChecking
-			return m, tea.Batch(m.list.SetItem(msg.index, currentItem), checkImageCmd(msg.index, currentItem.config))
+			return m, tea.Batch(m.setItem(currentItem.index, currentItem), checkImageCmd(msg.service, currentItem.config))
+		}
+		return m, m.setItem(currentItem.index, currentItem)
+	case watchMsg:
+		currentItem, ok := m.lookupItem(msg.service)
+		if !ok {
+			return m, nil
+		}
+		if currentItem.config.Watch == nil {
+			// A reload dropped the watch.
+			return m, nil
+		}
+		changed := currentItem.watchSum != "" && msg.sum != currentItem.watchSum
+		currentItem.watchSum = msg.sum
+		next := watchCmd(msg.service, currentItem.config.Watch.Path, m.intervals.watch)
+		// Only offer a reset for an existing container that isn't busy.
+		idle := currentItem.status == statusRunning || currentItem.status == statusStopped
+		if !changed || !idle || currentItem.confirming != actionNone || currentItem.containerID == "" {
+			return m, tea.Batch(m.setItem(currentItem.index, currentItem), next)
+		}
+		if currentItem.config.Watch.Auto {
+			currentItem.status = statusResetting
+			currentItem.pendingMigrate = true
+			return m, tea.Batch(m.setItem(currentItem.index, currentItem), removeContainerCmd(msg.service, currentItem.config, currentItem.containerID, true), next)
+		}
+		currentItem.confirming = actionWatchReset
+		return m, tea.Batch(m.setItem(currentItem.index, currentItem), next)
+	case servicePreparedMsg:
+		currentItem, ok := m.lookupItem(msg.service)
+		if !ok {
+			return m, nil
+		}
+		if msg.err != nil {
+			currentItem.setup = errorStyle.Render(fmt.Sprintf("✗ failed: %v", msg.err))
+		} else {
+			currentItem.setup = successStyle.Render("✓ created")
+		}
+		if currentItem.restartDeps {
+			currentItem.restartDeps = false
+			return m, tea.Batch(m.setItem(currentItem.index, currentItem), m.restartDependents(currentItem.config.Name))
+		}
+		return m, m.setItem(currentItem.index, currentItem)
+	case migrationDoneMsg:
+		currentItem, ok := m.lookupItem(msg.service)
+		if !ok {
+			return m, nil
 		}
-		return m, m.list.SetItem(msg.index, currentItem)
+		if msg.err != nil {
+			currentItem.migration = errorStyle.Render(fmt.Sprintf("✗ failed: %v", msg.err))
+		} else {
+			currentItem.migration, currentItem.migratedAt = "", time.Now()
+		}
+		if currentItem.restartDeps {
+			currentItem.restartDeps = false
+			return m, tea.Batch(m.setItem(currentItem.index, currentItem), m.restartDependents(currentItem.config.Name))
+		}
+		return m, m.setItem(currentItem.index, currentItem)
+	case dependencyReadyMsg:
+		currentItem, ok := m.lookupItem(msg.service)
+		if !ok {
+			return m, nil
+		}
+		if !currentItem.restartDeps {
+			return m, nil
+		}
+		currentItem.restartDeps = false
+		return m, tea.Batch(m.setItem(currentItem.index, currentItem), m.restartDependents(currentItem.config.Name))
+	case processStartedMsg:
+		currentItem, ok := m.lookupItem(msg.service)
+		if !ok {
+			return m, nil
+		}
+		if msg.err != nil {
+			currentItem.status = statusError
+			currentItem.statusText = msg.err.Error()
+			return m, m.setItem(currentItem.index, currentItem)
+		}
+		currentItem.status = statusRunning
+		currentItem.process = msg.process
+		currentItem.stopping = false
+		return m, tea.Batch(m.setItem(currentItem.index, currentItem), waitProcessCmd(msg.service, msg.process))
+	case processExitedMsg:
+		currentItem, ok := m.lookupItem(msg.service)
+		if !ok {
+			return m, nil
+		}
+		currentItem.process = nil
+		if currentItem.restarting && !m.quitting {
+			currentItem.restarting, currentItem.stopping = false, false
+			currentItem.status = statusStarting
+			return m, tea.Batch(m.setItem(currentItem.index, currentItem), startProcessCmd(msg.service, currentItem.config, siblingEnv(m.items), m.logs))
+		}
+		if msg.err != nil && !currentItem.stopping {
+			currentItem.status = statusError
+			currentItem.statusText = fmt.Sprintf("process exited: %v", msg.err)
+		} else {
+			currentItem.status = statusStopped
+		}
+		currentItem.stopping, currentItem.restarting = false, false
+		return m, m.setItem(currentItem.index, currentItem)
+	case externalResolvedMsg:
+		currentItem, ok := m.lookupItem(msg.service)
+		if !ok {
+			return m, nil
+		}
+		if msg.err != nil {
+			currentItem.status = statusError
+			currentItem.statusText = msg.err.Error()
+			return m, m.setItem(currentItem.index, currentItem)
+		}
+		currentItem.status = statusChecking
+		currentItem.external = nil
+		currentItem.conflict = conflictNone
+		return m, tea.Batch(m.setItem(currentItem.index, currentItem), checkContainerCmd(msg.service, m.config, currentItem.config, false))
 	case imageStatusMsg:
-		currentItem := m.list.Items()[msg.index].(item)
+		currentItem, ok := m.lookupItem(msg.service)
+		if !ok {
+			return m, nil
+		}
 		if msg.hasImage {
+			currentItem.emulated = msg.emulated
 			currentItem.status = statusStarting
-			return m, tea.Batch(m.list.SetItem(msg.index, currentItem), startContainerCmd(msg.index, currentItem.config, ""))
+			return m, tea.Batch(m.setItem(currentItem.index, currentItem), startContainerCmd(msg.service, currentItem.config, m.slots.starts))
+		}
+		if currentItem.config.Build != nil {
+			currentItem.status = statusBuilding
+			return m, tea.Batch(m.setItem(currentItem.index, currentItem), buildImageCmd(msg.service, currentItem.config, m.logs, m.slots.pulls))
 		}
 		currentItem.status = statusDownloading
-		return m, tea.Batch(m.list.SetItem(msg.index, currentItem), pullImageCmd(msg.index, currentItem.config))
+		return m, tea.Batch(m.setItem(currentItem.index, currentItem), pullImageCmd(msg.service, currentItem.config, m.slots.pulls))
 	case imagePulledMsg:
-		currentItem := m.list.Items()[msg.index].(item)
+		currentItem, ok := m.lookupItem(msg.service)
+		if !ok {
+			return m, nil
+		}
 		if msg.err != nil {
 			currentItem.status = statusError
 			currentItem.statusText = msg.err.Error()
 		} else {
+			currentItem.emulated = msg.emulated
 			currentItem.status = statusStarting
-			return m, tea.Batch(m.list.SetItem(msg.index, currentItem), startContainerCmd(msg.index, currentItem.config, ""))
+			return m, tea.Batch(m.setItem(currentItem.index, currentItem), startContainerCmd(msg.service, currentItem.config, m.slots.starts))
 		}
-		return m, m.list.SetItem(msg.index, currentItem)
+		return m, m.setItem(currentItem.index, currentItem)
 	case containerStartedMsg:
-		currentItem := m.list.Items()[msg.index].(item)
+		currentItem, ok := m.lookupItem(msg.service)
+		if !ok {
+			return m, nil
+		}
 		if msg.err != nil {
 			currentItem.status = statusError
 			currentItem.statusText = msg.err.Error()
@@ -237,19 +883,55 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			currentItem.status = statusRunning
 			currentItem.containerID = msg.containerID
 			currentItem.connectionString = msg.connectionString
+			// Processes that restart with the service do so once it's ready,
+			// which is after its setup or migration when it has one.
+			currentItem.restartDeps = currentItem.upBefore && m.hasDependents(currentItem.config.Name)
+			currentItem.upBefore = true
+			currentItem.checkingStart, currentItem.exit = true, nil
+			m.setItem(currentItem.index, currentItem)
+			refresh := m.refreshProcessEnv()
+			// A container that exits right away is retried, up to a point.
+			check := checkStartCmd(msg.service, msg.containerID)
+			if currentItem.pendingMigrate {
+				currentItem.pendingMigrate = false
+				if currentItem.config.Watch != nil && currentItem.config.Watch.Migrate != "" {
+					currentItem.migration = pendingStyle.Render("⏳ waiting for the database, then migrating...")
+					// migrateCmd creates the databases and extensions first.
+					return m, tea.Batch(m.setItem(currentItem.index, currentItem), migrateCmd(msg.service, currentItem.config, msg.containerID, m.intervals.health), refresh, check)
+				}
+			}
+			if needsSetup(currentItem.config) {
+				currentItem.setup = pendingStyle.Render("⏳ waiting for the database...")
+				return m, tea.Batch(m.setItem(currentItem.index, currentItem), prepareServiceCmd(msg.service, currentItem.config, msg.containerID, m.intervals.health), refresh, check)
+			}
+			if currentItem.restartDeps {
+				return m, tea.Batch(m.setItem(currentItem.index, currentItem), dependencyReadyCmd(msg.service, currentItem.config, msg.containerID, m.intervals.health), refresh, check)
+			}
+			return m, tea.Batch(m.setItem(currentItem.index, currentItem), refresh, check)
 		}
-		return m, m.list.SetItem(msg.index, currentItem)
+		return m, m.setItem(currentItem.index, currentItem)
+	case startCheckedMsg:
+		return m.handleStartChecked(msg)
+	case crashRetryMsg:
+		return m.handleCrashRetry(msg)
 	case containerStoppedMsg:
-		currentItem := m.list.Items()[msg.index].(item)
+		currentItem, ok := m.lookupItem(msg.service)
+		if !ok {
+			return m, nil
+		}
 		if msg.err != nil {
 			currentItem.status = statusError
 			currentItem.statusText = msg.err.Error()
 		} else {
-			currentItem.status = statusStopped
+			// Stopped on purpose, so not a crash.
+			currentItem.status, currentItem.checkingStart, currentItem.exit = statusStopped, false, nil
 		}
-		return m, m.list.SetItem(msg.index, currentItem)
+		return m, m.setItem(currentItem.index, currentItem)
 	case containerRemovedMsg:
-		currentItem := m.list.Items()[msg.index].(item)
+		currentItem, ok := m.lookupItem(msg.service)
+		if !ok {
+			return m, nil
+		}
 		if msg.err != nil {
 			currentItem.status = statusError
 			currentItem.statusText = msg.err.Error()
@@ -258,11 +940,11 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			currentItem.connectionString = ""
 			if msg.isReset {
 				currentItem.status = statusChecking
-				return m, tea.Batch(m.list.SetItem(msg.index, currentItem), checkImageCmd(msg.index, currentItem.config))
+				return m, tea.Batch(m.setItem(currentItem.index, currentItem), checkImageCmd(msg.service, currentItem.config))
 			}
 			currentItem.status = statusPending
 		}
-		return m, m.list.SetItem(msg.index, currentItem)
+		return m, m.setItem(currentItem.index, currentItem)
 	}
 
 	var cmd tea.Cmd
@@ -275,16 +957,83 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// updateExternalPrompt handles the adopt / rename / abort choice for a service
+// whose container name or port is taken by a container Plate didn't create.
+func (m model) updateExternalPrompt(msg tea.KeyMsg, selectedItem item, selectedIndex int) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "l", "L":
+		if selectedItem.conflict != conflictRenamed {
+			return m, nil
+		}
+		selectedItem.confirming = actionNone
+		selectedItem.status = statusChecking
+		return m, tea.Batch(m.setItem(selectedIndex, selectedItem), relinkContainerCmd(selectedItem.config.Name, selectedItem.config, *selectedItem.external))
+	case "a", "A":
+		if selectedItem.conflict == conflictRenamed {
+			return m, nil
+		}
+		selectedItem.confirming = actionNone
+		selectedItem.status = statusChecking
+		return m, tea.Batch(m.setItem(selectedIndex, selectedItem), adoptContainerCmd(selectedItem.config.Name, selectedItem.config, *selectedItem.external))
+	case "r", "R":
+		if selectedItem.conflict != conflictName {
+			return m, nil
+		}
+		selectedItem.confirming = actionNone
+		selectedItem.status = statusChecking
+		return m, tea.Batch(m.setItem(selectedIndex, selectedItem), renameExternalCmd(selectedItem.config.Name, selectedItem.config, *selectedItem.external))
+	case "x", "X", "esc":
+		selectedItem.confirming = actionNone
+		if selectedItem.conflict == conflictCandidate || selectedItem.conflict == conflictRenamed {
+			// Nothing is in the way, so declining adoption provisions a fresh container.
+			selectedItem.status = statusChecking
+			selectedItem.external = nil
+			selectedItem.conflict = conflictNone
+			return m, tea.Batch(m.setItem(selectedIndex, selectedItem), checkImageCmd(selectedItem.config.Name, selectedItem.config))
+		}
+		return m, m.setItem(selectedIndex, selectedItem)
+	}
+	return m, nil
+}
+
 func (m model) View() string {
 	if m.err != nil {
 		return docStyle.Render(errorStyle.Render(fmt.Sprintf("Fatal error: %v", m.err)))
 	}
+	if m.quitting && m.keepRunning {
+		return docStyle.Render(fmt.Sprintf("\n%s Stopping processes, leaving containers running...\n", m.spinner.View()))
+	}
 	if m.quitting {
 		return docStyle.Render(fmt.Sprintf("\n%s Stopping containers... Please wait.\n", m.spinner.View()))
 	}
+	if m.confirmingQuit {
+		return docStyle.Render(m.renderQuitConfirmView())
+	}
 	if m.showingHelp {
 		return m.renderFullHelpView()
 	}
+	if m.showingDiff {
+		return m.renderDiffView()
+	}
+	if m.showingLogs {
+		return m.renderLogView()
+	}
+	if m.showingInspect {
+		return m.renderInspectView()
+	}
+	if m.showingTop {
+		return m.renderTopView()
+	}
+	if m.showingQR {
+		return m.renderQRView()
+	}
+	if m.showingHistory {
+		return m.renderHistoryView()
+	}
+
+	if m.inline {
+		return m.renderInlineView()
+	}
 
 	detailView := m.renderDetailView()
 	mainView := lipgloss.JoinHorizontal(lipgloss.Top, m.list.View(), detailPaneStyle.Render(detailView))
@@ -293,33 +1042,171 @@ func (m model) View() string {
 	return docStyle.Render(lipgloss.JoinVertical(lipgloss.Left, mainView, helpView))
 }
 
+// Things the detail pane can copy.
+const (
+	copyURL     = "url"
+	copyCommand = "command"
+	copyAll     = "all" // every running service's connection string
+)
+
+// copyNotice returns the note shown next to what is being copied.
+func (m model) copyNotice(target string) string {
+	switch {
+	case m.copying != target || m.copied == nil:
+		return ""
+	case m.copied.err != nil:
+		return " " + errorStyle.Render("Couldn't copy: "+lastLine(m.copied.err.Error()))
+	case m.copied.viaTerminal:
+		return " " + copySuccessStyle.Render("Sent to the terminal's clipboard (OSC 52)")
+	}
+	return " " + copySuccessStyle.Render("Copied!")
+}
+
 func (m model) renderDetailView() string {
+	var b strings.Builder
+	if m.announcement != "" {
+		b.WriteString(m.renderAnnouncement(max(m.list.Width(), 40)) + "\n")
+	}
+	if m.touring {
+		b.WriteString(m.renderTour(max(m.list.Width(), 40)) + "\n")
+	}
+	if header, ok := m.list.SelectedItem().(groupItem); ok {
+		return b.String() + m.renderGroupDetail(header) + m.renderTools()
+	}
 	selectedItem, ok := m.list.SelectedItem().(item)
 	if !ok {
-		return "Select a service to see details."
+		return b.String() + "Select a service to see details."
 	}
-	var b strings.Builder
 	b.WriteString(detailTitleStyle.Render(selectedItem.Title()))
 	b.WriteString("\n\n")
 	b.WriteString(fmt.Sprintf("%s: %s\n", detailAttrStyle.Render("Type"), detailValStyle.Render(selectedItem.config.Type)))
-	b.WriteString(fmt.Sprintf("%s: %s\n", detailAttrStyle.Render("Version"), detailValStyle.Render(selectedItem.config.Version)))
+	if selectedItem.config.isProcess() {
+		b.WriteString(fmt.Sprintf("%s: %s\n", detailAttrStyle.Render("Command"), detailValStyle.Render(selectedItem.config.Command)))
+	} else {
+		b.WriteString(fmt.Sprintf("%s: %s\n", detailAttrStyle.Render("Version"), detailValStyle.Render(selectedItem.config.Version)))
+	}
+	if selectedItem.emulated != "" {
+		b.WriteString(fmt.Sprintf("%s: %s\n", detailAttrStyle.Render("Platform"), renderEmulation(selectedItem.emulated)))
+	}
 	b.WriteString(fmt.Sprintf("%s: %s\n", detailAttrStyle.Render("Status"), selectedItem.Description()))
-	if selectedItem.status == statusRunning {
+	if selectedItem.process != nil {
+		b.WriteString(fmt.Sprintf("\n%s: %s\n", detailAttrStyle.Render("PID"), detailValStyle.Render(fmt.Sprintf("%d", selectedItem.process.cmd.Process.Pid))))
+		if selectedItem.config.Dir != "" {
+			b.WriteString(fmt.Sprintf("%s: %s\n", detailAttrStyle.Render("Directory"), detailValStyle.Render(selectedItem.config.Dir)))
+		}
+	} else if selectedItem.status == statusRunning {
 		b.WriteString("\n")
 		b.WriteString(fmt.Sprintf("%s: %s\n", detailAttrStyle.Render("Host Port"), detailValStyle.Render(fmt.Sprintf("%d", selectedItem.config.Port))))
-		b.WriteString(fmt.Sprintf("%s: %s\n", detailAttrStyle.Render("Container ID"), detailValStyle.Render(selectedItem.containerID[:12])))
-		copyStatus := ""
-		if m.showCopied {
-			copyStatus = " " + copySuccessStyle.Render("Copied!")
+		b.WriteString(fmt.Sprintf("%s: %s\n", detailAttrStyle.Render("Container ID"), detailValStyle.Render(shortID(selectedItem.containerID))))
+		if m.clock.service == selectedItem.config.Name {
+			b.WriteString(fmt.Sprintf("%s: %s\n", detailAttrStyle.Render("Container Time"), renderContainerClock(m.clock, time.Now())))
+		}
+		b.WriteString(fmt.Sprintf("%s:%s\n%s\n", detailAttrStyle.Render("Connection URL"), m.copyNotice(copyURL), successStyle.Render(selectedItem.connectionString)))
+		if ui := serviceUIURL(selectedItem.config); ui != "" {
+			b.WriteString(fmt.Sprintf("%s: %s\n", detailAttrStyle.Render("Web UI"), detailValStyle.Render(ui)))
+		}
+		if isIdentityProvider(selectedItem.config) {
+			b.WriteString(fmt.Sprintf("%s: %s\n", detailAttrStyle.Render("Issuer"), detailValStyle.Render(issuerURL(selectedItem.config, selectedItem.connectionString))))
+		}
+		if selectedItem.config.Type == "mock-api" {
+			b.WriteString(fmt.Sprintf("%s: %s\n", detailAttrStyle.Render("Admin API"), detailValStyle.Render(wiremockAdminURL(selectedItem.connectionString))))
 		}
-		b.WriteString(fmt.Sprintf("%s:%s\n%s\n", detailAttrStyle.Render("Connection URL"), copyStatus, successStyle.Render(selectedItem.connectionString)))
 	} else if selectedItem.status == statusStopped {
-		b.WriteString(fmt.Sprintf("\n%s: %s\n", detailAttrStyle.Render("Container ID"), detailValStyle.Render(selectedItem.containerID[:12])))
+		b.WriteString(fmt.Sprintf("\n%s: %s\n", detailAttrStyle.Render("Container ID"), detailValStyle.Render(shortID(selectedItem.containerID))))
+		if exit := selectedItem.exit; exit != nil && exit.oomKilled {
+			b.WriteString(fmt.Sprintf("\n%s\n", confirmStyle.Render(memoryHint(selectedItem.config))))
+		}
+	} else if selectedItem.status == statusExternal && selectedItem.external != nil {
+		conflict := fmt.Sprintf("The name %s is used by a container Plate didn't create.", containerName(selectedItem.config))
+		switch selectedItem.conflict {
+		case conflictPort:
+			conflict = fmt.Sprintf("Port %d is published by a container Plate didn't create.", selectedItem.config.Port)
+		case conflictCandidate:
+			conflict = "A container Plate didn't create runs this service's image and port."
+		case conflictRenamed:
+			conflict = fmt.Sprintf("The service %s is gone from the config, but its container and data are still here.", selectedItem.external.Labels[labelService])
+		}
+		b.WriteString(fmt.Sprintf("\n%s\n", confirmStyle.Render(conflict)))
+		b.WriteString(fmt.Sprintf("%s: %s\n", detailAttrStyle.Render("Container"), detailValStyle.Render(selectedItem.external.Name)))
+		b.WriteString(fmt.Sprintf("%s: %s\n", detailAttrStyle.Render("Image"), detailValStyle.Render(selectedItem.external.Image)))
+		if selectedItem.confirming == actionNone {
+			hint := "Press enter to adopt or rename it."
+			if selectedItem.conflict == conflictRenamed {
+				hint = "Press enter to link it to this service or create a new one."
+			}
+			b.WriteString(fmt.Sprintf("\n%s\n", helpStyle.Render(hint)))
+		}
+	} else if selectedItem.status == statusCrashLooping {
+		b.WriteString(renderCrashLoop(selectedItem))
 	} else if selectedItem.status == statusError {
 		b.WriteString(fmt.Sprintf("\n%s: %s\n", detailAttrStyle.Render("Details"), errorStyle.Render(selectedItem.statusText)))
 	} else if selectedItem.confirming != actionNone {
 		b.WriteString(fmt.Sprintf("\n%s", confirmStyle.Render("Are you sure? This action cannot be undone.")))
 	}
+
+	if resources := renderResources(m.stats[selectedItem.config.Name]); resources != "" {
+		b.WriteString("\n" + resources)
+	}
+
+	if notes := selectedItem.config.Notes; notes != "" {
+		b.WriteString(fmt.Sprintf("\n%s:\n%s\n", detailAttrStyle.Render("Notes"), renderNotes(notes, max(m.list.Width(), 40))))
+	}
+
+	if dbs := selectedItem.config.Databases; len(dbs) > 0 {
+		b.WriteString(fmt.Sprintf("\n%s:\n", detailAttrStyle.Render("Databases")))
+		for _, db := range dbs {
+			line := detailValStyle.Render(db.Name)
+			if selectedItem.connectionString != "" {
+				line += " " + successStyle.Render(databaseURL(selectedItem.connectionString, db))
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+	if users := selectedItem.config.Users; len(users) > 0 {
+		b.WriteString(fmt.Sprintf("\n%s:\n", detailAttrStyle.Render("Users")))
+		for _, u := range users {
+			line := detailValStyle.Render(u.Name)
+			if selectedItem.connectionString != "" {
+				line += " " + successStyle.Render(redisUserURL(selectedItem.connectionString, u))
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+	if selectedItem.config.ReplicaSet {
+		b.WriteString(fmt.Sprintf("\n%s: %s\n", detailAttrStyle.Render("Replica set"), detailValStyle.Render(mongoReplicaSet+" (single node)")))
+	}
+	if exts := selectedItem.config.Extensions; len(exts) > 0 {
+		b.WriteString(fmt.Sprintf("\n%s: %s\n", detailAttrStyle.Render("Extensions"), detailValStyle.Render(strings.Join(exts, ", "))))
+	}
+	if selectedItem.setup != "" {
+		b.WriteString(fmt.Sprintf("%s: %s\n", detailAttrStyle.Render("Setup"), selectedItem.setup))
+	}
+
+	if w := selectedItem.config.Watch; w != nil {
+		b.WriteString(fmt.Sprintf("\n%s: %s\n", detailAttrStyle.Render("Watching"), detailValStyle.Render(w.Path)))
+		if selectedItem.migration != "" {
+			b.WriteString(fmt.Sprintf("%s: %s\n", detailAttrStyle.Render("Migration"), selectedItem.migration))
+		} else if !selectedItem.migratedAt.IsZero() {
+			b.WriteString(fmt.Sprintf("%s: %s\n", detailAttrStyle.Render("Migration"), successStyle.Render("✓ migrated "+timeDisplay.when(selectedItem.migratedAt, time.Now()))))
+		}
+	}
+
+	if !selectedItem.config.isProcess() && selectedItem.status != statusExternal {
+		if command, err := dockerRunCommand(selectedItem.config); err == nil {
+			b.WriteString(fmt.Sprintf("\n%s:%s\n%s\n", detailAttrStyle.Render("Docker Command"), m.copyNotice(copyCommand), detailValStyle.Width(max(m.list.Width(), 40)).Render(command)))
+		}
+	}
+
+	var tasks []string
+	for _, t := range m.tasks {
+		if t.config.Service == selectedItem.config.Name {
+			tasks = append(tasks, fmt.Sprintf("%s (every %s): %s", detailValStyle.Render(t.config.Name), t.config.Every, t.statusLine()))
+		}
+	}
+	if len(tasks) > 0 {
+		b.WriteString(fmt.Sprintf("\n%s:\n%s\n", detailAttrStyle.Render("Tasks"), strings.Join(tasks, "\n")))
+	}
+	b.WriteString(m.renderTools())
 	return b.String()
 }
 
@@ -331,18 +1218,45 @@ func (m model) renderFullHelpView() string {
 	b.WriteString("It reads a `plate.config.json` file to provision the services you need.\n\n")
 
 	b.WriteString(detailTitleStyle.Render("In-App Commands") + "\n")
-	b.WriteString(fmt.Sprintf("%s: Navigate the list of services.\n", detailAttrStyle.Render("↑/↓")))
-	b.WriteString(fmt.Sprintf("%s: Show/hide this help screen.\n", detailAttrStyle.Render("h")))
-	b.WriteString(fmt.Sprintf("%s: Stop a running service.\n", detailAttrStyle.Render("s")))
-	b.WriteString(fmt.Sprintf("%s: Boot/start a stopped service.\n", detailAttrStyle.Render("b")))
-	b.WriteString(fmt.Sprintf("%s: Copy connection string for a running service.\n", detailAttrStyle.Render("c")))
-	b.WriteString(fmt.Sprintf("%s: Reset a service (stops, removes, and recreates it).\n", detailAttrStyle.Render("r")))
-	b.WriteString(fmt.Sprintf("%s: Delete a service (stops and removes its container).\n", detailAttrStyle.Render("d")))
-	b.WriteString(fmt.Sprintf("%s: Quit the application (stops running containers).\n\n", detailAttrStyle.Render("q/ctrl+c")))
+	b.WriteString(helpScreenText(m.readOnly) + "\n")
+	if m.readOnly {
+		b.WriteString(helpStyle.Render("Read-only mode: actions that change containers are disabled.") + "\n\n")
+	}
 
 	b.WriteString(detailTitleStyle.Render("CLI Commands") + "\n")
 	b.WriteString(fmt.Sprintf("%s: Start the TUI.\n", detailAttrStyle.Render("plate")))
+	b.WriteString(fmt.Sprintf("%s: Start the TUI with the onboarding tour.\n", detailAttrStyle.Render("plate tour")))
 	b.WriteString(fmt.Sprintf("%s: Create a default config file.\n", detailAttrStyle.Render("plate init")))
+	b.WriteString(fmt.Sprintf("%s: Append a service from a recipe on a free port.\n", detailAttrStyle.Render("plate add")))
+	b.WriteString(fmt.Sprintf("%s: Pick a service's version from its image's tags.\n", detailAttrStyle.Render("plate tags")))
+	b.WriteString(fmt.Sprintf("%s: Print the config vs. container diff.\n", detailAttrStyle.Render("plate diff")))
+	b.WriteString(fmt.Sprintf("%s: Converge containers to the config.\n", detailAttrStyle.Render("plate apply")))
+	b.WriteString(fmt.Sprintf("%s: Stop every running service.\n", detailAttrStyle.Render("plate down")))
+	b.WriteString(fmt.Sprintf("%s: Download every image ahead of time.\n", detailAttrStyle.Render("plate pull")))
+	b.WriteString(fmt.Sprintf("%s: Load an image archive from 'plate export images'.\n", detailAttrStyle.Render("plate load images")))
+	b.WriteString(fmt.Sprintf("%s: Make the running TUI apply changes to the config.\n", detailAttrStyle.Render("plate reload")))
+	b.WriteString(fmt.Sprintf("%s: Show the environment fingerprint and service states.\n", detailAttrStyle.Render("plate status")))
+	b.WriteString(fmt.Sprintf("%s: Manage an existing container as a service.\n", detailAttrStyle.Render("plate adopt")))
+	b.WriteString(fmt.Sprintf("%s: Print the config with local overrides merged in.\n", detailAttrStyle.Render("plate config show --effective")))
+	b.WriteString(fmt.Sprintf("%s: Upgrade the config to the current schema version.\n", detailAttrStyle.Render("plate config migrate")))
+	b.WriteString(fmt.Sprintf("%s: Bundle the environment for a teammate.\n", detailAttrStyle.Render("plate share")))
+	b.WriteString(fmt.Sprintf("%s: Save, and later restore, the data of every service.\n", detailAttrStyle.Render("plate snapshot")))
+	b.WriteString(fmt.Sprintf("%s: Start a throwaway copy of the services that expires.\n", detailAttrStyle.Render("plate up --ephemeral")))
+	b.WriteString(fmt.Sprintf("%s: Warn after a checkout or pull that changed the config.\n", detailAttrStyle.Render("plate hooks install")))
+	b.WriteString(fmt.Sprintf("%s: Show or forget your answers to the config's prompts.\n", detailAttrStyle.Render("plate prompts")))
+	b.WriteString(fmt.Sprintf("%s: Generate config for other tools (devcontainer, gha, procfile, mprocs, tableplus, dbeaver) or an image archive (images).\n", detailAttrStyle.Render("plate export")))
+	b.WriteString(fmt.Sprintf("%s: Print or export a service's logs.\n", detailAttrStyle.Render("plate logs")))
+	b.WriteString(fmt.Sprintf("%s: Diagnose (and with --fix, repair) the environment.\n", detailAttrStyle.Render("plate doctor")))
+	b.WriteString(fmt.Sprintf("%s: Print a one-line summary for status bars.\n", detailAttrStyle.Render("plate statusline")))
+	b.WriteString(fmt.Sprintf("%s: Find port conflicts and remap them in the config.\n", detailAttrStyle.Render("plate ports")))
+	b.WriteString(fmt.Sprintf("%s: Issue local certificates and trust the local CA.\n", detailAttrStyle.Render("plate ca")))
+	b.WriteString(fmt.Sprintf("%s: Print the services' connection strings as environment variables.\n", detailAttrStyle.Render("plate env")))
+	b.WriteString(fmt.Sprintf("%s: Load them with direnv whenever you enter the project directory.\n", detailAttrStyle.Render("plate direnv")))
+	b.WriteString(fmt.Sprintf("%s: Render a template, such as a database.yml, with the connection details.\n", detailAttrStyle.Render("plate render")))
+	b.WriteString(fmt.Sprintf("%s: Report local usage stats.\n", detailAttrStyle.Render("plate stats")))
+	b.WriteString(fmt.Sprintf("%s: Benchmark environment startup.\n", detailAttrStyle.Render("plate bench")))
+	b.WriteString(fmt.Sprintf("%s: Bundle redacted diagnostics to attach to an issue.\n", detailAttrStyle.Render("plate bug-report")))
+	b.WriteString(fmt.Sprintf("%s: Print the version and build details.\n", detailAttrStyle.Render("plate version")))
 	b.WriteString(fmt.Sprintf("%s: Show command-line help.\n\n", detailAttrStyle.Render("plate help")))
 
 	b.WriteString(helpStyle.Render("Press 'h', 'q', or 'esc' to return."))
@@ -350,7 +1264,58 @@ func (m model) renderFullHelpView() string {
 	return docStyle.Render(b.String())
 }
 
+func (m model) renderDiffView() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Config Diff"))
+	b.WriteString("\n\n")
+	switch {
+	case m.diff == nil:
+		b.WriteString(fmt.Sprintf("%s Comparing config with containers...", m.spinner.View()))
+	case m.diff.err != nil:
+		b.WriteString(errorStyle.Render(m.diff.err.Error()))
+	default:
+		b.WriteString(renderDiff(m.diff.changes))
+	}
+	b.WriteString("\n\n")
+	b.WriteString(helpStyle.Render("Press 'D', 'q', or 'esc' to return."))
+	return docStyle.Render(b.String())
+}
+
+// renderInlineView draws one line per service plus a short key hint, small
+// enough to live in a corner split of tmux.
+func (m model) renderInlineView() string {
+	var b strings.Builder
+	title := "plate"
+	if m.readOnly {
+		title += " (read-only)"
+	}
+	b.WriteString(detailAttrStyle.Render(title) + "\n")
+	for i, itm := range m.list.Items() {
+		row := itm.(list.DefaultItem)
+		cursor := "  "
+		if i == m.list.Index() {
+			cursor = lipgloss.NewStyle().Foreground(katistixOrange).Render("› ")
+		}
+		line := fmt.Sprintf("%s%s  %s", cursor, row.Title(), row.Description())
+		if it, ok := itm.(item); ok && it.status == statusRunning && it.connectionString != "" {
+			line += "  " + detailValStyle.Render(it.connectionString)
+		}
+		b.WriteString(line + "\n")
+	}
+	hint := "↑/↓ select • h help • q quit"
+	for _, target := range []string{copyURL, copyAll} {
+		if notice := m.copyNotice(target); notice != "" {
+			hint = strings.TrimSpace(notice) + " " + hint
+		}
+	}
+	b.WriteString(helpStyle.Render(hint))
+	return b.String()
+}
+
 func (m model) renderHelpView() string {
-	helpText := "↑/↓: navigate • h: help • q: quit • s: stop • b: boot • r: reset • d: delete • c: copy"
-	return helpStyle.Render("\n" + helpText)
+	bar := helpBarText(m.readOnly)
+	if notice := m.copyNotice(copyAll); notice != "" {
+		bar = strings.TrimSpace(notice) + " " + bar
+	}
+	return helpStyle.Render(m.fingerprint + "\n" + bar)
 }
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestInlineView(t *testing.T) {
+	t.Chdir(t.TempDir())
+	cfg := PlateConfig{Project: "shop", Services: []ServiceConfig{
+		{Type: "redis", Name: "cache", Version: "7", Port: 6380},
+		{Type: "postgres", Name: "auth-db", Version: "16", Port: 5433, group: "auth"},
+		{Type: "redis", Name: "auth-sessions", Version: "7", Port: 6381, group: "auth"},
+	}}
+	m := initialModel(cfg, false)
+	m.inline = true
+	running := m.items[0].(item)
+	running.status = statusRunning
+	running.connectionString = "redis://localhost:6380"
+	m.items[0] = running
+	m.list.SetItems(m.visibleItems())
+
+	view := m.View()
+	lines := strings.Split(view, "\n")
+	if len(lines) != 6 {
+		t.Fatalf("Expected a title, 4 rows and a hint, got %d lines:\n%s", len(lines), view)
+	}
+	expected := []string{
+		"plate",
+		"cache",
+		"📚 auth (2)",
+		"auth-db",
+		"auth-sessions",
+		"q quit",
+	}
+	for i, want := range expected {
+		if !strings.Contains(lines[i], want) {
+			t.Errorf("Expected line %d to contain %q, got %q", i, want, lines[i])
+		}
+	}
+	if !strings.Contains(lines[1], "› ") || !strings.Contains(lines[1], "redis://localhost:6380") {
+		t.Errorf("Expected the selected running service to show its URL, got %q", lines[1])
+	}
+	if strings.Contains(lines[3], "postgres://") {
+		t.Errorf("Expected a stopped service not to show a URL, got %q", lines[3])
+	}
+	if strings.Contains(view, "╭") || strings.Contains(view, "│") {
+		t.Errorf("Expected no detail pane in inline mode, got:\n%s", view)
+	}
+
+	m.collapsed["auth"] = true
+	m.list.SetItems(m.visibleItems())
+	if view := m.View(); strings.Contains(view, "auth-db") || !strings.Contains(view, "▸ 📚 auth (2)") {
+		t.Errorf("Expected a collapsed stack to show only its header, got:\n%s", view)
+	}
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// --- MONGODB REPLICA SET ---
+
+// mongoReplicaSet is the name of the single-node replica set.
+const mongoReplicaSet = "rs0"
+
+// mongoInitiateScript initiates the replica set unless it already is, and
+// prints whether this node is the writable primary.
+var mongoInitiateScript = fmt.Sprintf(`try { rs.status() } catch (e) { rs.initiate({_id: %q, members: [{_id: 0, host: "localhost:27017"}]}) }
+print(db.hello().isWritablePrimary)`, mongoReplicaSet)
+
+// initiateReplicaSet initiates the service's replica set and waits until
+// the node was elected primary, so the first write doesn't fail.
+func initiateReplicaSet(containerID string, poll time.Duration) error {
+	deadline := time.Now().Add(readyTimeout)
+	for {
+		output, err := backend.exec(containerID, nil, "mongosh", "--quiet", "--eval", mongoInitiateScript)
+		out := strings.TrimSpace(string(output))
+		if err == nil && lastLine(out) == "true" {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			if err != nil && out != "" {
+				return fmt.Errorf("%s", lastLine(out))
+			}
+			return fmt.Errorf("no primary after %s", readyTimeout)
+		}
+		time.Sleep(poll)
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// --- MYSQL SETTINGS ---
+
+// MySQLConfig tunes the server settings apps most often trip over, so
+// nobody has to maintain a my.cnf for them.
+type MySQLConfig struct {
+	// CharacterSet and Collation are the server defaults, e.g. "utf8mb4"
+	// and "utf8mb4_unicode_ci".
+	CharacterSet string `json:"characterSet,omitempty"`
+	Collation    string `json:"collation,omitempty"`
+	// SQLMode replaces the default sql_mode, e.g. "" to turn off strict
+	// mode. Leave it unset to keep MySQL's default.
+	SQLMode *string `json:"sqlMode,omitempty"`
+	// TimeZone is the default time zone, as an offset like "+00:00" or a
+	// name like "Europe/Berlin". Names set the container's TZ instead, since
+	// mysqld refuses them before the image has loaded its time zone tables.
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// mysqlArgs returns the mysqld flags for the settings.
+func mysqlArgs(cfg *MySQLConfig) []string {
+	if cfg == nil {
+		return nil
+	}
+	var args []string
+	if cfg.CharacterSet != "" {
+		args = append(args, "--character-set-server="+cfg.CharacterSet)
+	}
+	if cfg.Collation != "" {
+		args = append(args, "--collation-server="+cfg.Collation)
+	}
+	if cfg.SQLMode != nil {
+		args = append(args, "--sql-mode="+*cfg.SQLMode)
+	}
+	if isTimeZoneOffset(cfg.TimeZone) {
+		args = append(args, "--default-time-zone="+cfg.TimeZone)
+	}
+	return args
+}
+
+// mysqlEnv returns the environment for the settings.
+func mysqlEnv(cfg *MySQLConfig) []string {
+	if cfg == nil || cfg.TimeZone == "" || isTimeZoneOffset(cfg.TimeZone) {
+		return nil
+	}
+	return []string{"TZ=" + cfg.TimeZone}
+}
+
+func isTimeZoneOffset(tz string) bool {
+	return strings.HasPrefix(tz, "+") || strings.HasPrefix(tz, "-")
+}
+
+// validateMySQL checks the MySQL settings of every service.
+func validateMySQL(cfg PlateConfig) error {
+	for _, svc := range cfg.Services {
+		m := svc.MySQL
+		if m == nil {
+			continue
+		}
+		if svc.Type != "mysql" {
+			return fmt.Errorf("service %s: mysql settings are supported for mysql, not %s", svc.Name, svc.Type)
+		}
+		if m.Collation != "" && m.CharacterSet != "" && !strings.HasPrefix(m.Collation, m.CharacterSet+"_") {
+			return fmt.Errorf("service %s: collation %s doesn't belong to character set %s", svc.Name, m.Collation, m.CharacterSet)
+		}
+		for _, v := range []string{m.CharacterSet, m.Collation, m.TimeZone} {
+			if strings.ContainsAny(v, " \t\n'\"") {
+				return fmt.Errorf("service %s: invalid mysql setting '%s'", svc.Name, v)
+			}
+		}
+	}
+	return nil
+}
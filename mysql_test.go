@@ -0,0 +1,71 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMySQLSettings(t *testing.T) {
+	empty := ""
+	tests := []struct {
+		name         string
+		cfg          *MySQLConfig
+		expectedArgs string
+		expectedEnv  string
+	}{
+		{"none", nil, "", ""},
+		{"charset", &MySQLConfig{CharacterSet: "utf8mb4", Collation: "utf8mb4_unicode_ci"}, "--character-set-server=utf8mb4 --collation-server=utf8mb4_unicode_ci", ""},
+		{"relaxed sql mode", &MySQLConfig{SQLMode: &empty}, "--sql-mode=", ""},
+		{"offset", &MySQLConfig{TimeZone: "+00:00"}, "--default-time-zone=+00:00", ""},
+		{"named zone", &MySQLConfig{TimeZone: "Europe/Berlin"}, "", "TZ=Europe/Berlin"},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(mysqlArgs(tt.cfg), " "); got != tt.expectedArgs {
+			t.Errorf("%s: Expected args %q, got %q", tt.name, tt.expectedArgs, got)
+		}
+		if got := strings.Join(mysqlEnv(tt.cfg), " "); got != tt.expectedEnv {
+			t.Errorf("%s: Expected env %q, got %q", tt.name, tt.expectedEnv, got)
+		}
+	}
+}
+
+func TestMySQLRunArgs(t *testing.T) {
+	svc := ServiceConfig{Type: "mysql", Name: "db", Version: "8", Port: 3306, MySQL: &MySQLConfig{CharacterSet: "utf8mb4"}}
+	_, args, err := getDockerRunArgs(svc, "test-mysql")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := strings.Join(args[len(args)-2:], " "); got != "mysql:8 --character-set-server=utf8mb4" {
+		t.Errorf("Expected the flags after the image, got %v", args)
+	}
+}
+
+func TestValidateMySQL(t *testing.T) {
+	tests := []struct {
+		name    string
+		svc     ServiceConfig
+		wantErr bool
+	}{
+		{"valid", ServiceConfig{Type: "mysql", Name: "db", MySQL: &MySQLConfig{CharacterSet: "utf8mb4", Collation: "utf8mb4_0900_ai_ci"}}, false},
+		{"not mysql", ServiceConfig{Type: "postgres", Name: "db", MySQL: &MySQLConfig{CharacterSet: "utf8mb4"}}, true},
+		{"mismatched collation", ServiceConfig{Type: "mysql", Name: "db", MySQL: &MySQLConfig{CharacterSet: "utf8mb4", Collation: "latin1_swedish_ci"}}, true},
+		{"quotes", ServiceConfig{Type: "mysql", Name: "db", MySQL: &MySQLConfig{TimeZone: "'UTC'"}}, true},
+	}
+	for _, tt := range tests {
+		err := validateMySQL(PlateConfig{Services: []ServiceConfig{tt.svc}})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: Expected error %v, got %v", tt.name, tt.wantErr, err)
+		}
+	}
+}
+
+func TestMySQLDevcontainerCommand(t *testing.T) {
+	cfg := PlateConfig{Project: "demo", Services: []ServiceConfig{{Type: "mysql", Name: "db", Version: "8", Port: 3306, MySQL: &MySQLConfig{SQLMode: new(string)}}}}
+	out, err := renderDevcontainerCompose(cfg)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.Contains(string(out), "    command:\n      - \"--sql-mode=\"\n") {
+		t.Errorf("Expected the flags as the compose command, got:\n%s", out)
+	}
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/glamour"
+)
+
+// --- SERVICE NOTES ---
+
+// notesKey identifies a rendering of a service's notes.
+type notesKey struct {
+	notes string
+	width int
+}
+
+// renderedNotes caches renderNotes, since the detail pane is redrawn on
+// every update and glamour builds a renderer per call.
+var renderedNotes = map[notesKey]string{}
+
+// renderNotes renders a service's markdown notes wrapped to width. Notes
+// glamour can't render are shown as they are.
+func renderNotes(notes string, width int) string {
+	key := notesKey{notes, width}
+	if out, ok := renderedNotes[key]; ok {
+		return out
+	}
+	out := strings.TrimSpace(notes)
+	r, err := glamour.NewTermRenderer(glamour.WithStandardStyle("dark"), glamour.WithWordWrap(width))
+	if err == nil {
+		if rendered, err := r.Render(notes); err == nil {
+			out = strings.Trim(rendered, "\n")
+		}
+	}
+	renderedNotes[key] = out
+	return out
+}
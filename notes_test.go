@@ -0,0 +1,23 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderNotes(t *testing.T) {
+	notes := "Log in as **admin**.\n\n- seeded with `orders`\n- 10k rows"
+	out := renderNotes(notes, 40)
+	if strings.Contains(out, "**") || !strings.Contains(out, "admin") {
+		t.Errorf("Expected the markdown to be rendered, got %q", out)
+	}
+	if !strings.Contains(out, "•") {
+		t.Errorf("Expected the list to get bullets, got %q", out)
+	}
+	if strings.HasPrefix(out, "\n") || strings.HasSuffix(out, "\n") {
+		t.Errorf("Expected no blank lines around the notes, got %q", out)
+	}
+	if _, ok := renderedNotes[notesKey{notes, 40}]; !ok {
+		t.Errorf("Expected the rendering to be cached")
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// --- LIFECYCLE NOTIFICATIONS ---
+
+// Lifecycle events that can be sent to a webhook.
+const (
+	eventServiceCrashed   = "service_crashed"
+	eventEnvironmentReady = "environment_ready"
+	eventResetPerformed   = "reset_performed"
+)
+
+// NotificationConfig configures where lifecycle events are sent.
+type NotificationConfig struct {
+	// Webhook is the URL events are POSTed to. $VARS are expanded so the
+	// URL's secret can stay out of the committed config.
+	Webhook string `json:"webhook"`
+	// Format is "json" (the default) or "slack" for a Slack-compatible
+	// {"text": ...} payload.
+	Format string `json:"format,omitempty"`
+	// Events limits which events are sent. Empty means all of them.
+	Events []string `json:"events,omitempty"`
+}
+
+// wants reports whether event should be sent.
+func (n NotificationConfig) wants(event string) bool {
+	if len(n.Events) == 0 {
+		return true
+	}
+	for _, e := range n.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// lifecycleEvent is the JSON body of a webhook call.
+type lifecycleEvent struct {
+	Event   string    `json:"event"`
+	Project string    `json:"project"`
+	Service string    `json:"service,omitempty"`
+	Message string    `json:"message"`
+	Time    time.Time `json:"time"`
+}
+
+// webhookPayload encodes an event in the configured format.
+func webhookPayload(format string, ev lifecycleEvent) ([]byte, error) {
+	switch format {
+	case "", "json":
+		return json.Marshal(ev)
+	case "slack":
+		return json.Marshal(map[string]string{"text": fmt.Sprintf("[%s] %s", ev.Project, ev.Message)})
+	default:
+		return nil, fmt.Errorf("unknown notification format %q", format)
+	}
+}
+
+// sendWebhookCmd POSTs an event to the webhook. Delivery is best effort:
+// a failing webhook must never get in the way of the environment.
+func sendWebhookCmd(n NotificationConfig, ev lifecycleEvent) tea.Cmd {
+	return func() tea.Msg {
+		body, err := webhookPayload(n.Format, ev)
+		if err != nil {
+			return nil
+		}
+		client := &http.Client{Timeout: 5 * time.Second}
+		resp, err := client.Post(os.ExpandEnv(n.Webhook), "application/json", bytes.NewReader(body))
+		if err == nil {
+			resp.Body.Close()
+		}
+		return nil
+	}
+}
+
+// lifecycleEvents compares the service list before and after an update and
+// returns the events the transition produced. ready is set once the
+// environment-ready event has been produced, so it is only sent once.
+func lifecycleEvents(project string, before, after []list.Item, ready bool) ([]lifecycleEvent, bool) {
+	var events []lifecycleEvent
+	now := time.Now()
+	allRunning := len(after) > 0
+	for i, itm := range after {
+		cur := itm.(item)
+		if cur.status != statusRunning {
+			allRunning = false
+		}
+		if i >= len(before) {
+			continue
+		}
+		prev := before[i].(item)
+		if prev.status == cur.status {
+			continue
+		}
+		switch {
+		case cur.status == statusError, cur.status == statusCrashLooping:
+			events = append(events, lifecycleEvent{Event: eventServiceCrashed, Project: project, Service: cur.config.Name, Message: fmt.Sprintf("%s failed: %s", cur.config.Name, cur.statusText), Time: now})
+		case prev.status == statusResetting:
+			events = append(events, lifecycleEvent{Event: eventResetPerformed, Project: project, Service: cur.config.Name, Message: fmt.Sprintf("%s was reset", cur.config.Name), Time: now})
+		}
+	}
+	if allRunning && !ready {
+		events = append(events, lifecycleEvent{Event: eventEnvironmentReady, Project: project, Message: fmt.Sprintf("all %d services are running", len(after)), Time: now})
+		ready = true
+	}
+	return events, ready
+}
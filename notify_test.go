@@ -0,0 +1,60 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+func TestLifecycleEvents(t *testing.T) {
+	db := ServiceConfig{Type: "postgres", Name: "main-db"}
+	cache := ServiceConfig{Type: "redis", Name: "cache"}
+	items := func(a, b status) []list.Item {
+		return []list.Item{item{config: db, status: a, statusText: "boom"}, item{config: cache, status: b}}
+	}
+
+	tests := []struct {
+		name     string
+		before   []list.Item
+		after    []list.Item
+		ready    bool
+		expected []string
+	}{
+		{"no change", items(statusRunning, statusStarting), items(statusRunning, statusStarting), false, nil},
+		{"crash", items(statusRunning, statusStarting), items(statusError, statusStarting), false, []string{eventServiceCrashed}},
+		{"reset", items(statusResetting, statusStarting), items(statusChecking, statusStarting), false, []string{eventResetPerformed}},
+		{"ready", items(statusRunning, statusStarting), items(statusRunning, statusRunning), false, []string{eventEnvironmentReady}},
+		{"ready only once", items(statusRunning, statusStopped), items(statusRunning, statusRunning), true, nil},
+	}
+	for _, tt := range tests {
+		events, ready := lifecycleEvents("shop", tt.before, tt.after, tt.ready)
+		if len(events) != len(tt.expected) {
+			t.Errorf("%s: Expected %d events, got %d", tt.name, len(tt.expected), len(events))
+			continue
+		}
+		for i, ev := range events {
+			if ev.Event != tt.expected[i] {
+				t.Errorf("%s: Expected event %s, got %s", tt.name, tt.expected[i], ev.Event)
+			}
+		}
+		if tt.name == "ready" && !ready {
+			t.Errorf("%s: Expected ready to be set", tt.name)
+		}
+	}
+}
+
+func TestWebhookPayload(t *testing.T) {
+	ev := lifecycleEvent{Event: eventServiceCrashed, Project: "shop", Service: "main-db", Message: "main-db failed: boom"}
+
+	got, err := webhookPayload("slack", ev)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if expected := `{"text":"[shop] main-db failed: boom"}`; string(got) != expected {
+		t.Errorf("Expected %s, got %s", expected, got)
+	}
+
+	if _, err := webhookPayload("teams", ev); err == nil {
+		t.Errorf("Expected an error for an unknown format")
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+)
+
+// --- OBSERVABILITY STACK ---
+
+// The "observability" service type runs an OTLP endpoint together with a
+// trace UI in one container. Its port is the OTLP/HTTP endpoint, and
+// OTLP/gRPC is published one port below it.
+const (
+	otlpHTTPPort = 4318
+	otlpGRPCPort = 4317
+	jaegerUIPort = 16686
+	grafanaPort  = 3000
+)
+
+// observabilitySpec describes the container of an "observability" service:
+// Jaeger's all-in-one image by default, or Grafana's LGTM image (an
+// OpenTelemetry collector with Tempo, Loki, Prometheus and a Grafana that has
+// their datasources provisioned) when Grafana is set.
+func observabilitySpec(config ServiceConfig) serviceSpec {
+	spec := serviceSpec{
+		Image:         "jaegertracing/all-in-one",
+		ContainerPort: otlpHTTPPort,
+		Env:           []string{"COLLECTOR_OTLP_ENABLED=true"},
+		UIPort:        jaegerUIPort,
+	}
+	if config.Grafana {
+		spec = serviceSpec{Image: "grafana/otel-lgtm", ContainerPort: otlpHTTPPort, DataDir: "/data", UIPort: grafanaPort}
+	}
+	uiHostPort := config.UIPort
+	if uiHostPort == 0 {
+		uiHostPort = spec.UIPort
+	}
+	spec.ExtraPorts = map[int]int{otlpGRPCPort: config.Port - 1, spec.UIPort: uiHostPort}
+	return spec
+}
+
+// serviceUIURL returns the address of the service's web UI, or "" if it has
+// none.
+func serviceUIURL(config ServiceConfig) string {
+	spec, err := getServiceSpec(config)
+	if err != nil || spec.UIPort == 0 {
+		return ""
+	}
+	port := config.Port
+	if spec.UIPort != spec.ContainerPort {
+		port = spec.ExtraPorts[spec.UIPort]
+	}
+	return "http://" + net.JoinHostPort(serviceHost(config), strconv.Itoa(port))
+}
+
+// otlpEnv returns the standard OpenTelemetry exporter variables pointing at
+// the service's OTLP/HTTP endpoint.
+func otlpEnv(config ServiceConfig) []string {
+	connStr, _ := getConnectionString(config)
+	return []string{
+		fmt.Sprintf("OTEL_EXPORTER_OTLP_ENDPOINT=%s", connStr),
+		"OTEL_EXPORTER_OTLP_PROTOCOL=http/protobuf",
+	}
+}
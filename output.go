@@ -0,0 +1,82 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+)
+
+// --- OUTPUT LEVELS ---
+
+type outputLevel int
+
+const (
+	outputNormal outputLevel = iota
+	// outputQuiet prints only errors, on stderr, and the results a command
+	// is run for, in a form scripts can parse.
+	outputQuiet
+	// outputVerbose also prints every docker command Plate runs, on stderr.
+	outputVerbose
+)
+
+// output is how much the CLI command of this run prints. The TUI ignores it.
+var output = outputNormal
+
+// addOutputFlags registers -q/--quiet and -v/--verbose, which set output.
+// -q=false and -v=false turn their level back off.
+func addOutputFlags(fs *flag.FlagSet) {
+	level := func(l outputLevel) func(string) error {
+		return func(value string) error {
+			on, err := strconv.ParseBool(value)
+			if err != nil {
+				return err
+			}
+			if on {
+				output = l
+			} else if output == l {
+				output = outputNormal
+			}
+			return nil
+		}
+	}
+	fs.BoolFunc("quiet", "only print errors, and results in a form scripts can parse", level(outputQuiet))
+	fs.BoolFunc("q", "short for --quiet", level(outputQuiet))
+	fs.BoolFunc("verbose", "print every docker command that runs", level(outputVerbose))
+	fs.BoolFunc("v", "short for --verbose", level(outputVerbose))
+}
+
+// progressOut returns where a command reports its progress.
+func progressOut() io.Writer {
+	if output == outputQuiet {
+		return io.Discard
+	}
+	return os.Stdout
+}
+
+// noticeOut returns where a command reports what Plate does on the side,
+// such as starting docker: stderr, so stdout stays the command's own output,
+// or nowhere when quiet.
+func noticeOut() io.Writer {
+	if output == outputQuiet {
+		return io.Discard
+	}
+	return os.Stderr
+}
+
+// reportFailure prints that label failed to out, or to stderr when the
+// output is quiet, since out then discards everything.
+func reportFailure(out io.Writer, label string, err error) {
+	if output == outputQuiet {
+		out = os.Stderr
+	}
+	fmt.Fprintf(out, "%s %s: %v\n", errorStyle.Render("✗"), label, err)
+}
+
+// logDockerCommand prints a docker command about to run, when verbose.
+func logDockerCommand(args []string) {
+	if output == outputVerbose {
+		fmt.Fprintln(os.Stderr, helpStyle.Render("+ "+dockerCommandLine(args...)))
+	}
+}
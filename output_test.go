@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"testing"
+)
+
+func TestOutputFlags(t *testing.T) {
+	t.Cleanup(func() { output = outputNormal })
+	tests := []struct {
+		args     []string
+		expected outputLevel
+	}{
+		{nil, outputNormal},
+		{[]string{"-q"}, outputQuiet},
+		{[]string{"--quiet"}, outputQuiet},
+		{[]string{"-v"}, outputVerbose},
+		{[]string{"--verbose", "--config", "x.json"}, outputVerbose},
+		{[]string{"-q=false"}, outputNormal},
+		{[]string{"--verbose=false"}, outputNormal},
+		{[]string{"-q", "-q=false"}, outputNormal},
+		{[]string{"-v", "-q=false"}, outputVerbose},
+	}
+	for _, tt := range tests {
+		output = outputNormal
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		addConfigFlag(fs)
+		addOutputFlags(fs)
+		if err := fs.Parse(tt.args); err != nil {
+			t.Fatalf("%v: Expected the flags to parse, got %v", tt.args, err)
+		}
+		if output != tt.expected {
+			t.Errorf("%v: Expected output level %d, got %d", tt.args, tt.expected, output)
+		}
+	}
+
+	output = outputQuiet
+	if progressOut() != io.Discard || noticeOut() != io.Discard {
+		t.Errorf("Expected quiet progress and notices to be discarded")
+	}
+	output = outputNormal
+	if noticeOut() != os.Stderr {
+		t.Errorf("Expected notices on stderr, so stdout stays the command's output")
+	}
+	var b bytes.Buffer
+	reportFailure(&b, "cache", errors.New("port is taken"))
+	if b.String() != "✗ cache: port is taken\n" {
+		t.Errorf("Expected the failure on out, got %q", b.String())
+	}
+}
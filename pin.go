@@ -0,0 +1,44 @@
+package main
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// --- PINNED SERVICES ---
+
+// isPinned reports whether a service is pinned: by the toggle stored in the
+// state, or else by its config.
+func isPinned(svc ServiceConfig, st plateState) bool {
+	if pinned, ok := st.Pinned[svc.Name]; ok {
+		return pinned
+	}
+	return svc.Pinned
+}
+
+// savePin stores a pin toggled in the TUI. A toggle back to what the config
+// says is dropped, so the config decides again.
+func savePin(svc ServiceConfig, pinned bool) error {
+	return updateState(func(st *plateState) {
+		if pinned == svc.Pinned {
+			delete(st.Pinned, svc.Name)
+			return
+		}
+		if st.Pinned == nil {
+			st.Pinned = map[string]bool{}
+		}
+		st.Pinned[svc.Name] = pinned
+	})
+}
+
+// savePinCmd runs savePin. The pin applies to this session either way.
+func savePinCmd(svc ServiceConfig, pinned bool) tea.Cmd {
+	return func() tea.Msg {
+		err := savePin(svc, pinned)
+		action := "unpin"
+		if pinned {
+			action = "pin"
+		}
+		recordAction(action, svc.Name, "", "", err)
+		return nil
+	}
+}
@@ -0,0 +1,32 @@
+package main
+
+import "testing"
+
+func TestPins(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	db := ServiceConfig{Name: "main-db", Pinned: true}
+	cache := ServiceConfig{Name: "cache"}
+	st, _ := loadState()
+	if !isPinned(db, st) || isPinned(cache, st) {
+		t.Errorf("Expected the config's pins without toggles")
+	}
+
+	if err := savePin(db, false); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := savePin(cache, true); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	st, _ = loadState()
+	if isPinned(db, st) || !isPinned(cache, st) {
+		t.Errorf("Expected the toggles to override the config, got %v", st.Pinned)
+	}
+
+	// Toggling back to the config's value drops the override.
+	savePin(db, true)
+	st, _ = loadState()
+	if _, ok := st.Pinned["main-db"]; ok || !isPinned(db, st) {
+		t.Errorf("Expected the config to decide again, got %v", st.Pinned)
+	}
+}
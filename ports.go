@@ -0,0 +1,196 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// --- PORT REMAPPING ---
+
+// portChange moves a service to a free host port.
+type portChange struct {
+	service string
+	from    int
+	to      int
+	reason  string
+}
+
+// planPortRemap finds services whose host port is used twice in the config,
+// is already bound on the host, or is below lowest (the ports a rootless
+// daemon can publish), and gives each the next port up that is free. Services
+// keep their order, so the first one to claim a port keeps it.
+func planPortRemap(services []ServiceConfig, inUse func(port int) bool, lowest int) []portChange {
+	claimed := map[int]string{}
+	for _, svc := range services {
+		if svc.Port != 0 {
+			if _, ok := claimed[svc.Port]; !ok {
+				claimed[svc.Port] = svc.Name
+			}
+		}
+	}
+
+	var changes []portChange
+	for _, svc := range services {
+		if svc.Port == 0 {
+			continue
+		}
+		reason, from := "", svc.Port+1
+		if owner := claimed[svc.Port]; owner != svc.Name {
+			reason = "also used by " + owner
+		} else if svc.Port < lowest {
+			reason = "privileged, which rootless docker can't publish"
+			from = max(privilegedPortAlternative(svc.Port), lowest)
+		} else if inUse(svc.Port) {
+			reason = "already bound on this machine"
+		}
+		if reason == "" {
+			continue
+		}
+		to := nextFreePort(from, func(port int) bool {
+			_, taken := claimed[port]
+			return taken || inUse(port)
+		})
+		if to == 0 {
+			continue
+		}
+		claimed[to] = svc.Name
+		changes = append(changes, portChange{service: svc.Name, from: svc.Port, to: to, reason: reason})
+	}
+	return changes
+}
+
+// nextFreePort returns the first port from from up that isn't taken, or 0
+// if there is none.
+func nextFreePort(from int, taken func(port int) bool) int {
+	for port := from; port <= 65535; port++ {
+		if !taken(port) {
+			return port
+		}
+	}
+	return 0
+}
+
+// writePortChanges stores the new ports in the config file at path.
+func writePortChanges(path string, changes []portChange) error {
+	return editConfigServices(path, func(services []any) []any {
+		for _, c := range changes {
+			services = setServiceField(services, c.service, "port", c.to)
+		}
+		return services
+	})
+}
+
+// setServiceField sets a field of the named service in a plain JSON service
+// list, adding an entry with just the name and that field if the service
+// isn't in it (e.g. because it comes from a base config).
+func setServiceField(services []any, name, key string, value any) []any {
+	found := false
+	for _, s := range services {
+		if svc, ok := s.(map[string]any); ok && svc["name"] == name {
+			svc[key] = value
+			found = true
+		}
+	}
+	if !found {
+		services = append(services, map[string]any{"name": name, key: value})
+	}
+	return services
+}
+
+// editConfigServices rewrites the services of the config file at path. The
+// file is edited as plain JSON so fields Plate doesn't know survive.
+func editConfigServices(path string, edit func(services []any) []any) error {
+	return editConfig(path, func(raw map[string]any) {
+		services, _ := raw["services"].([]any)
+		raw["services"] = edit(services)
+	})
+}
+
+// editConfig rewrites the config file at path after edit changed its
+// top-level keys.
+func editConfig(path string, edit func(raw map[string]any)) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("could not parse '%s'. %v", path, err)
+	}
+	edit(raw)
+	return writeRawConfig(path, raw)
+}
+
+// writeRawConfig writes a config's top-level keys to path, indented.
+func writeRawConfig(path string, raw map[string]any) error {
+	data, err := json.MarshalIndent(raw, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// confirm asks a yes/no question on the terminal.
+func confirm(in io.Reader, question string) bool {
+	fmt.Printf("%s (y/n) ", question)
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+func handlePortsCmd(args []string) {
+	fs := flag.NewFlagSet("ports", flag.ExitOnError)
+	addConfigFlag(fs)
+	local := fs.Bool("local", false, "write the new ports to the local override file instead")
+	yes := fs.Bool("yes", false, "write the new ports without asking")
+	fs.Parse(args)
+	configPath := configPathArg(fs)
+	plateConfig := mustLoadConfig(configPath)
+
+	// Ports published by this project's own containers are bound by design.
+	own := map[int]bool{}
+	if containers, err := listPlateContainers(plateConfig.Project); err == nil {
+		for name, ctr := range serviceContainers(plateConfig, containers) {
+			for _, svc := range plateConfig.Services {
+				if svc.Name == name && ctr.managed() && ctr.State == "running" {
+					own[svc.Port] = true
+				}
+			}
+		}
+	}
+	inUse := func(port int) bool { return !own[port] && !portFree(port) }
+
+	changes := planPortRemap(plateConfig.enabledServices(), inUse, lowestPublishablePort(detectDaemonMode()))
+	if len(changes) == 0 {
+		fmt.Println(successStyle.Render("No port conflicts."))
+		return
+	}
+	for _, c := range changes {
+		fmt.Printf("%s %s: %d → %d (%s)\n", pendingStyle.Render("~"), c.service, c.from, c.to, c.reason)
+	}
+
+	target := configPath
+	if *local {
+		target = localConfigPath(configPath)
+	} else if !isFileSource(configPath) {
+		fmt.Println("Error: The config isn't a local file. Use --local to write the new ports to your local override file.")
+		os.Exit(1)
+	}
+	if !*yes && !confirm(os.Stdin, fmt.Sprintf("\nWrite these ports to %s?", target)) {
+		fmt.Println("Nothing changed.")
+		return
+	}
+	if _, err := os.Stat(target); *local && os.IsNotExist(err) {
+		os.WriteFile(target, []byte("{\"services\": []}\n"), 0644)
+	}
+	if err := writePortChanges(target, changes); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Updated %s. Run 'plate apply' to recreate the affected containers.\n", target)
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPlanPortRemap(t *testing.T) {
+	services := []ServiceConfig{
+		{Name: "main-db", Port: 5433},
+		{Name: "orders", Port: 5433},
+		{Name: "cache", Port: 6379},
+		{Name: "web", Port: 0},
+		{Name: "docs", Port: 5434},
+	}
+	bound := map[int]bool{6379: true, 6380: true}
+	inUse := func(port int) bool { return bound[port] }
+
+	changes := planPortRemap(services, inUse, 0)
+
+	expected := []portChange{
+		{service: "orders", from: 5433, to: 5435, reason: "also used by main-db"},
+		{service: "cache", from: 6379, to: 6381, reason: "already bound on this machine"},
+	}
+	if len(changes) != len(expected) {
+		t.Fatalf("Expected %d changes, got %d: %+v", len(expected), len(changes), changes)
+	}
+	for i, want := range expected {
+		if changes[i] != want {
+			t.Errorf("Expected %+v, got %+v", want, changes[i])
+		}
+	}
+}
+
+func TestWritePortChanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plate.config.json")
+	config := `{"extends": "base.json", "services": [{"type": "postgres", "name": "orders", "version": "16", "port": 5433, "custom": true}]}`
+	if err := os.WriteFile(path, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := writePortChanges(path, []portChange{{service: "orders", to: 5435}, {service: "cache", to: 6381}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	var raw struct {
+		Extends  string           `json:"extends"`
+		Services []map[string]any `json:"services"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatal(err)
+	}
+	if raw.Extends != "base.json" || len(raw.Services) != 2 {
+		t.Fatalf("Unexpected config: %s", data)
+	}
+	if raw.Services[0]["port"] != float64(5435) || raw.Services[0]["custom"] != true {
+		t.Errorf("Expected orders to move to 5435 and keep its fields, got %v", raw.Services[0])
+	}
+	if raw.Services[1]["name"] != "cache" || raw.Services[1]["port"] != float64(6381) || len(raw.Services[1]) != 2 {
+		t.Errorf("Expected a port-only override for cache, got %v", raw.Services[1])
+	}
+}
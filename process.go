@@ -0,0 +1,162 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// --- PROCESS SERVICES ---
+
+// processStopTimeout is how long a process gets to exit after being asked
+// to stop before it is killed.
+const processStopTimeout = 10 * time.Second
+
+// runningProcess is a process service started by the TUI.
+type runningProcess struct {
+	cmd      *exec.Cmd
+	done     chan struct{} // closed once the process has exited
+	err      error         // the exit error, valid after done is closed
+	injected []string      // the other services' variables it was started with
+}
+
+// processEnv returns the environment a process service runs with: Plate's
+// own environment, the injected variables of the other services, and the
+// service's env, sorted by key. Later entries win.
+func processEnv(config ServiceConfig, injected []string) []string {
+	env := append(os.Environ(), injected...)
+	for _, k := range sortedEnvKeys(config.Env) {
+		env = append(env, fmt.Sprintf("%s=%s", k, config.Env[k]))
+	}
+	return env
+}
+
+// startProcess runs a process service's command through the shell, with
+// injected added to its environment. Its output goes to the log hub, if
+// there is one.
+func startProcess(config ServiceConfig, injected []string, logs *logHub) (*runningProcess, error) {
+	if config.Command == "" {
+		return nil, fmt.Errorf("process service %s has no command", config.Name)
+	}
+	cmd := exec.Command("sh", "-c", config.Command)
+	cmd.Dir = config.Dir
+	cmd.Env = processEnv(config, injected)
+	if logs != nil {
+		cmd.Stdout = logs.writer(config.Name)
+		cmd.Stderr = logs.writer(config.Name)
+	}
+	setProcessGroup(cmd)
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	p := &runningProcess{cmd: cmd, done: make(chan struct{}), injected: injected}
+	go func() {
+		p.err = cmd.Wait()
+		close(p.done)
+	}()
+	return p, nil
+}
+
+// stop asks the process (and its children) to exit, killing it if it is
+// still running after processStopTimeout.
+func (p *runningProcess) stop() {
+	select {
+	case <-p.done:
+		return
+	default:
+	}
+	_ = terminateProcessGroup(p.cmd)
+	select {
+	case <-p.done:
+	case <-time.After(processStopTimeout):
+		_ = killProcessGroup(p.cmd)
+		<-p.done
+	}
+}
+
+// refreshProcessEnv restarts the running processes whose injected variables
+// are out of date, e.g. because a service came up on another port than the
+// config's.
+func (m model) refreshProcessEnv() tea.Cmd {
+	env := siblingEnv(m.items)
+	var cmds []tea.Cmd
+	for i, itm := range m.items {
+		it := itm.(item)
+		if it.process == nil || it.stopping || slices.Equal(it.process.injected, env) {
+			continue
+		}
+		it.stopping, it.restarting = true, true
+		cmds = append(cmds, m.setItem(i, it), stopProcessCmd(it.config, it.process))
+	}
+	return tea.Batch(cmds...)
+}
+
+// validateRestartWith checks that restartWith is only set on process
+// services, and names container services.
+func validateRestartWith(cfg PlateConfig) error {
+	containers := map[string]bool{}
+	for _, svc := range cfg.Services {
+		if !svc.isProcess() {
+			containers[svc.Name] = true
+		}
+	}
+	for _, svc := range cfg.Services {
+		if len(svc.RestartWith) == 0 {
+			continue
+		}
+		if !svc.isProcess() {
+			return fmt.Errorf("service %s: restartWith is supported for process services, not %s", svc.Name, svc.Type)
+		}
+		for _, name := range svc.RestartWith {
+			if !containers[name] {
+				return fmt.Errorf("service %s: restartWith names %s, which isn't a container service", svc.Name, name)
+			}
+		}
+	}
+	return nil
+}
+
+type dependencyReadyMsg struct {
+	service string
+}
+
+// dependencyReadyCmd reports once a service that came back accepts
+// connections again. It reports after readyTimeout at the latest, since its
+// dependents are better off restarted than left talking to the old container.
+func dependencyReadyCmd(service string, config ServiceConfig, containerID string, poll time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		_ = waitForService(config, containerID, poll)
+		return dependencyReadyMsg{service: service}
+	}
+}
+
+// hasDependents reports whether a running process restarts with the service.
+func (m model) hasDependents(name string) bool {
+	for _, itm := range m.items {
+		it := itm.(item)
+		if it.process != nil && slices.Contains(it.config.RestartWith, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// restartDependents restarts the running processes that restart with the
+// service.
+func (m model) restartDependents(name string) tea.Cmd {
+	var cmds []tea.Cmd
+	for i, itm := range m.items {
+		it := itm.(item)
+		if it.process == nil || it.stopping || !slices.Contains(it.config.RestartWith, name) {
+			continue
+		}
+		it.stopping, it.restarting = true, true
+		cmds = append(cmds, m.setItem(i, it), stopProcessCmd(it.config, it.process))
+	}
+	return tea.Batch(cmds...)
+}
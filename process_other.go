@@ -0,0 +1,27 @@
+//go:build !unix
+
+package main
+
+import (
+	"os/exec"
+)
+
+// setProcessGroup is a no-op; process groups are a unix concept.
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// terminateProcessGroup kills the process, since there is no portable way
+// to ask it to exit.
+func terminateProcessGroup(cmd *exec.Cmd) error {
+	return cmd.Process.Kill()
+}
+
+// killProcessGroup kills the process.
+func killProcessGroup(cmd *exec.Cmd) error {
+	return cmd.Process.Kill()
+}
+
+// processAlive reports whether a process with the given PID exists. Without
+// signal 0 this can't be checked cheaply, so any PID is assumed alive.
+func processAlive(pid int) bool {
+	return pid > 0
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestValidateRestartWith(t *testing.T) {
+	tests := []struct {
+		name        string
+		restartWith []string
+		typ         string
+		valid       bool
+	}{
+		{"container service", []string{"main-db"}, "process", true},
+		{"unknown service", []string{"nope"}, "process", false},
+		{"another process", []string{"worker"}, "process", false},
+		{"not a process", []string{"main-db"}, "redis", false},
+	}
+	for _, tt := range tests {
+		cfg := PlateConfig{Services: []ServiceConfig{
+			{Type: "postgres", Name: "main-db"},
+			{Type: "process", Name: "worker", Command: "true"},
+			{Type: tt.typ, Name: "web", RestartWith: tt.restartWith},
+		}}
+		if err := validateRestartWith(cfg); (err == nil) != tt.valid {
+			t.Errorf("%s: Expected valid=%v, got %v", tt.name, tt.valid, err)
+		}
+	}
+}
+
+func TestRestartDependents(t *testing.T) {
+	db := item{config: ServiceConfig{Type: "redis", Name: "cache"}, status: statusStarting}
+	web := item{config: ServiceConfig{Type: "process", Name: "web", RestartWith: []string{"cache"}}, status: statusRunning, index: 1}
+	other := item{config: ServiceConfig{Type: "process", Name: "other"}, status: statusRunning, index: 2}
+	m := model{items: []list.Item{db, web, other}}
+	// Both processes already have cache's variables.
+	for i := 1; i <= 2; i++ {
+		it := m.items[i].(item)
+		it.process = &runningProcess{injected: siblingEnv(m.items)}
+		m.items[i] = it
+	}
+
+	// The first start isn't a restart.
+	next, _ := m.update(containerStartedMsg{service: "cache", containerID: "abc"})
+	m = next.(model)
+	if m.items[0].(item).restartDeps {
+		t.Errorf("Expected no restart of dependents on the first start")
+	}
+
+	next, _ = m.update(containerStartedMsg{service: "cache", containerID: "def"})
+	m = next.(model)
+	if !m.items[0].(item).restartDeps || m.items[1].(item).restarting {
+		t.Errorf("Expected web to wait for cache to be ready")
+	}
+	next, _ = m.update(dependencyReadyMsg{service: "cache"})
+	m = next.(model)
+	if !m.items[1].(item).restarting {
+		t.Errorf("Expected web to restart once cache is ready")
+	}
+	if m.items[2].(item).restarting {
+		t.Errorf("Expected other, which doesn't restart with cache, to keep running")
+	}
+}
+
+func TestMessageForUnknownService(t *testing.T) {
+	db := item{config: ServiceConfig{Type: "postgres", Name: "db"}, status: statusStarting}
+	m := model{items: []list.Item{db}}
+	// A message for a service that isn't in the list, e.g. one that was
+	// reloaded away while its command ran, is dropped.
+	for _, msg := range []tea.Msg{
+		containerStartedMsg{service: "gone", containerID: "abc"},
+		containerStatusMsg{service: "gone", status: "running"},
+		processExitedMsg{service: "gone"},
+		dependencyReadyMsg{service: "gone"},
+	} {
+		next, cmd := m.update(msg)
+		if it := next.(model).items[0].(item); it.status != statusStarting || cmd != nil {
+			t.Errorf("%T: Expected the message to be dropped, got status %v", msg, it.status)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// --- MACHINE-READABLE PROGRESS ---
+
+// Phases of progressEvent, in the order a service goes through them.
+const (
+	phasePulling  = "pulling"
+	phaseBuilding = "building"
+	phaseStarting = "starting"
+	phaseHealthy  = "healthy"
+	phaseFailed   = "failed"
+	// phaseDone ends the stream once every service is healthy.
+	phaseDone = "done"
+)
+
+// progressEvent is one line of `--progress json` output.
+type progressEvent struct {
+	Time    time.Time `json:"time"`
+	Phase   string    `json:"phase"`
+	Service string    `json:"service,omitempty"`
+	Image   string    `json:"image,omitempty"`
+	// Percent is the share of the image's layers downloaded while pulling.
+	Percent *int   `json:"percent,omitempty"`
+	Error   string `json:"error,omitempty"`
+	// Env holds the connection strings, once done.
+	Env map[string]string `json:"env,omitempty"`
+}
+
+// progressEmitter writes progress events as NDJSON. A nil emitter drops
+// them, which is what the human-readable output uses.
+type progressEmitter struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func newProgressEmitter(w io.Writer) *progressEmitter {
+	return &progressEmitter{enc: json.NewEncoder(w)}
+}
+
+func (e *progressEmitter) emit(ev progressEvent) {
+	if e == nil {
+		return
+	}
+	ev.Time = time.Now().UTC()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.enc.Encode(ev)
+}
+
+// pullWithEvents pulls the service's image, reporting each change in the
+// share of its layers that were downloaded.
+func pullWithEvents(svc ServiceConfig, events *progressEmitter) error {
+	p := &pullState{image: imageName(svc), layers: map[string]bool{}}
+	last := -1
+	report := func(percent int) {
+		if percent != last {
+			last = percent
+			events.emit(progressEvent{Phase: phasePulling, Service: svc.Name, Image: p.image, Percent: &percent})
+		}
+	}
+	report(0)
+	err := pullWithProgress(p.image, func(line string) {
+		p.observe(line)
+		if _, _, layers, done := pullProgress([]*pullState{p}); layers > 0 {
+			report(done * 100 / layers)
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("could not pull %s: %w", p.image, err)
+	}
+	report(100)
+	return nil
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestProgressEmitter(t *testing.T) {
+	var nilEmitter *progressEmitter
+	nilEmitter.emit(progressEvent{Phase: phaseStarting}) // dropped, not a panic
+
+	var b bytes.Buffer
+	events := newProgressEmitter(&b)
+	zero := 0
+	events.emit(progressEvent{Phase: phasePulling, Service: "main-db", Image: "postgres:16", Percent: &zero})
+	events.emit(progressEvent{Phase: phaseHealthy, Service: "main-db"})
+	events.emit(progressEvent{Phase: phaseDone, Env: map[string]string{"PLATE_MAIN_DB_URL": "postgres://localhost:5433"}})
+
+	var got []map[string]any
+	scanner := bufio.NewScanner(&b)
+	for scanner.Scan() {
+		var ev map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &ev); err != nil {
+			t.Fatalf("Expected one JSON object per line, got %q: %v", scanner.Text(), err)
+		}
+		got = append(got, ev)
+	}
+	if len(got) != 3 {
+		t.Fatalf("Expected 3 events, got %d", len(got))
+	}
+	if got[0]["percent"] != float64(0) || got[0]["time"] == nil {
+		t.Errorf("Expected a timestamped pull at 0%%, got %v", got[0])
+	}
+	if _, ok := got[1]["percent"]; ok {
+		t.Errorf("Expected no percent outside of pulls, got %v", got[1])
+	}
+	if env, ok := got[2]["env"].(map[string]any); !ok || env["PLATE_MAIN_DB_URL"] == nil {
+		t.Errorf("Expected the connection strings when done, got %v", got[2])
+	}
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+)
+
+// --- PROMPTED VARIABLES ---
+
+// PromptConfig is a variable of a service that every developer picks for
+// themselves, such as how much data to seed. Plate asks for it the first
+// time the environment comes up, remembers the answer in the local state,
+// and sets it in the service's env.
+type PromptConfig struct {
+	Name    string `json:"name"`
+	Message string `json:"message,omitempty"`
+	// Default is used when the answer is left empty, and when Plate can't
+	// ask because it doesn't run in a terminal.
+	Default string `json:"default,omitempty"`
+	// Options, if set, are the only answers accepted.
+	Options []string `json:"options,omitempty"`
+}
+
+// pendingPrompt is a prompt that hasn't been answered yet.
+type pendingPrompt struct {
+	service string
+	prompt  PromptConfig
+}
+
+func validatePrompts(cfg PlateConfig) error {
+	for _, svc := range cfg.Services {
+		seen := map[string]bool{}
+		for _, p := range svc.Prompts {
+			if !envNamePattern.MatchString(p.Name) {
+				return fmt.Errorf("service %s: prompt %q must be a variable name: letters, digits and underscores", svc.Name, p.Name)
+			}
+			if seen[p.Name] {
+				return fmt.Errorf("service %s: prompt %s is declared twice", svc.Name, p.Name)
+			}
+			seen[p.Name] = true
+			if _, ok := svc.Env[p.Name]; ok {
+				return fmt.Errorf("service %s: %s is both a prompt and set in env", svc.Name, p.Name)
+			}
+			if p.Default != "" && len(p.Options) > 0 && !slices.Contains(p.Options, p.Default) {
+				return fmt.Errorf("service %s: prompt %s defaults to %s, which isn't one of its options", svc.Name, p.Name, p.Default)
+			}
+		}
+	}
+	return nil
+}
+
+// applyPromptAnswers sets every prompt's answer, or its default when it
+// hasn't been answered, in its service's env.
+func applyPromptAnswers(cfg *PlateConfig, answers map[string]map[string]string) {
+	for i := range cfg.Services {
+		svc := &cfg.Services[i]
+		if len(svc.Prompts) == 0 {
+			continue
+		}
+		env := make(map[string]string, len(svc.Env)+len(svc.Prompts))
+		for k, v := range svc.Env {
+			env[k] = v
+		}
+		for _, p := range svc.Prompts {
+			if answer, ok := answers[svc.Name][p.Name]; ok {
+				env[p.Name] = answer
+			} else if p.Default != "" {
+				env[p.Name] = p.Default
+			}
+		}
+		svc.Env = env
+	}
+}
+
+// unansweredPrompts returns the prompts of enabled services that have no
+// answer yet, in config order.
+func unansweredPrompts(cfg PlateConfig, answers map[string]map[string]string) []pendingPrompt {
+	var pending []pendingPrompt
+	for _, svc := range cfg.Services {
+		if svc.Disabled {
+			continue
+		}
+		for _, p := range svc.Prompts {
+			if _, ok := answers[svc.Name][p.Name]; !ok {
+				pending = append(pending, pendingPrompt{service: svc.Name, prompt: p})
+			}
+		}
+	}
+	return pending
+}
+
+// askPrompts asks for each pending prompt until it gets an acceptable
+// answer. An empty answer picks the default.
+func askPrompts(pending []pendingPrompt, in io.Reader, out io.Writer) (map[string]map[string]string, error) {
+	answers := map[string]map[string]string{}
+	reader := bufio.NewReader(in)
+	for _, pp := range pending {
+		p := pp.prompt
+		question := p.Message
+		if question == "" {
+			question = p.Name
+		}
+		if len(p.Options) > 0 {
+			question += " (" + strings.Join(p.Options, ", ") + ")"
+		}
+		if p.Default != "" {
+			question += " [" + p.Default + "]"
+		}
+		for {
+			fmt.Fprintf(out, "%s %s: ", detailAttrStyle.Render(pp.service), question)
+			line, err := reader.ReadString('\n')
+			answer := strings.TrimSpace(line)
+			if answer == "" {
+				answer = p.Default
+			}
+			valid := answer != "" && (len(p.Options) == 0 || slices.Contains(p.Options, answer))
+			if valid {
+				if answers[pp.service] == nil {
+					answers[pp.service] = map[string]string{}
+				}
+				answers[pp.service][p.Name] = answer
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("no answer for %s of %s", p.Name, pp.service)
+			}
+			if len(p.Options) > 0 {
+				fmt.Fprintf(out, "Pick one of %s.\n", strings.Join(p.Options, ", "))
+			}
+		}
+	}
+	return answers, nil
+}
+
+// mustAnswerPrompts asks for the prompts nobody answered yet, remembers the
+// answers, and returns cfg with them applied. Without a terminal, prompts
+// with a default take it, and the others are an error.
+func mustAnswerPrompts(cfg PlateConfig) PlateConfig {
+	st, err := loadState()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	pending := unansweredPrompts(cfg, st.Prompts)
+	if len(pending) == 0 {
+		return cfg
+	}
+	if !isTerminal(os.Stdin) {
+		for _, pp := range pending {
+			if pp.prompt.Default == "" {
+				fmt.Printf("Error: service %s asks for %s, which has no default; run plate in a terminal to answer it.\n", pp.service, pp.prompt.Name)
+				os.Exit(exitConfig)
+			}
+		}
+		return cfg
+	}
+	fmt.Println("This config has a few settings for you to pick. Plate remembers them; 'plate prompts reset' asks again.")
+	answers, err := askPrompts(pending, os.Stdin, os.Stdout)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	err = updateState(func(st *plateState) {
+		if st.Prompts == nil {
+			st.Prompts = map[string]map[string]string{}
+		}
+		for service, values := range answers {
+			if st.Prompts[service] == nil {
+				st.Prompts[service] = map[string]string{}
+			}
+			for name, value := range values {
+				st.Prompts[service][name] = value
+			}
+		}
+	})
+	if err != nil {
+		fmt.Printf("Error: could not remember the answers: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println()
+	applyPromptAnswers(&cfg, answers)
+	return cfg
+}
+
+// handlePromptsCmd lists the remembered answers, or forgets them so the
+// next start asks again.
+func handlePromptsCmd(args []string) {
+	usage := "Usage: plate prompts [list | reset [service]]"
+	sub := "list"
+	if len(args) > 0 {
+		sub, args = args[0], args[1:]
+	}
+	fs := flag.NewFlagSet("prompts "+sub, flag.ExitOnError)
+	addConfigFlag(fs)
+	fs.Parse(args)
+	plateConfig := mustLoadConfig(configPathArg(fs))
+	st, err := loadState()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	switch sub {
+	case "list":
+		found := false
+		for _, svc := range plateConfig.Services {
+			for _, p := range svc.Prompts {
+				found = true
+				value, ok := st.Prompts[svc.Name][p.Name]
+				switch {
+				case ok:
+					fmt.Printf("%s %s=%s\n", detailAttrStyle.Render(svc.Name), p.Name, value)
+				case p.Default != "":
+					fmt.Printf("%s %s=%s %s\n", detailAttrStyle.Render(svc.Name), p.Name, p.Default, stoppedStyle.Render("(default, not asked yet)"))
+				default:
+					fmt.Printf("%s %s %s\n", detailAttrStyle.Render(svc.Name), p.Name, stoppedStyle.Render("(not asked yet)"))
+				}
+			}
+		}
+		if !found {
+			fmt.Println("No service in the config has prompts.")
+		}
+	case "reset":
+		if fs.NArg() > 1 {
+			fmt.Println(usage)
+			os.Exit(exitUsage)
+		}
+		service := fs.Arg(0)
+		if service != "" && !slices.ContainsFunc(plateConfig.Services, func(s ServiceConfig) bool { return s.Name == service }) {
+			fmt.Printf("Error: no service named '%s' in the config\n", service)
+			os.Exit(1)
+		}
+		err := updateState(func(st *plateState) {
+			if service == "" {
+				st.Prompts = nil
+				return
+			}
+			delete(st.Prompts, service)
+		})
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println("Forgot the answers. Plate asks again the next time it starts.")
+	default:
+		fmt.Println(usage)
+		os.Exit(exitUsage)
+	}
+}
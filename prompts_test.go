@@ -0,0 +1,103 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidatePrompts(t *testing.T) {
+	tests := []struct {
+		name   string
+		svc    ServiceConfig
+		errors bool
+	}{
+		{"valid", ServiceConfig{Name: "db", Prompts: []PromptConfig{{Name: "SEED_SIZE", Options: []string{"small", "large"}, Default: "small"}}}, false},
+		{"bad name", ServiceConfig{Name: "db", Prompts: []PromptConfig{{Name: "seed size"}}}, true},
+		{"twice", ServiceConfig{Name: "db", Prompts: []PromptConfig{{Name: "A"}, {Name: "A"}}}, true},
+		{"also in env", ServiceConfig{Name: "db", Env: map[string]string{"A": "1"}, Prompts: []PromptConfig{{Name: "A"}}}, true},
+		{"default not an option", ServiceConfig{Name: "db", Prompts: []PromptConfig{{Name: "A", Options: []string{"x"}, Default: "y"}}}, true},
+	}
+	for _, tt := range tests {
+		err := validatePrompts(PlateConfig{Services: []ServiceConfig{tt.svc}})
+		if (err != nil) != tt.errors {
+			t.Errorf("%s: Expected error=%v, got %v", tt.name, tt.errors, err)
+		}
+	}
+}
+
+func TestAskPrompts(t *testing.T) {
+	pending := []pendingPrompt{
+		{service: "db", prompt: PromptConfig{Name: "SEED_SIZE", Options: []string{"small", "large"}, Default: "small"}},
+		{service: "web", prompt: PromptConfig{Name: "THEME"}},
+	}
+	// An invalid option is asked again, and an empty answer takes the default.
+	answers, err := askPrompts(pending, strings.NewReader("huge\n\ndark\n"), io.Discard)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if answers["db"]["SEED_SIZE"] != "small" || answers["web"]["THEME"] != "dark" {
+		t.Errorf("Expected small and dark, got %v", answers)
+	}
+	if _, err := askPrompts(pending[1:], strings.NewReader(""), io.Discard); err == nil {
+		t.Errorf("Expected an error when a prompt without a default gets no answer")
+	}
+}
+
+func TestPromptAnswersInConfig(t *testing.T) {
+	t.Chdir(t.TempDir())
+	config := `{"services": [{"type": "postgres", "name": "db", "port": 5432, "env": {"X": "1"}, "prompts": [{"name": "SEED_SIZE", "default": "small"}, {"name": "THEME"}]}]}`
+	os.WriteFile("plate.config.json", []byte(config), 0644)
+
+	cfg, err := loadConfig("plate.config.json")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	env := cfg.Services[0].Env
+	if env["SEED_SIZE"] != "small" || env["X"] != "1" {
+		t.Errorf("Expected the default next to the env, got %v", env)
+	}
+	if _, ok := env["THEME"]; ok {
+		t.Errorf("Expected no THEME before it is answered")
+	}
+	if pending := unansweredPrompts(cfg, nil); len(pending) != 2 {
+		t.Errorf("Expected 2 unanswered prompts, got %d", len(pending))
+	}
+
+	updateState(func(st *plateState) {
+		st.Prompts = map[string]map[string]string{"db": {"SEED_SIZE": "large", "THEME": "dark"}}
+	})
+	cfg, _ = loadConfig("plate.config.json")
+	if env := cfg.Services[0].Env; env["SEED_SIZE"] != "large" || env["THEME"] != "dark" {
+		t.Errorf("Expected the remembered answers, got %v", env)
+	}
+	st, _ := loadState()
+	if pending := unansweredPrompts(cfg, st.Prompts); len(pending) != 0 {
+		t.Errorf("Expected no unanswered prompts, got %v", pending)
+	}
+}
+
+func TestPromptAnswersFollowConfig(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	t.Cleanup(func() { stateDir = stateDirName })
+	os.Mkdir("app", 0755)
+	config := `{"services": [{"type": "postgres", "name": "db", "port": 5432, "prompts": [{"name": "SEED_SIZE", "default": "small"}]}]}`
+	os.WriteFile(filepath.Join("app", "plate.config.json"), []byte(config), 0644)
+
+	// Answered in the project, then loaded from its parent with --config.
+	t.Chdir(filepath.Join(dir, "app"))
+	useStateDirOf("plate.config.json")
+	updateState(func(st *plateState) {
+		st.Prompts = map[string]map[string]string{"db": {"SEED_SIZE": "large"}}
+	})
+	stateDir = stateDirName
+
+	t.Chdir(dir)
+	cfg := mustLoadConfig(filepath.Join("app", "plate.config.json"))
+	if seed := cfg.Services[0].Env["SEED_SIZE"]; seed != "large" {
+		t.Errorf("Expected the answer stored next to the config, got %s", seed)
+	}
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// --- PROXIES AND MIRRORS ---
+
+// cliProxy is the default proxy set in the docker CLI's config.json.
+type cliProxy struct {
+	HTTPProxy  string `json:"httpProxy"`
+	HTTPSProxy string `json:"httpsProxy"`
+	NoProxy    string `json:"noProxy"`
+}
+
+// dockerConfigDir returns where the docker CLI keeps config.json.
+func dockerConfigDir() string {
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return dir
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".docker")
+}
+
+// loadCLIProxy reads the default proxy from the docker CLI's config.json.
+// The CLI passes it to containers, not to the daemon's pulls.
+func loadCLIProxy() cliProxy {
+	var cfg struct {
+		Proxies map[string]cliProxy `json:"proxies"`
+	}
+	data, err := os.ReadFile(filepath.Join(dockerConfigDir(), "config.json"))
+	if err != nil || json.Unmarshal(data, &cfg) != nil {
+		return cliProxy{}
+	}
+	return cfg.Proxies["default"]
+}
+
+// proxyFor picks the proxy for a request URL: the environment's, like any
+// Go program, or else the docker CLI's, so registry lookups work on networks
+// where only docker was set up.
+func proxyFor(environment func(*http.Request) (*url.URL, error), cli cliProxy) func(*http.Request) (*url.URL, error) {
+	return func(req *http.Request) (*url.URL, error) {
+		if proxy, err := environment(req); proxy != nil || err != nil {
+			return proxy, err
+		}
+		raw := cli.HTTPProxy
+		if req.URL.Scheme == "https" {
+			raw = cli.HTTPSProxy
+		}
+		if raw == "" || noProxyMatches(cli.NoProxy, req.URL.Hostname()) {
+			return nil, nil
+		}
+		return url.Parse(raw)
+	}
+}
+
+// noProxyMatches reports whether host is excluded by a comma-separated
+// NO_PROXY list of hosts, domain suffixes, and "*".
+func noProxyMatches(noProxy, host string) bool {
+	for _, entry := range strings.Split(noProxy, ",") {
+		entry = strings.TrimSpace(entry)
+		if h, _, err := net.SplitHostPort(entry); err == nil {
+			entry = h
+		}
+		switch {
+		case entry == "":
+		case entry == "*", entry == host:
+			return true
+		case strings.HasSuffix(host, "."+strings.TrimPrefix(entry, ".")):
+			return true
+		}
+	}
+	return false
+}
+
+// proxiedClient returns an HTTP client that honors the proxy settings.
+func proxiedClient(timeout time.Duration) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		return proxyFor(http.ProxyFromEnvironment, loadCLIProxy())(req)
+	}
+	return &http.Client{Transport: transport, Timeout: timeout}
+}
+
+// pullError turns docker pull output into an error, explaining failures
+// that usually come from a proxy, or the lack of one.
+func pullError(output string) error {
+	msg := lastLine(strings.TrimSpace(output))
+	lower := strings.ToLower(output)
+	switch {
+	case strings.Contains(lower, "proxyconnect"):
+		return fmt.Errorf("%s\nThe docker daemon couldn't reach its proxy. Check the proxy in Docker Desktop's settings, or the HTTP_PROXY of the docker service (a systemd drop-in on Linux)", msg)
+	case strings.Contains(lower, "x509: certificate signed by unknown authority"), strings.Contains(lower, "tls: failed to verify certificate"):
+		return fmt.Errorf("%s\nA proxy may be intercepting TLS. Add its CA to the machine docker runs on, or configure a registry mirror the network allows", msg)
+	case strings.Contains(lower, "client.timeout exceeded"), strings.Contains(lower, "i/o timeout"),
+		strings.Contains(lower, "no such host"), strings.Contains(lower, "connection refused") && strings.Contains(lower, "registry"):
+		return fmt.Errorf("%s\nThe registry isn't reachable. Behind a corporate proxy, give the docker daemon its proxy (proxies in ~/.docker/config.json only apply to containers) or a registry mirror; 'plate doctor' shows what it uses", msg)
+	case noManifestHint(output) != "":
+		return fmt.Errorf("%s\n%s", msg, noManifestHint(output))
+	case msg == "":
+		return fmt.Errorf("docker pull failed")
+	}
+	return fmt.Errorf("%s", msg)
+}
+
+// daemonNetwork is the proxy and mirrors the docker daemon pulls through.
+type daemonNetwork struct {
+	HTTPProxy  string   `json:"HTTPProxy"`
+	HTTPSProxy string   `json:"HTTPSProxy"`
+	Mirrors    []string `json:"Mirrors"`
+}
+
+// inspectDaemonNetwork asks docker for the daemon's proxy and mirrors.
+func inspectDaemonNetwork() (daemonNetwork, error) {
+	output, err := dockerCommand("info", "--format", `{"HTTPProxy":{{json .HTTPProxy}},"HTTPSProxy":{{json .HTTPSProxy}},"Mirrors":{{json .RegistryConfig.Mirrors}}}`).Output()
+	if err != nil {
+		return daemonNetwork{}, err
+	}
+	var n daemonNetwork
+	err = json.Unmarshal(output, &n)
+	return n, err
+}
+
+// checkRegistryNetwork describes how the daemon reaches registries. It
+// fails when this shell or the docker CLI has a proxy but the daemon,
+// which does the pulling, doesn't.
+func checkRegistryNetwork(n daemonNetwork, shellProxy string, cli cliProxy) doctorResult {
+	var parts []string
+	if n.HTTPSProxy != "" || n.HTTPProxy != "" {
+		parts = append(parts, "proxy "+firstNonEmpty(n.HTTPSProxy, n.HTTPProxy))
+	}
+	if len(n.Mirrors) > 0 {
+		parts = append(parts, "mirrors "+strings.Join(n.Mirrors, ", "))
+	}
+	if len(parts) > 0 {
+		return doctorResult{ok: true, detail: strings.Join(parts, "; ")}
+	}
+	if proxy := firstNonEmpty(shellProxy, cli.HTTPSProxy, cli.HTTPProxy); proxy != "" {
+		return doctorResult{detail: fmt.Sprintf("a proxy (%s) is set for this shell or the docker CLI, but the daemon pulls without one; set it in Docker Desktop's settings or the docker service's environment", proxy)}
+	}
+	return doctorResult{ok: true, detail: "direct, no proxy or mirrors"}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestProxyFor(t *testing.T) {
+	noEnv := func(*http.Request) (*url.URL, error) { return nil, nil }
+	cli := cliProxy{HTTPProxy: "http://proxy:3128", HTTPSProxy: "http://proxy:3129", NoProxy: "localhost,.corp.example"}
+	tests := []struct {
+		url      string
+		expected string
+	}{
+		{"https://registry-1.docker.io/v2/", "http://proxy:3129"},
+		{"http://example.com/plate.json", "http://proxy:3128"},
+		{"https://git.corp.example/plate.json", ""},
+		{"http://localhost:8080/", ""},
+	}
+	for _, tt := range tests {
+		req, _ := http.NewRequest("GET", tt.url, nil)
+		proxy, err := proxyFor(noEnv, cli)(req)
+		got := ""
+		if proxy != nil {
+			got = proxy.String()
+		}
+		if err != nil || got != tt.expected {
+			t.Errorf("%s: Expected proxy '%s', got '%s' (%v)", tt.url, tt.expected, got, err)
+		}
+	}
+
+	env := func(*http.Request) (*url.URL, error) { return url.Parse("http://env-proxy:8080") }
+	req, _ := http.NewRequest("GET", "https://hub.docker.com/", nil)
+	if proxy, _ := proxyFor(env, cli)(req); proxy == nil || proxy.Host != "env-proxy:8080" {
+		t.Errorf("Expected the environment's proxy to win, got %v", proxy)
+	}
+}
+
+func TestPullError(t *testing.T) {
+	tests := []struct {
+		output   string
+		expected string
+	}{
+		{"Error response from daemon: Get \"https://registry-1.docker.io/v2/\": proxyconnect tcp: dial tcp 10.0.0.1:3128: connect: connection refused", "couldn't reach its proxy"},
+		{"Error response from daemon: Get \"https://registry-1.docker.io/v2/\": tls: failed to verify certificate: x509: certificate signed by unknown authority", "intercepting TLS"},
+		{"Error response from daemon: Get \"https://registry-1.docker.io/v2/\": net/http: request canceled while waiting for connection (Client.Timeout exceeded while awaiting headers)", "registry isn't reachable"},
+		{"Error response from daemon: manifest for postgres:99 not found: manifest unknown", "manifest unknown"},
+	}
+	for _, tt := range tests {
+		if err := pullError(tt.output); !strings.Contains(err.Error(), tt.expected) {
+			t.Errorf("Expected '%s' in the error, got %v", tt.expected, err)
+		}
+	}
+}
+
+func TestCheckRegistryNetwork(t *testing.T) {
+	if res := checkRegistryNetwork(daemonNetwork{Mirrors: []string{"https://mirror.corp.example/"}}, "", cliProxy{}); !res.ok || !strings.Contains(res.detail, "mirror.corp.example") {
+		t.Errorf("Expected the mirror to be reported, got %+v", res)
+	}
+	if res := checkRegistryNetwork(daemonNetwork{}, "", cliProxy{HTTPSProxy: "http://proxy:3128"}); res.ok {
+		t.Errorf("Expected a CLI-only proxy to be flagged, got %+v", res)
+	}
+	if res := checkRegistryNetwork(daemonNetwork{}, "", cliProxy{}); !res.ok {
+		t.Errorf("Expected a direct connection to pass, got %+v", res)
+	}
+}
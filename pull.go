@@ -0,0 +1,238 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// --- IMAGE PRE-FETCH ---
+
+// pullRedraw is how often the progress line is redrawn on a terminal.
+const pullRedraw = 200 * time.Millisecond
+
+// layerIDPattern matches the short layer IDs docker pull reports progress for.
+var layerIDPattern = regexp.MustCompile(`^[0-9a-f]{12}$`)
+
+// configImages returns the registry images the config's container services
+// use, in config order and without duplicates. Images with a build are
+// built locally and left out.
+func configImages(cfg PlateConfig) []string {
+	seen := map[string]bool{}
+	var images []string
+	for _, svc := range cfg.containerServices() {
+		if svc.Build != nil {
+			continue
+		}
+		image := imageName(svc)
+		if !seen[image] {
+			seen[image] = true
+			images = append(images, image)
+		}
+	}
+	return images
+}
+
+// pullState follows the output of one docker pull.
+type pullState struct {
+	image    string
+	layers   map[string]bool // layer ID -> downloaded and extracted
+	finished bool
+	err      error
+}
+
+// observe reads one line of docker pull output, such as
+// "a2318d6c47ec: Pull complete".
+func (p *pullState) observe(line string) {
+	id, status, ok := strings.Cut(strings.TrimSpace(line), ": ")
+	if !ok || !layerIDPattern.MatchString(id) {
+		return
+	}
+	switch {
+	case status == "Pull complete" || status == "Already exists":
+		p.layers[id] = true
+	case !p.layers[id]:
+		p.layers[id] = false
+	}
+}
+
+// pullProgress sums up the pulls: finished images and downloaded layers.
+func pullProgress(states []*pullState) (images, doneImages, layers, doneLayers int) {
+	for _, p := range states {
+		images++
+		if p.finished {
+			doneImages++
+		}
+		for _, done := range p.layers {
+			layers++
+			if done {
+				doneLayers++
+			}
+		}
+	}
+	return images, doneImages, layers, doneLayers
+}
+
+// renderPullProgress draws the aggregate progress as one line.
+func renderPullProgress(states []*pullState) string {
+	images, doneImages, layers, doneLayers := pullProgress(states)
+	const width = 20
+	filled := 0
+	if layers > 0 {
+		filled = doneLayers * width / layers
+	}
+	if doneImages == images {
+		filled = width
+	}
+	bar := downloadingStyle.Render(strings.Repeat("█", filled)) + strings.Repeat("░", width-filled)
+	return fmt.Sprintf("%s %d/%d images, %d/%d layers", bar, doneImages, images, doneLayers, layers)
+}
+
+// pullWithProgress pulls an image, passing each line of output to observe.
+func pullWithProgress(image string, observe func(line string)) error {
+	cmd := dockerCommand(pullArgs(image)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	scanner := bufio.NewScanner(stdout)
+	for scanner.Scan() {
+		observe(scanner.Text())
+	}
+	if err := cmd.Wait(); err != nil {
+		if strings.TrimSpace(stderr.String()) != "" {
+			return pullError(stderr.String())
+		}
+		return err
+	}
+	return nil
+}
+
+// prefetchImages pulls the images within the pull limit and reports their
+// aggregate progress. On a terminal the progress line is redrawn in place.
+// It returns the number of images that could not be pulled.
+func prefetchImages(images []string, slots semaphore, out io.Writer, live bool) int {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	states := make([]*pullState, len(images))
+	for i, image := range images {
+		states[i] = &pullState{image: image, layers: map[string]bool{}}
+	}
+	// report prints a line above the progress line; callers hold mu.
+	report := func(line string) {
+		if live {
+			fmt.Fprint(out, "\r\033[K")
+		}
+		fmt.Fprintln(out, line)
+		if live {
+			fmt.Fprint(out, renderPullProgress(states))
+		}
+	}
+
+	for _, p := range states {
+		wg.Add(1)
+		go func(p *pullState) {
+			defer wg.Done()
+			var err error
+			slots.run(func() {
+				err = pullWithProgress(p.image, func(line string) {
+					mu.Lock()
+					p.observe(line)
+					mu.Unlock()
+				})
+			})
+			mu.Lock()
+			defer mu.Unlock()
+			p.finished, p.err = true, err
+			if err != nil && output == outputQuiet {
+				reportFailure(out, p.image, err)
+				return
+			}
+			if err != nil {
+				report(fmt.Sprintf("%s %s: %v", errorStyle.Render("✗"), p.image, err))
+				return
+			}
+			report(fmt.Sprintf("%s %s", successStyle.Render("✓"), p.image))
+		}(p)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	if live {
+		ticker := time.NewTicker(pullRedraw)
+		defer ticker.Stop()
+	redraw:
+		for {
+			select {
+			case <-ticker.C:
+				mu.Lock()
+				fmt.Fprint(out, "\r\033[K"+renderPullProgress(states))
+				mu.Unlock()
+			case <-done:
+				break redraw
+			}
+		}
+		fmt.Fprint(out, "\r\033[K")
+	}
+	<-done
+
+	failures := 0
+	for _, p := range states {
+		if p.err != nil {
+			failures++
+		}
+	}
+	return failures
+}
+
+// isTerminal reports whether f is attached to a terminal.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// prefetchConfigImages pulls every image the config uses, printing progress
+// to stdout. It returns the number of images that could not be pulled.
+func prefetchConfigImages(cfg PlateConfig, out io.Writer) int {
+	images := configImages(cfg)
+	if len(images) == 0 {
+		fmt.Fprintln(out, "No images to pull.")
+		return 0
+	}
+	fmt.Fprintf(out, "Pulling %d image(s)...\n", len(images))
+	failures := prefetchImages(images, newDockerSemaphores(cfg.Concurrency).pulls, out, out == os.Stdout && isTerminal(os.Stdout))
+	if failures > 0 {
+		fmt.Fprintln(out, errorStyle.Render(fmt.Sprintf("%d of %d image(s) could not be pulled.", failures, len(images))))
+	} else {
+		fmt.Fprintln(out, successStyle.Render("All images are up to date."))
+	}
+	return failures
+}
+
+// handlePullCmd downloads the images the config uses ahead of time.
+func handlePullCmd(args []string) {
+	fs := flag.NewFlagSet("pull", flag.ExitOnError)
+	addConfigFlag(fs)
+	addOutputFlags(fs)
+	fs.Parse(args)
+	plateConfig := mustLoadConfig(configPathArg(fs))
+	mustHaveDockerAccess(plateConfig)
+	if prefetchConfigImages(plateConfig, progressOut()) > 0 {
+		os.Exit(exitPartial)
+	}
+}
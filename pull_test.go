@@ -0,0 +1,45 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConfigImages(t *testing.T) {
+	cfg := PlateConfig{Services: []ServiceConfig{
+		{Type: "postgres", Name: "main", Version: "16"},
+		{Type: "redis", Name: "cache", Version: "7"},
+		{Type: "postgres", Name: "replica", Version: "16"},
+		{Type: "redis", Name: "old", Version: "6", Disabled: true},
+		{Type: "process", Name: "web", Command: "npm start"},
+	}}
+	got := configImages(cfg)
+	expected := []string{"postgres:16", "redis:7"}
+	if strings.Join(got, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestPullStateObserve(t *testing.T) {
+	p := &pullState{image: "postgres:16", layers: map[string]bool{}}
+	for _, line := range []string{
+		"16: Pulling from library/postgres",
+		"a2318d6c47ec: Already exists",
+		"8b2d8e3a4a5c: Pulling fs layer",
+		"0b1c6a4c2f1e: Pulling fs layer",
+		"8b2d8e3a4a5c: Downloading",
+		"8b2d8e3a4a5c: Pull complete",
+		"0b1c6a4c2f1e: Verifying Checksum",
+		"Digest: sha256:0123456789abcdef0123456789abcdef",
+		"Status: Downloaded newer image for postgres:16",
+	} {
+		p.observe(line)
+	}
+	images, doneImages, layers, doneLayers := pullProgress([]*pullState{p})
+	if images != 1 || doneImages != 0 || layers != 3 || doneLayers != 2 {
+		t.Errorf("Expected 0/1 images and 2/3 layers, got %d/%d images and %d/%d layers", doneImages, images, doneLayers, layers)
+	}
+	if got := renderPullProgress([]*pullState{p}); !strings.Contains(got, "0/1 images, 2/3 layers") {
+		t.Errorf("Expected the progress line to count images and layers, got %q", got)
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// --- QR CODE VIEW ---
+
+// qrView is the state of the QR code view.
+type qrView struct {
+	service string
+	url     string // what the code encodes
+	code    string // rendered code, empty when err is set
+	err     error
+}
+
+// newQRView encodes the connection string of a running service for a
+// device on the same network.
+func newQRView(config ServiceConfig, connStr string) qrView {
+	v := qrView{service: config.Name}
+	v.url, v.err = lanConnectionString(config, connStr, lanAddress())
+	if v.err != nil {
+		return v
+	}
+	code, err := qrcode.New(v.url, qrcode.Medium)
+	if err != nil {
+		v.err = err
+		return v
+	}
+	v.code = code.ToSmallString(true)
+	return v
+}
+
+// lanConnectionString returns the connection string as seen from another
+// device on the network. localhost becomes the machine's LAN address;
+// a port published on loopback only can't be reached at all.
+func lanConnectionString(config ServiceConfig, connStr, lanAddr string) (string, error) {
+	if ip := net.ParseIP(config.Host); ip != nil && ip.IsLoopback() {
+		return "", fmt.Errorf("%s is only published on %s; set host to a LAN address (or remove it) to reach it from other devices", config.Name, config.Host)
+	}
+	if config.Host != "" && config.Host != "localhost" {
+		return connStr, nil
+	}
+	if lanAddr == "" {
+		return "", fmt.Errorf("this machine has no network address other devices could use")
+	}
+	u, err := url.Parse(connStr)
+	if err != nil {
+		return "", err
+	}
+	u.Host = net.JoinHostPort(lanAddr, u.Port())
+	return u.String(), nil
+}
+
+// lanAddress returns the machine's first private IPv4 address, or any
+// non-loopback one, or "" without a network.
+func lanAddress() string {
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return ""
+	}
+	fallback := ""
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok || ipNet.IP.To4() == nil || ipNet.IP.IsLoopback() || ipNet.IP.IsLinkLocalUnicast() {
+			continue
+		}
+		if ipNet.IP.IsPrivate() {
+			return ipNet.IP.String()
+		}
+		if fallback == "" {
+			fallback = ipNet.IP.String()
+		}
+	}
+	return fallback
+}
+
+func (m model) updateQRView(key tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch key.String() {
+	case "Q", "q", "esc":
+		m.showingQR = false
+	}
+	return m, nil
+}
+
+func (m model) renderQRView() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("QR Code: " + m.qr.service))
+	b.WriteString("\n\n")
+	if m.qr.err != nil {
+		b.WriteString(errorStyle.Render(m.qr.err.Error()) + "\n")
+	} else {
+		// The code needs dark modules on a light background to scan.
+		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("0")).Background(lipgloss.Color("15")).Render(strings.TrimRight(m.qr.code, "\n")) + "\n\n")
+		b.WriteString(successStyle.Render(m.qr.url) + "\n")
+	}
+	b.WriteString("\n" + helpStyle.Render("Q/esc: close"))
+	return b.String()
+}
@@ -0,0 +1,28 @@
+package main
+
+import "testing"
+
+func TestLanConnectionString(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   ServiceConfig
+		connStr  string
+		lanAddr  string
+		expected string
+		wantErr  bool
+	}{
+		{"localhost", ServiceConfig{Name: "db"}, "postgres://postgres:pw@localhost:5433/postgres?sslmode=disable", "192.168.1.20", "postgres://postgres:pw@192.168.1.20:5433/postgres?sslmode=disable", false},
+		{"lan host", ServiceConfig{Name: "db", Host: "192.168.106.2"}, "redis://192.168.106.2:6380", "10.0.0.5", "redis://192.168.106.2:6380", false},
+		{"loopback only", ServiceConfig{Name: "db", Host: "127.0.0.1"}, "redis://127.0.0.1:6380", "10.0.0.5", "", true},
+		{"no network", ServiceConfig{Name: "db"}, "redis://localhost:6380", "", "", true},
+	}
+	for _, tt := range tests {
+		got, err := lanConnectionString(tt.config, tt.connStr, tt.lanAddr)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: Expected error %v, got %v", tt.name, tt.wantErr, err)
+		}
+		if got != tt.expected {
+			t.Errorf("%s: Expected '%s', got '%s'", tt.name, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// --- QUIT CONFIRMATION ---
+
+// quitPlan lists what quitting stops and what it leaves running, as lines
+// for the confirmation. keepContainers is the keep-running mode.
+func quitPlan(items []list.Item, keepContainers bool) (stop, keep []string) {
+	for _, itm := range items {
+		i := itm.(item)
+		switch {
+		case i.process != nil:
+			stop = append(stop, fmt.Sprintf("%s (process)", i.config.Name))
+		case i.containerID == "" || i.status != statusRunning:
+		case i.pinned:
+			keep = append(keep, fmt.Sprintf("%s (pinned)", i.config.Name))
+		case keepContainers:
+			keep = append(keep, fmt.Sprintf("%s (%s)", i.config.Name, containerName(i.config)))
+		default:
+			stop = append(stop, fmt.Sprintf("%s (%s)", i.config.Name, containerName(i.config)))
+		}
+	}
+	return stop, keep
+}
+
+// startQuit quits right away when there is nothing to stop, and otherwise
+// asks first, so a stray q doesn't tear the environment down.
+func (m model) startQuit() (tea.Model, tea.Cmd) {
+	if stop, _ := quitPlan(m.items, m.keepRunning); len(stop) == 0 {
+		m.quitting = true
+		return m, stopAllContainersOnExit(m.items, m.keepRunning)
+	}
+	m.confirmingQuit = true
+	return m, nil
+}
+
+func (m model) updateQuitConfirm(key tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Only an explicit answer quits, so pressing q twice by accident
+	// doesn't stop everything.
+	switch key.String() {
+	case "y", "Y", "enter":
+		m.confirmingQuit, m.quitting = false, true
+		return m, stopAllContainersOnExit(m.items, m.keepRunning)
+	case "X":
+		m.confirmingQuit, m.quitting, m.keepRunning = false, true, true
+		return m, stopAllContainersOnExit(m.items, true)
+	}
+	m.confirmingQuit = false
+	return m, nil
+}
+
+func (m model) renderQuitConfirmView() string {
+	stop, keep := quitPlan(m.items, m.keepRunning)
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Quit Plate?"))
+	b.WriteString("\n\n")
+	b.WriteString(detailAttrStyle.Render("Will stop") + ":\n")
+	for _, line := range stop {
+		b.WriteString("  " + errorStyle.Render(line) + "\n")
+	}
+	if len(keep) > 0 {
+		b.WriteString("\n" + detailAttrStyle.Render("Left running") + ":\n")
+		for _, line := range keep {
+			b.WriteString("  " + successStyle.Render(line) + "\n")
+		}
+	}
+	b.WriteString("\n" + helpStyle.Render("y/enter: quit • X: quit, leave containers running • n/esc: cancel"))
+	return b.String()
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestQuitPlan(t *testing.T) {
+	items := []list.Item{
+		item{config: ServiceConfig{Type: "postgres", Name: "main-db"}, status: statusRunning, containerID: "abc"},
+		item{config: ServiceConfig{Type: "postgres", Name: "shared-db"}, status: statusRunning, containerID: "def", pinned: true},
+		item{config: ServiceConfig{Type: "redis", Name: "cache"}, status: statusStopped, containerID: "ghi"},
+		item{config: ServiceConfig{Type: "process", Name: "web"}, status: statusRunning, process: &runningProcess{}},
+	}
+	tests := []struct {
+		name           string
+		keepContainers bool
+		stop, keep     string
+	}{
+		{"quit", false, "main-db (plate-postgres-main-db), web (process)", "shared-db (pinned)"},
+		{"keep running", true, "web (process)", "main-db (plate-postgres-main-db), shared-db (pinned)"},
+	}
+	for _, tt := range tests {
+		stop, keep := quitPlan(items, tt.keepContainers)
+		if got := strings.Join(stop, ", "); got != tt.stop {
+			t.Errorf("%s: Expected to stop %s, got %s", tt.name, tt.stop, got)
+		}
+		if got := strings.Join(keep, ", "); got != tt.keep {
+			t.Errorf("%s: Expected to keep %s, got %s", tt.name, tt.keep, got)
+		}
+	}
+}
+
+func TestQuitConfirmation(t *testing.T) {
+	m := model{items: []list.Item{item{config: ServiceConfig{Type: "redis", Name: "cache"}, status: statusRunning, containerID: "abc"}}}
+	next, cmd := m.startQuit()
+	m = next.(model)
+	if !m.confirmingQuit || m.quitting || cmd != nil {
+		t.Fatalf("Expected a confirmation before stopping anything")
+	}
+	for _, key := range []tea.KeyMsg{{Type: tea.KeyEsc}, {Type: tea.KeyRunes, Runes: []rune("q")}, {Type: tea.KeyCtrlC}} {
+		next, cmd := m.updateQuitConfirm(key)
+		if m := next.(model); m.confirmingQuit || m.quitting || cmd != nil {
+			t.Errorf("Expected %s to cancel", key)
+		}
+	}
+	next, cmd = m.updateQuitConfirm(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if m := next.(model); !m.quitting || cmd == nil {
+		t.Errorf("Expected y to start the cleanup")
+	}
+
+	// Nothing to stop, nothing to ask.
+	next, cmd = model{}.startQuit()
+	if m := next.(model); m.confirmingQuit || !m.quitting || cmd == nil {
+		t.Errorf("Expected to quit right away")
+	}
+}
@@ -0,0 +1,363 @@
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// --- RECIPES ---
+
+//go:embed recipes/*.json
+var builtinRecipes embed.FS
+
+// recipe is a ready-made service entry that `plate add` appends to a config.
+// Service is kept as plain JSON so a recipe can set any field. A recipe has
+// Stack instead for services that come up as one unit: they are added as a
+// stack, named after the entry, and an entry referencing it.
+type recipe struct {
+	Description string           `json:"description"`
+	Service     map[string]any   `json:"service,omitempty"`
+	Stack       []map[string]any `json:"stack,omitempty"`
+	name        string
+	source      string
+}
+
+// userRecipeDir is where a user keeps their own recipes, e.g.
+// ~/.config/plate/recipes on Linux.
+func userRecipeDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "plate", "recipes"), nil
+}
+
+// parseRecipe reads a recipe file and checks that its service is one Plate
+// can run.
+func parseRecipe(name, source string, data []byte) (recipe, error) {
+	r := recipe{name: name, source: source}
+	if err := json.Unmarshal(data, &r); err != nil {
+		return r, fmt.Errorf("recipe %s: %v", name, err)
+	}
+	entries := []map[string]any{r.Service}
+	if len(r.Stack) > 0 {
+		if r.Service != nil {
+			return r, fmt.Errorf("recipe %s: has both a service and a stack", name)
+		}
+		entries = r.Stack
+	}
+	for _, entry := range entries {
+		svc, err := decodeService(entry)
+		if err != nil {
+			return r, fmt.Errorf("recipe %s: %v", name, err)
+		}
+		if svc.Name == "" {
+			return r, fmt.Errorf("recipe %s: service has no name", name)
+		}
+		if svc.Stack != "" {
+			return r, fmt.Errorf("recipe %s: service %s can't reference a stack", name, svc.Name)
+		}
+		if !svc.isProcess() {
+			if _, err := getServiceSpec(svc); err != nil {
+				return r, fmt.Errorf("recipe %s: %v", name, err)
+			}
+		}
+	}
+	return r, nil
+}
+
+// serviceConfig decodes the recipe's service entry.
+func (r recipe) serviceConfig() (ServiceConfig, error) {
+	return decodeService(r.Service)
+}
+
+// decodeService decodes a service entry kept as plain JSON.
+func decodeService(entry map[string]any) (ServiceConfig, error) {
+	var svc ServiceConfig
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return svc, err
+	}
+	err = json.Unmarshal(data, &svc)
+	return svc, err
+}
+
+// loadRecipes returns the built-in recipes together with the user's own from
+// dir, which win over built-ins of the same name.
+func loadRecipes(dir string) (map[string]recipe, error) {
+	recipes := map[string]recipe{}
+	entries, _ := builtinRecipes.ReadDir("recipes")
+	for _, e := range entries {
+		data, err := builtinRecipes.ReadFile("recipes/" + e.Name())
+		if err != nil {
+			return nil, err
+		}
+		name := strings.TrimSuffix(e.Name(), ".json")
+		r, err := parseRecipe(name, "built-in", data)
+		if err != nil {
+			return nil, err
+		}
+		recipes[name] = r
+	}
+	if dir == "" {
+		return recipes, nil
+	}
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	for _, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		name := strings.TrimSuffix(filepath.Base(path), ".json")
+		r, err := parseRecipe(name, path, data)
+		if err != nil {
+			return nil, err
+		}
+		recipes[name] = r
+	}
+	return recipes, nil
+}
+
+// recipeService turns a recipe into a service entry for cfg. The entry gets
+// name, or the recipe's name with a number appended if that is taken, and
+// the first port from the recipe's up that no service uses and isn't bound
+// on the host.
+func recipeService(cfg PlateConfig, r recipe, name string, inUse func(port int) bool) (map[string]any, error) {
+	svc, err := r.serviceConfig()
+	if err != nil {
+		return nil, err
+	}
+	names, ports := takenNames(cfg)
+	name, err = entryName(names, name, svc.Name)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := freePortsEntry(r.Service, ports, inUse)
+	if err != nil {
+		return nil, err
+	}
+	entry["name"] = name
+	return entry, nil
+}
+
+// recipeStack turns a stack recipe into the members of a stack for cfg and
+// the entry referencing it. The stack and entry get name, or the recipe's
+// name with a number appended if that is taken, and every member its own
+// free ports.
+func recipeStack(cfg PlateConfig, r recipe, name string, inUse func(port int) bool) (map[string]any, []any, error) {
+	names, ports := takenNames(cfg)
+	for stack := range cfg.Stacks {
+		names[stack] = true
+	}
+	name, err := entryName(names, name, r.name)
+	if err != nil {
+		return nil, nil, err
+	}
+	var members []any
+	for _, s := range r.Stack {
+		member, err := freePortsEntry(s, ports, inUse)
+		if err != nil {
+			return nil, nil, err
+		}
+		members = append(members, member)
+	}
+	return map[string]any{"name": name, "stack": name}, members, nil
+}
+
+// takenNames returns the service and stack entry names and the ports cfg
+// uses.
+func takenNames(cfg PlateConfig) (map[string]bool, map[int]bool) {
+	names := map[string]bool{}
+	ports := map[int]bool{}
+	for _, s := range cfg.Services {
+		names[s.Name] = true
+		if s.group != "" {
+			names[s.group] = true
+		}
+		ports[s.Port] = true
+		if s.UIPort != 0 {
+			ports[s.UIPort] = true
+		}
+	}
+	return names, ports
+}
+
+// entryName returns name if it is free, or else the first free one of base,
+// base-2, base-3, and so on.
+func entryName(names map[string]bool, name, base string) (string, error) {
+	if name != "" && names[name] {
+		return "", fmt.Errorf("a service named '%s' already exists", name)
+	}
+	if name == "" {
+		name = base
+		for n := 2; names[name]; n++ {
+			name = fmt.Sprintf("%s-%d", base, n)
+		}
+	}
+	return name, nil
+}
+
+// freePortsEntry copies a recipe's service entry with its port and uiPort
+// moved up to free ones, which it then claims in ports.
+func freePortsEntry(service map[string]any, ports map[int]bool, inUse func(port int) bool) (map[string]any, error) {
+	svc, err := decodeService(service)
+	if err != nil {
+		return nil, err
+	}
+	entry := map[string]any{}
+	for k, v := range service {
+		entry[k] = v
+	}
+	for _, key := range []string{"port", "uiPort"} {
+		from := svc.Port
+		if key == "uiPort" {
+			from = svc.UIPort
+		}
+		if from == 0 {
+			continue
+		}
+		port := nextFreePort(from, func(port int) bool { return ports[port] || inUse(port) })
+		if port == 0 {
+			return nil, fmt.Errorf("no free port from %d up", from)
+		}
+		ports[port] = true
+		entry[key] = port
+	}
+	return entry, nil
+}
+
+func handleAddCmd(args []string) {
+	fs := flag.NewFlagSet("add", flag.ExitOnError)
+	addConfigFlag(fs)
+	name := fs.String("name", "", "name of the new service (defaults to the recipe's)")
+	local := fs.Bool("local", false, "add the service to the local override file instead")
+	pickVersion := fs.Bool("pick-version", false, "choose the version from the image's tags")
+	fs.Parse(args)
+
+	dir, _ := userRecipeDir()
+	recipes, err := loadRecipes(dir)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if fs.NArg() == 0 {
+		names := make([]string, 0, len(recipes))
+		for n := range recipes {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		fmt.Println("Usage: plate add [--config path] [--name name] [--local] [--pick-version] <recipe>")
+		fmt.Println()
+		for _, n := range names {
+			r := recipes[n]
+			source := ""
+			if r.source != "built-in" {
+				source = stoppedStyle.Render(" (" + r.source + ")")
+			}
+			fmt.Printf("  %-12s %s%s\n", detailAttrStyle.Render(n), r.Description, source)
+		}
+		if dir != "" {
+			fmt.Printf("\nAdd your own recipes as JSON files in %s.\n", dir)
+		}
+		return
+	}
+	r, ok := recipes[fs.Arg(0)]
+	if !ok {
+		fmt.Printf("Error: unknown recipe '%s'. Run 'plate add' to list recipes.\n", fs.Arg(0))
+		os.Exit(1)
+	}
+
+	configPath := "plate.config.json"
+	if v := fs.Lookup("config").Value.String(); v != "" {
+		configPath = v
+	}
+	plateConfig := mustLoadConfig(configPath)
+	target := configPath
+	if *local {
+		target = localConfigPath(configPath)
+	} else if !isFileSource(configPath) {
+		fmt.Println("Error: The config isn't a local file. Use --local to add the service to your local override file.")
+		os.Exit(1)
+	}
+	if len(r.Stack) > 0 {
+		if *pickVersion {
+			fmt.Printf("Error: %s is a stack; --pick-version only works for a single service.\n", r.name)
+			os.Exit(1)
+		}
+		addRecipeStack(plateConfig, r, *name, target, *local)
+		return
+	}
+
+	entry, err := recipeService(plateConfig, r, *name, func(port int) bool { return !portFree(port) })
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if svc, _ := r.serviceConfig(); *pickVersion && !svc.isProcess() {
+		tag, err := pickTag(imageName(svc), svc.Version)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		if tag == "" {
+			fmt.Println("Nothing changed.")
+			return
+		}
+		entry["version"] = tag
+	}
+	if _, err := os.Stat(target); *local && os.IsNotExist(err) {
+		os.WriteFile(target, []byte("{\"services\": []}\n"), 0644)
+	}
+	err = editConfigServices(target, func(services []any) []any { return append(services, entry) })
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	where := ""
+	if port, ok := entry["port"]; ok {
+		where = fmt.Sprintf(" on port %v", port)
+	}
+	fmt.Printf("%s Added %s (%s)%s to %s.\n", successStyle.Render("✓"), entry["name"], entry["type"], where, target)
+}
+
+// addRecipeStack adds a stack recipe's members and the entry referencing
+// them to the config file at target.
+func addRecipeStack(cfg PlateConfig, r recipe, name, target string, local bool) {
+	entry, members, err := recipeStack(cfg, r, name, func(port int) bool { return !portFree(port) })
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if _, err := os.Stat(target); local && os.IsNotExist(err) {
+		os.WriteFile(target, []byte("{\"services\": []}\n"), 0644)
+	}
+	err = editConfig(target, func(raw map[string]any) {
+		stacks, _ := raw["stacks"].(map[string]any)
+		if stacks == nil {
+			stacks = map[string]any{}
+		}
+		stacks[entry["name"].(string)] = members
+		raw["stacks"] = stacks
+		services, _ := raw["services"].([]any)
+		raw["services"] = append(services, entry)
+	})
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("%s Added the stack %s to %s:\n", successStyle.Render("✓"), entry["name"], target)
+	for _, m := range members {
+		member := m.(map[string]any)
+		where := ""
+		if port, ok := member["port"]; ok {
+			where = fmt.Sprintf(" on port %v", port)
+		}
+		fmt.Printf("  %s-%s (%s)%s\n", entry["name"], member["name"], member["type"], where)
+	}
+}
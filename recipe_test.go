@@ -0,0 +1,103 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadRecipes(t *testing.T) {
+	dir := t.TempDir()
+	custom := `{"description": "Postgres 16", "service": {"type": "postgres", "name": "db", "version": "16-alpine", "port": 5440}}`
+	if err := os.WriteFile(filepath.Join(dir, "postgres.json"), []byte(custom), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	recipes, err := loadRecipes(dir)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for _, name := range []string{"postgres", "mysql", "redis", "mongodb", "jaeger", "grafana"} {
+		if _, ok := recipes[name]; !ok {
+			t.Errorf("Expected recipe %s", name)
+		}
+	}
+	if got := recipes["postgres"].Service["version"]; got != "16-alpine" {
+		t.Errorf("Expected the user's postgres recipe to win, got version %v", got)
+	}
+
+	bad := `{"service": {"type": "kafka", "name": "events", "port": 9092}}`
+	if err := os.WriteFile(filepath.Join(dir, "kafka.json"), []byte(bad), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadRecipes(dir); err == nil {
+		t.Errorf("Expected an error for a recipe with an unknown service type")
+	}
+}
+
+func TestRecipeService(t *testing.T) {
+	recipes, err := loadRecipes("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := PlateConfig{Services: []ServiceConfig{
+		{Type: "redis", Name: "cache", Port: 6380},
+		{Type: "redis", Name: "cache-2", Port: 6381},
+	}}
+	bound := map[int]bool{6382: true}
+	inUse := func(port int) bool { return bound[port] }
+
+	tests := []struct {
+		name         string
+		expectedName string
+		expectedPort int
+		wantErr      bool
+	}{
+		{"", "cache-3", 6383, false},
+		{"sessions", "sessions", 6383, false},
+		{"cache", "", 0, true},
+	}
+	for _, tt := range tests {
+		entry, err := recipeService(cfg, recipes["redis"], tt.name, inUse)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%q: Expected error %v, got %v", tt.name, tt.wantErr, err)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+		if entry["name"] != tt.expectedName || entry["port"] != tt.expectedPort || entry["type"] != "redis" {
+			t.Errorf("%q: Expected %s on port %d, got %v", tt.name, tt.expectedName, tt.expectedPort, entry)
+		}
+	}
+}
+
+func TestRecipeStack(t *testing.T) {
+	recipes, err := loadRecipes("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := PlateConfig{
+		Services: []ServiceConfig{{Type: "mailpit", Name: "debug-tools-mail", Port: 1025, UIPort: 8025, group: "debug-tools"}},
+		Stacks:   map[string][]ServiceConfig{"debug-tools": {{Type: "mailpit", Name: "mail"}}},
+	}
+	inUse := func(port int) bool { return port == 8026 }
+
+	entry, members, err := recipeStack(cfg, recipes["debug-tools"], "", inUse)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if entry["name"] != "debug-tools-2" || entry["stack"] != "debug-tools-2" {
+		t.Errorf("Expected a numbered entry for the new stack, got %v", entry)
+	}
+	if len(members) != 2 {
+		t.Fatalf("Expected 2 members, got %v", members)
+	}
+	mail := members[0].(map[string]any)
+	if mail["name"] != "mail" || mail["port"] != 1026 || mail["uiPort"] != 8027 {
+		t.Errorf("Expected mail on free SMTP and UI ports, got %v", mail)
+	}
+	if _, _, err := recipeStack(cfg, recipes["debug-tools"], "debug-tools", inUse); err == nil {
+		t.Errorf("Expected an error for a taken stack name")
+	}
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// --- REDIS USERS ---
+
+// redisDatabases is how many logical databases redis has by default.
+const redisDatabases = 16
+
+var redisUserPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// RedisUserConfig is an ACL user Plate creates in a redis service, so apps
+// sharing one container can have their own credentials.
+type RedisUserConfig struct {
+	Name     string `json:"name"`
+	Password string `json:"password,omitempty"`
+	// Keys are the key patterns the user may access, such as "orders:*".
+	// They default to all keys.
+	Keys []string `json:"keys,omitempty"`
+	// Commands are ACL rules such as "+@all" or "-flushall", and default to
+	// all commands.
+	Commands []string `json:"commands,omitempty"`
+	// DB is the logical database in the user's connection string, and
+	// defaults to the service's.
+	DB *int `json:"db,omitempty"`
+}
+
+func (u RedisUserConfig) password() string {
+	if u.Password == "" {
+		return defaultDatabasePassword
+	}
+	return u.Password
+}
+
+// validateRedis checks the db index and users of every service.
+func validateRedis(cfg PlateConfig) error {
+	validDB := func(db int) bool { return db >= 0 && db < redisDatabases }
+	for _, svc := range cfg.Services {
+		if (svc.DB != 0 || len(svc.Users) > 0) && svc.Type != "redis" {
+			return fmt.Errorf("service %s: db and users are supported for redis, not %s", svc.Name, svc.Type)
+		}
+		if !validDB(svc.DB) {
+			return fmt.Errorf("service %s: db must be between 0 and %d", svc.Name, redisDatabases-1)
+		}
+		seen := map[string]bool{}
+		for _, u := range svc.Users {
+			switch {
+			case !redisUserPattern.MatchString(u.Name):
+				return fmt.Errorf("service %s: invalid user name '%s'", svc.Name, u.Name)
+			case u.Name == "default":
+				return fmt.Errorf("service %s: the default user can't be changed, Plate uses it for health checks", svc.Name)
+			case seen[u.Name]:
+				return fmt.Errorf("service %s: user '%s' is listed twice", svc.Name, u.Name)
+			case strings.ContainsAny(u.password(), " \t\n"):
+				return fmt.Errorf("service %s: the password of '%s' can't contain whitespace", svc.Name, u.Name)
+			case u.DB != nil && !validDB(*u.DB):
+				return fmt.Errorf("service %s: the db of '%s' must be between 0 and %d", svc.Name, u.Name, redisDatabases-1)
+			}
+			for _, rule := range append(append([]string(nil), u.Keys...), u.Commands...) {
+				if rule == "" || strings.ContainsAny(rule, " \t\n") {
+					return fmt.Errorf("service %s: invalid key pattern or command rule '%s' for '%s'", svc.Name, rule, u.Name)
+				}
+			}
+			seen[u.Name] = true
+		}
+	}
+	return nil
+}
+
+// redisACLRules returns the ACL SETUSER rules of a user. "reset" first, so
+// rules removed from the config are removed from the user too.
+func redisACLRules(u RedisUserConfig) []string {
+	rules := []string{"reset", "on", ">" + u.password()}
+	if len(u.Keys) == 0 {
+		rules = append(rules, "~*")
+	}
+	for _, k := range u.Keys {
+		rules = append(rules, "~"+k)
+	}
+	if len(u.Commands) == 0 {
+		rules = append(rules, "+@all")
+	}
+	rules = append(rules, u.Commands...)
+	return rules
+}
+
+// redisUserURL turns the service's connection string into one for u.
+func redisUserURL(connStr string, u RedisUserConfig) string {
+	parsed, err := url.Parse(connStr)
+	if err != nil {
+		return connStr
+	}
+	parsed.User = url.UserPassword(u.Name, u.password())
+	if u.DB != nil {
+		parsed.Path = redisDBPath(*u.DB)
+	}
+	return parsed.String()
+}
+
+// redisDBPath is the path selecting a logical database in a redis URL.
+func redisDBPath(db int) string {
+	if db == 0 {
+		return ""
+	}
+	return "/" + strconv.Itoa(db)
+}
+
+// redisUserEnvVarName returns the variable u's connection string is
+// exported as, e.g. PLATE_CACHE_ORDERS_URL for "orders" in "cache".
+func redisUserEnvVarName(config ServiceConfig, u RedisUserConfig) string {
+	name := strings.ToUpper(strings.ReplaceAll(u.Name, "-", "_"))
+	return envVarNameFor(config, envKey(config.Name)+"_"+name)
+}
+
+// createRedisUsers sets up the service's ACL users with redis-cli.
+func createRedisUsers(config ServiceConfig, containerID string, poll time.Duration) error {
+	cli := []string{"redis-cli"}
+	if config.TLS {
+		cli = append(cli, "--tls", "--cacert", tlsMountDir+"/ca.crt")
+	}
+	deadline := time.Now().Add(readyTimeout)
+	for _, u := range config.Users {
+		args := append(append(append([]string(nil), cli...), "ACL", "SETUSER", u.Name), redisACLRules(u)...)
+		for {
+			// Error replies are printed, so only "OK" means it worked.
+			output, err := backend.exec(containerID, nil, args...)
+			out := strings.TrimSpace(string(output))
+			if err == nil && out == "OK" {
+				break
+			}
+			if out == "" {
+				out = fmt.Sprint(err)
+			}
+			// Redis refuses commands while it loads its data from disk.
+			if !strings.Contains(out, "LOADING") || time.Now().After(deadline) {
+				return fmt.Errorf("user %s: %s", u.Name, lastLine(out))
+			}
+			time.Sleep(poll)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"reflect"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// --- CONFIG RELOAD ---
+
+// Scripts that edit plate.config.json run `plate reload`, which sends
+// reloadSignal to the TUI holding the project lock, so the changes apply
+// without a restart.
+
+// errNoInstance is returned when no Plate instance holds the project lock.
+var errNoInstance = errors.New("no plate instance is running in this directory")
+
+// configReloadMsg asks the TUI to read its config again.
+type configReloadMsg struct{}
+
+// configReloadedMsg carries the config read again, or why it couldn't be.
+type configReloadedMsg struct {
+	cfg PlateConfig
+	err error
+}
+
+// notifyReload forwards reloadSignal to the program until stop is called.
+// It does nothing where there is no such signal.
+func notifyReload(p *tea.Program) (stop func()) {
+	if reloadSignal == nil {
+		return func() {}
+	}
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, reloadSignal)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-sigs:
+				p.Send(configReloadMsg{})
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(sigs)
+		close(done)
+	}
+}
+
+// reloadConfigCmd reads the config at path again, the way runTUI did.
+func reloadConfigCmd(path string, perBranch bool) tea.Cmd {
+	return func() tea.Msg {
+		cfg, err := loadConfig(path)
+		if err == nil {
+			err = resolveBuilds(&cfg)
+		}
+		if err == nil && perBranch && !cfg.PerBranch {
+			cfg.PerBranch = true
+			err = applyPerBranch(&cfg, ".")
+		}
+		return configReloadedMsg{cfg: cfg, err: err}
+	}
+}
+
+// applyReload brings the services in line with a reloaded config. New
+// services start, running containers whose docker run arguments changed are
+// recreated with their data kept, running processes whose config changed
+// restart, and services gone from the config stop. Read-only instances only
+// take the new config in. It returns a summary for the status bar.
+func (m *model) applyReload(cfg PlateConfig) (tea.Cmd, string) {
+	services := map[string]ServiceConfig{}
+	for _, svc := range cfg.enabledServices() {
+		services[svc.Name] = svc
+	}
+	var cmds []tea.Cmd
+	var added, changed, removed int
+	known := map[string]bool{}
+	for i, itm := range m.items {
+		it := itm.(item)
+		known[it.config.Name] = true
+		svc, ok := services[it.config.Name]
+		if !ok {
+			if !it.removed {
+				removed++
+				it.removed = true
+				cmds = append(cmds, m.setItem(i, it))
+				if !m.readOnly {
+					cmds = append(cmds, m.stopService(it))
+				}
+			}
+			continue
+		}
+		if it.removed {
+			added++
+			it.removed = false
+		}
+		if reflect.DeepEqual(it.config, svc) {
+			cmds = append(cmds, m.setItem(i, it))
+			continue
+		}
+		changed++
+		old := it.config
+		it.config = svc
+		if svc.Watch != nil && old.Watch == nil && !m.readOnly {
+			cmds = append(cmds, watchCmd(svc.Name, svc.Watch.Path, m.intervals.watch))
+		}
+		switch {
+		case m.readOnly:
+		case it.process != nil && !it.stopping:
+			it.stopping, it.restarting = true, true
+			cmds = append(cmds, stopProcessCmd(old, it.process))
+		case !svc.isProcess() && it.status == statusRunning && runArgsChanged(old, svc):
+			it.status = statusResetting
+			cmds = append(cmds, removeContainerCmd(svc.Name, svc, it.containerID, true))
+		}
+		cmds = append(cmds, m.setItem(i, it))
+	}
+
+	st, _ := loadState()
+	for _, svc := range cfg.enabledServices() {
+		if known[svc.Name] {
+			continue
+		}
+		added++
+		index := len(m.items)
+		m.items = append(m.items, item{config: svc, status: statusPending, index: index, pinned: isPinned(svc, st)})
+		cmds = append(cmds, m.bootItem(index))
+		if svc.Watch != nil && !m.readOnly {
+			cmds = append(cmds, watchCmd(svc.Name, svc.Watch.Path, m.intervals.watch))
+		}
+	}
+	m.config = cfg
+	return tea.Batch(cmds...), reloadSummary(added, changed, removed)
+}
+
+// runArgsChanged reports whether a service's container would be created
+// differently under its new config.
+func runArgsChanged(old, updated ServiceConfig) bool {
+	_, oldArgs, oldErr := getDockerRunArgs(old, containerName(old))
+	_, newArgs, newErr := getDockerRunArgs(updated, containerName(updated))
+	return oldErr != nil || newErr != nil || !slices.Equal(oldArgs, newArgs)
+}
+
+// reloadSummary describes what a reload changed, e.g. "Config reloaded: 1
+// added, 2 changed".
+func reloadSummary(added, changed, removed int) string {
+	var parts []string
+	for _, p := range []struct {
+		n    int
+		what string
+	}{{added, "added"}, {changed, "changed"}, {removed, "removed"}} {
+		if p.n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", p.n, p.what))
+		}
+	}
+	if len(parts) == 0 {
+		return "Config reloaded: no service changed"
+	}
+	return "Config reloaded: " + strings.Join(parts, ", ")
+}
+
+// handleReloadCmd asks the TUI running in this directory to read its
+// config again.
+func handleReloadCmd(args []string) {
+	fs := flag.NewFlagSet("reload", flag.ExitOnError)
+	addConfigFlag(fs)
+	fs.Parse(args)
+	// The instance holds the lock next to its config.
+	useStateDirOf(configPathArg(fs))
+	pid, err := runningInstance()
+	if err == nil {
+		err = signalReload(pid)
+	}
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	fmt.Printf("Asked plate (PID %d) to reload its config.\n", pid)
+}
@@ -0,0 +1,16 @@
+//go:build !unix
+
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// reloadSignal is nil: there is no signal to spare on this platform.
+var reloadSignal os.Signal
+
+// signalReload fails, since the TUI can't be signaled here.
+func signalReload(pid int) error {
+	return fmt.Errorf("plate reload needs unix signals; restart plate (PID %d) instead", pid)
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+func TestApplyReload(t *testing.T) {
+	t.Chdir(t.TempDir())
+	db := ServiceConfig{Name: "db", Type: "postgres", Port: 5432}
+	cache := ServiceConfig{Name: "cache", Type: "redis", Port: 6379}
+	m := model{items: []list.Item{
+		item{config: db, status: statusRunning, containerID: "abc", index: 0},
+		item{config: cache, status: statusRunning, containerID: "def", index: 1},
+	}}
+
+	moved := db
+	moved.Port = 5433
+	queue := ServiceConfig{Name: "queue", Type: "redis", Port: 6380}
+	_, summary := m.applyReload(PlateConfig{Services: []ServiceConfig{moved, queue}})
+
+	if summary != "Config reloaded: 1 added, 1 changed, 1 removed" {
+		t.Errorf("Expected a summary of every change, got %q", summary)
+	}
+	if len(m.items) != 3 {
+		t.Fatalf("Expected the new service to be appended, got %d items", len(m.items))
+	}
+	if it := m.items[0].(item); it.status != statusResetting || it.config.Port != 5433 {
+		t.Errorf("Expected db to be recreated on its new port, got %s on %d", it.status, it.config.Port)
+	}
+	if it := m.items[1].(item); !it.removed {
+		t.Errorf("Expected cache to be marked removed")
+	}
+	if it := m.items[2].(item); it.index != 2 || it.status != statusChecking {
+		t.Errorf("Expected queue at index 2 to be checked, got index %d, %s", it.index, it.status)
+	}
+
+	_, summary = m.applyReload(PlateConfig{Services: []ServiceConfig{moved, queue, cache}})
+	if summary != "Config reloaded: 1 added" || m.items[1].(item).removed {
+		t.Errorf("Expected cache to be back, got %q", summary)
+	}
+}
+
+func TestApplyReloadReadOnly(t *testing.T) {
+	t.Chdir(t.TempDir())
+	db := ServiceConfig{Name: "db", Type: "postgres", Port: 5432}
+	m := model{readOnly: true, items: []list.Item{item{config: db, status: statusRunning, containerID: "abc"}}}
+
+	moved := db
+	moved.Port = 5433
+	m.applyReload(PlateConfig{Services: []ServiceConfig{moved}})
+	if it := m.items[0].(item); it.status != statusRunning || it.config.Port != 5433 {
+		t.Errorf("Expected read-only mode to only take the config in, got %s on %d", it.status, it.config.Port)
+	}
+}
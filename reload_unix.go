@@ -0,0 +1,16 @@
+//go:build unix
+
+package main
+
+import (
+	"os"
+	"syscall"
+)
+
+// reloadSignal asks a running TUI to read its config again.
+var reloadSignal os.Signal = syscall.SIGUSR1
+
+// signalReload sends reloadSignal to the Plate instance with the given PID.
+func signalReload(pid int) error {
+	return syscall.Kill(pid, syscall.SIGUSR1)
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// --- REMOTE CONFIGS ---
+
+// isURL reports whether a config source is fetched over HTTP.
+func isURL(source string) bool {
+	return strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://")
+}
+
+// isGitSource reports whether a config source lives in a git repository,
+// e.g. git+ssh://git@github.com/acme/platform.git//plate.config.json?ref=main.
+func isGitSource(source string) bool {
+	return strings.HasPrefix(source, "git+")
+}
+
+// isRemoteSource reports whether a config source has to be downloaded.
+func isRemoteSource(source string) bool {
+	return isURL(source) || isGitSource(source)
+}
+
+// splitIntegrity separates an optional #sha256=<hex> pin from a source.
+func splitIntegrity(source string) (string, string) {
+	if i := strings.LastIndex(source, "#sha256="); i >= 0 {
+		return source[:i], strings.ToLower(source[i+len("#sha256="):])
+	}
+	return source, ""
+}
+
+// resolveRelativeSource resolves ref against the source that referenced it.
+func resolveRelativeSource(source, ref string) string {
+	if isRemoteSource(ref) || filepath.IsAbs(ref) {
+		return ref
+	}
+	source, _ = splitIntegrity(source)
+	switch {
+	case isURL(source):
+		parent, err := url.Parse(source)
+		if err != nil {
+			return ref
+		}
+		relative, err := url.Parse(ref)
+		if err != nil {
+			return ref
+		}
+		return parent.ResolveReference(relative).String()
+	case isGitSource(source):
+		repo, file, query := splitGitSource(source)
+		resolved := "git+" + repo + "//" + path.Join(path.Dir(file), ref)
+		if query != "" {
+			resolved += "?" + query
+		}
+		return resolved
+	default:
+		return filepath.Join(filepath.Dir(source), ref)
+	}
+}
+
+// fetchRemoteConfig downloads a remote config, verifies its checksum when the
+// source pins one, and caches it. If the download fails, the cached copy is
+// used so Plate keeps working offline.
+func fetchRemoteConfig(source string) ([]byte, error) {
+	location, wantSum := splitIntegrity(source)
+	// A config can run commands, so one that anyone on the network could
+	// swap has to be pinned.
+	if strings.HasPrefix(location, "http://") && wantSum == "" {
+		return nil, fmt.Errorf("'%s' is fetched over plain http: use https, or pin it with #sha256=<hex>", location)
+	}
+	cachePath, cacheErr := configCachePath(location)
+
+	var data []byte
+	var err error
+	if isGitSource(location) {
+		data, err = fetchGitConfig(location)
+	} else {
+		data, err = fetchHTTPConfig(location)
+	}
+	if err != nil {
+		cached, readErr := os.ReadFile(cachePath)
+		if cacheErr != nil || readErr != nil {
+			return nil, err
+		}
+		fmt.Fprintf(os.Stderr, "Warning: could not fetch '%s' (%v), using cached copy.\n", location, err)
+		data = cached
+	}
+
+	if wantSum != "" {
+		sum := sha256.Sum256(data)
+		if got := hex.EncodeToString(sum[:]); got != wantSum {
+			return nil, fmt.Errorf("checksum mismatch: expected sha256 %s, got %s", wantSum, got)
+		}
+	}
+	if !json.Valid(data) {
+		return nil, fmt.Errorf("downloaded config is not valid JSON")
+	}
+	if cacheErr == nil {
+		if err := os.MkdirAll(filepath.Dir(cachePath), 0755); err == nil {
+			os.WriteFile(cachePath, data, 0644)
+		}
+	}
+	return data, nil
+}
+
+// configCachePath returns where a remote config is cached.
+func configCachePath(location string) (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(location))
+	return filepath.Join(dir, "plate", "configs", hex.EncodeToString(sum[:8])+".json"), nil
+}
+
+// fetchHTTPConfig downloads a config over HTTP.
+func fetchHTTPConfig(location string) ([]byte, error) {
+	resp, err := proxiedClient(10 * time.Second).Get(location)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("server returned %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// splitGitSource splits git+ssh://host/repo.git//path/to/file.json?ref=main
+// into the repository URL, the file path inside it, and the query.
+func splitGitSource(location string) (string, string, string) {
+	location = strings.TrimPrefix(location, "git+")
+	query := ""
+	if i := strings.Index(location, "?"); i >= 0 {
+		location, query = location[:i], location[i+1:]
+	}
+	repo, file := location, "plate.config.json"
+	// Skip the "//" after the scheme when looking for the path separator.
+	if scheme := strings.Index(location, "://"); scheme >= 0 {
+		if i := strings.Index(location[scheme+3:], "//"); i >= 0 {
+			repo, file = location[:scheme+3+i], location[scheme+3+i+2:]
+		}
+	}
+	return repo, file, query
+}
+
+// fetchGitConfig shallow-clones a repository and reads a config file from it.
+func fetchGitConfig(location string) ([]byte, error) {
+	repo, file, query := splitGitSource(location)
+	values, _ := url.ParseQuery(query)
+	if strings.HasPrefix(repo, "-") {
+		return nil, fmt.Errorf("invalid git repository '%s'", repo)
+	}
+	if !filepath.IsLocal(filepath.FromSlash(file)) {
+		return nil, fmt.Errorf("config path '%s' is outside the repository", file)
+	}
+
+	dir, err := os.MkdirTemp("", "plate-config-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	args := []string{"clone", "--quiet", "--depth", "1"}
+	if ref := values.Get("ref"); ref != "" {
+		args = append(args, "--branch", ref)
+	}
+	args = append(args, "--", repo, dir)
+	if output, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("git clone failed: %s", strings.TrimSpace(string(output)))
+	}
+	return os.ReadFile(filepath.Join(dir, filepath.FromSlash(file)))
+}
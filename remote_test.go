@@ -0,0 +1,73 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFetchRemoteConfig(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	body := `{"services": [{"type": "redis", "name": "cache", "version": "7", "port": 6380}]}`
+	online := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !online {
+			http.Error(w, "offline", http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	sum := sha256.Sum256([]byte(body))
+	pinned := server.URL + "/plate.config.json#sha256=" + hex.EncodeToString(sum[:])
+	if _, err := fetchRemoteConfig(pinned); err != nil {
+		t.Fatalf("Expected pinned config to verify, got %v", err)
+	}
+	if _, err := fetchRemoteConfig(server.URL + "/plate.config.json#sha256=deadbeef"); err == nil {
+		t.Errorf("Expected a checksum mismatch error")
+	}
+
+	online = false
+	data, err := fetchRemoteConfig(pinned)
+	if err != nil || string(data) != body {
+		t.Errorf("Expected cached config while offline, got %q, %v", data, err)
+	}
+}
+
+func TestResolveRelativeSource(t *testing.T) {
+	testCases := []struct {
+		source, ref, expected string
+	}{
+		{"configs/plate.config.json", "../base.json", "base.json"},
+		{"https://example.com/a/plate.json#sha256=abc", "base.json", "https://example.com/a/base.json"},
+		{"git+ssh://git@github.com/acme/platform.git//stacks/web.json?ref=v2", "base.json", "git+ssh://git@github.com/acme/platform.git//stacks/base.json?ref=v2"},
+		{"plate.config.json", "https://example.com/base.json", "https://example.com/base.json"},
+	}
+
+	for _, tc := range testCases {
+		if got := resolveRelativeSource(tc.source, tc.ref); got != tc.expected {
+			t.Errorf("Expected %s relative to %s to be %s, got %s", tc.ref, tc.source, tc.expected, got)
+		}
+	}
+}
+
+func TestRejectUnsafeRemoteSources(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	tests := []struct {
+		source, want string
+	}{
+		{"http://example.com/plate.config.json", "plain http"},
+		{"git+--upload-pack=touch /tmp/pwned//plate.config.json", "invalid git repository"},
+		{"git+ssh://git@github.com/acme/platform.git//../../etc/passwd", "outside the repository"},
+		{"git+ssh://git@github.com/acme/platform.git///etc/passwd", "outside the repository"},
+	}
+	for _, tt := range tests {
+		if _, err := fetchRemoteConfig(tt.source); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: Expected an error about %q, got %v", tt.source, tt.want, err)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// --- RENAMED SERVICES ---
+
+// findRenamedContainer looks for the container of a service that was renamed
+// to svc: a container Plate created for this project, labelled with a service
+// the config no longer has, that runs svc's image.
+func findRenamedContainer(cfg PlateConfig, svc ServiceConfig, containers []containerInfo) (containerInfo, bool) {
+	spec, err := getServiceSpec(svc)
+	if err != nil {
+		return containerInfo{}, false
+	}
+	known := map[string]bool{}
+	for _, s := range cfg.Services {
+		known[s.Name] = true
+	}
+	for _, ctr := range containers {
+		if !ctr.managed() || ctr.Labels[labelProject] != cfg.Project || known[ctr.Labels[labelService]] {
+			continue
+		}
+		if imageRepository(ctr.Image) == imageRepository(spec.Image) {
+			return ctr, true
+		}
+	}
+	return containerInfo{}, false
+}
+
+// relinkContainer hands the container of a renamed service over to svc. It
+// takes svc's container name and is recorded as adopted by svc, since docker
+// can't change the service label it was created with.
+func relinkContainer(svc ServiceConfig, ctr containerInfo) error {
+	if name := containerName(svc); ctr.Name != name {
+		if err := backend.rename(ctr.Name, name); err != nil {
+			return fmt.Errorf("could not rename %s: %w", ctr.Name, err)
+		}
+	}
+	return updateState(func(st *plateState) {
+		if st.Adopted == nil {
+			st.Adopted = map[string]string{}
+		}
+		for service, id := range st.Adopted {
+			if id == ctr.ID {
+				delete(st.Adopted, service)
+			}
+		}
+		st.Adopted[svc.Name] = ctr.ID
+	})
+}
+
+func relinkContainerCmd(service string, config ServiceConfig, ctr containerInfo) tea.Cmd {
+	return func() tea.Msg {
+		err := relinkContainer(config, ctr)
+		recordAction("link", config.Name, ctr.Name, "", err)
+		return externalResolvedMsg{service: service, err: err}
+	}
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// --- TEMPLATE RENDERING ---
+
+// renderService is what a template sees of a container service.
+type renderService struct {
+	Name     string
+	Type     string
+	Host     string
+	Port     int
+	User     string
+	Password string
+	Database string // the database in the URL, or redis's logical database
+	URL      string
+	// Databases are the service's extra databases, by name.
+	Databases map[string]renderDatabase
+}
+
+// renderDatabase is one of a service's extra databases.
+type renderDatabase struct {
+	Name     string
+	User     string
+	Password string
+	URL      string
+}
+
+// renderData is the data a template is executed with.
+type renderData struct {
+	Project  string
+	Services map[string]renderService // by service name
+	Env      map[string]string        // the variables 'plate env' prints
+}
+
+// urlParts returns the user, password, and database of a connection string.
+func urlParts(connStr string) (user, password, database string) {
+	u, err := url.Parse(connStr)
+	if err != nil {
+		return "", "", ""
+	}
+	if u.User != nil {
+		user = u.User.Username()
+		password, _ = u.User.Password()
+	}
+	return user, password, strings.TrimPrefix(u.Path, "/")
+}
+
+// renderDataFor collects the connection details of the config's container
+// services.
+func renderDataFor(cfg PlateConfig) renderData {
+	data := renderData{Project: cfg.Project, Services: map[string]renderService{}, Env: map[string]string{}}
+	for _, kv := range serviceEnv(cfg) {
+		k, v, _ := strings.Cut(kv, "=")
+		data.Env[k] = v
+	}
+	for _, svc := range cfg.containerServices() {
+		connStr, err := getConnectionString(svc)
+		if err != nil {
+			continue
+		}
+		s := renderService{Name: svc.Name, Type: svc.Type, Host: serviceHost(svc), Port: svc.Port, URL: connStr, Databases: map[string]renderDatabase{}}
+		s.User, s.Password, s.Database = urlParts(connStr)
+		for _, db := range svc.Databases {
+			dbURL := databaseURL(connStr, db)
+			user, password, _ := urlParts(dbURL)
+			s.Databases[db.Name] = renderDatabase{Name: db.Name, User: user, Password: password, URL: dbURL}
+		}
+		data.Services[svc.Name] = s
+	}
+	return data
+}
+
+// renderTemplate executes a Go template with data. Besides .Services and
+// .Env, templates can call `service "main-db"` and `env "PLATE_MAIN_DB_URL"`,
+// which fail on names that don't exist rather than rendering nothing.
+func renderTemplate(name, text string, data renderData) ([]byte, error) {
+	funcs := template.FuncMap{
+		"service": func(name string) (renderService, error) {
+			s, ok := data.Services[name]
+			if !ok {
+				return s, fmt.Errorf("no container service named '%s'", name)
+			}
+			return s, nil
+		},
+		"env": func(key string) (string, error) {
+			v, ok := data.Env[key]
+			if !ok {
+				return "", fmt.Errorf("no variable named %s (see 'plate env')", key)
+			}
+			return v, nil
+		},
+	}
+	tmpl, err := template.New(name).Funcs(funcs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, data); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// handleRenderCmd renders a template with the services' connection details,
+// for frameworks that read them from a config file rather than the env.
+func handleRenderCmd(args []string) {
+	fs := flag.NewFlagSet("render", flag.ExitOnError)
+	addConfigFlag(fs)
+	output := fs.String("o", "", "file to write instead of printing it")
+	force := fs.Bool("force", false, "overwrite the output file")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		fmt.Println("Usage: plate render [-o file] [--force] [--config path] <template>")
+		os.Exit(exitUsage)
+	}
+	path := fs.Arg(0)
+	text, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	plateConfig := mustLoadConfig(configPathArg(fs))
+
+	rendered, err := renderTemplate(filepath.Base(path), string(text), renderDataFor(plateConfig))
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if *output == "" {
+		os.Stdout.Write(rendered)
+		return
+	}
+	if filepath.Clean(*output) == filepath.Clean(path) {
+		fmt.Println("Error: The output would overwrite the template.")
+		os.Exit(exitUsage)
+	}
+	if err := writeExportFile(*output, rendered, *force); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("%s Wrote %s\n", successStyle.Render("✓"), *output)
+}
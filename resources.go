@@ -0,0 +1,227 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// --- RESOURCE HISTORY ---
+
+const (
+	// statsWindow is how many samples are kept per service.
+	statsWindow = 60
+
+	// The sparklines span at least this much, see sparkline.
+	cpuSparkRange = 10       // percent
+	memSparkRange = 16 << 20 // bytes
+)
+
+// sparkBlocks draw a sparkline from lowest to highest.
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// resourceSample is one reading of a service's CPU and memory use.
+type resourceSample struct {
+	cpu float64 // percent of one core
+	mem float64 // bytes
+}
+
+type statsTickMsg struct{}
+
+// statsSampledMsg carries one sample per service that could be measured,
+// and the names of all services that were running.
+type statsSampledMsg struct {
+	samples map[string]resourceSample
+	running map[string]bool
+}
+
+// statsTickCmd schedules the next sample.
+func statsTickCmd(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(time.Time) tea.Msg { return statsTickMsg{} })
+}
+
+// sampleStatsCmd measures every running container with docker stats and
+// every running process with ps.
+func sampleStatsCmd(items []item) tea.Cmd {
+	containers := map[string]string{}
+	processes := map[string]int{}
+	running := map[string]bool{}
+	for _, it := range items {
+		switch {
+		case it.process != nil:
+			processes[it.config.Name] = it.process.cmd.Process.Pid
+		case it.status == statusRunning && it.containerID != "":
+			containers[it.containerID] = it.config.Name
+		default:
+			continue
+		}
+		running[it.config.Name] = true
+	}
+	return func() tea.Msg {
+		samples := map[string]resourceSample{}
+		if len(containers) > 0 {
+			var ids []string
+			for id := range containers {
+				ids = append(ids, id)
+			}
+			for id, s := range parseDockerStats(backend.stats(ids...)) {
+				if name, ok := containers[id]; ok {
+					samples[name] = s
+				}
+			}
+		}
+		for name, pid := range processes {
+			if s, err := sampleProcess(pid); err == nil {
+				samples[name] = s
+			}
+		}
+		return statsSampledMsg{samples: samples, running: running}
+	}
+}
+
+// parseDockerStats reads "container\tCPU%\tused / limit" lines.
+func parseDockerStats(output string) map[string]resourceSample {
+	samples := map[string]resourceSample{}
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(strings.TrimSpace(line), "\t")
+		if len(fields) != 3 {
+			continue
+		}
+		cpu, err := strconv.ParseFloat(strings.TrimSuffix(fields[1], "%"), 64)
+		if err != nil {
+			continue
+		}
+		used, _, _ := strings.Cut(fields[2], "/")
+		mem, err := parseByteSize(strings.TrimSpace(used))
+		if err != nil {
+			continue
+		}
+		samples[fields[0]] = resourceSample{cpu: cpu, mem: mem}
+	}
+	return samples
+}
+
+// parseByteSize parses sizes like "512B", "12.5MiB" or "1.2GB".
+func parseByteSize(s string) (float64, error) {
+	units := []struct {
+		suffix string
+		factor float64
+	}{
+		{"KiB", 1 << 10}, {"MiB", 1 << 20}, {"GiB", 1 << 30}, {"TiB", 1 << 40},
+		{"kB", 1e3}, {"KB", 1e3}, {"MB", 1e6}, {"GB", 1e9}, {"TB", 1e12}, {"B", 1},
+	}
+	for _, u := range units {
+		if n, ok := strings.CutSuffix(s, u.suffix); ok {
+			v, err := strconv.ParseFloat(strings.TrimSpace(n), 64)
+			return v * u.factor, err
+		}
+	}
+	return 0, fmt.Errorf("unknown size %q", s)
+}
+
+// formatByteSize renders bytes with a binary unit, e.g. "12.5 MiB".
+func formatByteSize(b float64) string {
+	units := []string{"B", "KiB", "MiB", "GiB", "TiB"}
+	i := 0
+	for b >= 1024 && i < len(units)-1 {
+		b /= 1024
+		i++
+	}
+	if i == 0 {
+		return fmt.Sprintf("%.0f %s", b, units[i])
+	}
+	return fmt.Sprintf("%.1f %s", b, units[i])
+}
+
+// sampleProcess measures a process with ps, which reports its CPU use and
+// resident memory in KiB.
+func sampleProcess(pid int) (resourceSample, error) {
+	output, err := exec.Command("ps", "-o", "pcpu=,rss=", "-p", strconv.Itoa(pid)).Output()
+	if err != nil {
+		return resourceSample{}, err
+	}
+	fields := strings.Fields(string(output))
+	if len(fields) != 2 {
+		return resourceSample{}, fmt.Errorf("unexpected ps output %q", output)
+	}
+	cpu, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return resourceSample{}, err
+	}
+	rss, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil {
+		return resourceSample{}, err
+	}
+	return resourceSample{cpu: cpu, mem: rss * 1024}, nil
+}
+
+// recordSamples appends new samples to the history, keeping the last
+// statsWindow per service. Services that no longer run lose their history,
+// so a restart starts a fresh chart.
+func recordSamples(history map[string][]resourceSample, samples map[string]resourceSample, running map[string]bool) {
+	for name := range history {
+		if !running[name] {
+			delete(history, name)
+		}
+	}
+	for name, s := range samples {
+		h := append(history[name], s)
+		if len(h) > statsWindow {
+			h = h[len(h)-statsWindow:]
+		}
+		history[name] = h
+	}
+}
+
+// sparkline draws values as block characters scaled between their lowest
+// and highest value. The scale spans at least minRange (and doesn't go below
+// zero), so small jitter doesn't fill the whole height.
+func sparkline(values []float64, minRange float64) string {
+	if len(values) == 0 {
+		return ""
+	}
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo, hi = math.Min(lo, v), math.Max(hi, v)
+	}
+	if hi-lo < minRange {
+		lo = math.Max(0, hi-minRange)
+		hi = lo + minRange
+	}
+	var b strings.Builder
+	for _, v := range values {
+		i := 0
+		if hi > lo {
+			i = int(math.Round((v - lo) / (hi - lo) * float64(len(sparkBlocks)-1)))
+		}
+		b.WriteRune(sparkBlocks[max(0, min(i, len(sparkBlocks)-1))])
+	}
+	return b.String()
+}
+
+// renderResources shows CPU and memory sparklines with the latest and peak
+// values.
+func renderResources(history []resourceSample) string {
+	if len(history) == 0 {
+		return ""
+	}
+	cpu := make([]float64, len(history))
+	mem := make([]float64, len(history))
+	peakCPU, peakMem := 0.0, 0.0
+	for i, s := range history {
+		cpu[i], mem[i] = s.cpu, s.mem
+		peakCPU, peakMem = math.Max(peakCPU, s.cpu), math.Max(peakMem, s.mem)
+	}
+	last := history[len(history)-1]
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("%s %s %s\n", detailAttrStyle.Render("CPU"), downloadingStyle.Render(sparkline(cpu, cpuSparkRange)),
+		detailValStyle.Render(fmt.Sprintf("%.1f%% (peak %.1f%%)", last.cpu, peakCPU))))
+	b.WriteString(fmt.Sprintf("%s %s %s\n", detailAttrStyle.Render("Mem"), successStyle.Render(sparkline(mem, memSparkRange)),
+		detailValStyle.Render(fmt.Sprintf("%s (peak %s)", formatByteSize(last.mem), formatByteSize(peakMem)))))
+	return b.String()
+}
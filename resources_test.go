@@ -0,0 +1,73 @@
+package main
+
+import "testing"
+
+func TestParseDockerStats(t *testing.T) {
+	output := "abc123\t12.50%\t100MiB / 7.6GiB\ndef456\t0.00%\t512KiB / 1GB\nbroken line\n"
+	samples := parseDockerStats(output)
+	if len(samples) != 2 {
+		t.Fatalf("Expected 2 samples, got %d", len(samples))
+	}
+	if s := samples["abc123"]; s.cpu != 12.5 || s.mem != 100<<20 {
+		t.Errorf("Expected 12.5%% and 100 MiB, got %+v", s)
+	}
+	if s := samples["def456"]; s.mem != 512<<10 {
+		t.Errorf("Expected 512 KiB, got %+v", s)
+	}
+}
+
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected float64
+		wantErr  bool
+	}{
+		{"0B", 0, false},
+		{"1.5GiB", 1.5 * (1 << 30), false},
+		{"12kB", 12000, false},
+		{"3 MB", 3e6, false},
+		{"lots", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseByteSize(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: Expected error %v, got %v", tt.input, tt.wantErr, err)
+			continue
+		}
+		if !tt.wantErr && got != tt.expected {
+			t.Errorf("%s: Expected %v, got %v", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		values   []float64
+		minRange float64
+		expected string
+	}{
+		{nil, 10, ""},
+		{[]float64{0, 5, 10}, 10, "▁▅█"},
+		{[]float64{1, 1, 1}, 10, "▂▂▂"},
+		{[]float64{100, 150, 200}, 10, "▁▅█"},
+		{[]float64{195, 200}, 10, "▅█"},
+	}
+	for _, tt := range tests {
+		if got := sparkline(tt.values, tt.minRange); got != tt.expected {
+			t.Errorf("%v: Expected %s, got %s", tt.values, tt.expected, got)
+		}
+	}
+}
+
+func TestRecordSamples(t *testing.T) {
+	history := map[string][]resourceSample{"gone": {{cpu: 1}}}
+	for i := 0; i < statsWindow+5; i++ {
+		recordSamples(history, map[string]resourceSample{"db": {cpu: float64(i)}}, map[string]bool{"db": true, "cache": true})
+	}
+	if _, ok := history["gone"]; ok {
+		t.Errorf("Expected the history of a stopped service to be dropped")
+	}
+	if h := history["db"]; len(h) != statsWindow || h[len(h)-1].cpu != statsWindow+4 {
+		t.Errorf("Expected the last %d samples, got %d ending in %v", statsWindow, len(h), h[len(h)-1])
+	}
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// --- REVERSE PROXY SERVICE ---
+
+// The "proxy" service type runs nginx in front of the other services, so a
+// project with several web apps is reached on one port with nice paths.
+const (
+	nginxPort = 80
+	// proxyUpstreamHost is where the proxy reaches the services' published
+	// ports, which works for process services too.
+	proxyUpstreamHost = "host.docker.internal"
+	// nginxConfEnv carries the generated nginx config into the container.
+	nginxConfEnv = "PLATE_NGINX_CONF"
+)
+
+// RouteConfig sends requests for a path, and optionally a host name, to a
+// service of the config.
+type RouteConfig struct {
+	// Path is the URL prefix, e.g. "/api". It defaults to "/".
+	Path string `json:"path,omitempty"`
+	// Host limits the route to requests for this host name, such as
+	// api.localhost, which browsers resolve to this machine.
+	Host    string `json:"host,omitempty"`
+	Service string `json:"service"`
+	// Port is the port to send to, for a service whose port isn't in the
+	// config, such as a process's dev server. It defaults to the service's.
+	Port int `json:"port,omitempty"`
+	// StripPrefix drops Path from the URL the service sees.
+	StripPrefix bool `json:"stripPrefix,omitempty"`
+
+	// targetPort is Port, or the service's, filled in by resolveRoutes.
+	targetPort int
+}
+
+// routePath returns the route's path, defaulting to "/".
+func (r RouteConfig) routePath() string {
+	if r.Path == "" {
+		return "/"
+	}
+	return r.Path
+}
+
+// resolveRoutes checks every proxy's routes and fills in the ports they
+// send to.
+func resolveRoutes(cfg *PlateConfig) error {
+	ports := map[string]int{}
+	for _, svc := range cfg.Services {
+		ports[svc.Name] = svc.Port
+	}
+	for i := range cfg.Services {
+		svc := &cfg.Services[i]
+		if svc.Type != "proxy" {
+			if len(svc.Routes) > 0 {
+				return fmt.Errorf("service %s: routes are for proxy services, not %s", svc.Name, svc.Type)
+			}
+			continue
+		}
+		if len(svc.Routes) == 0 {
+			return fmt.Errorf("service %s: a proxy needs at least one route", svc.Name)
+		}
+		seen := map[string]bool{}
+		for j := range svc.Routes {
+			r := &svc.Routes[j]
+			if !strings.HasPrefix(r.routePath(), "/") || strings.ContainsAny(r.routePath(), " ;{}") {
+				return fmt.Errorf("service %s: route path %q must start with / and have no spaces, ; or braces", svc.Name, r.Path)
+			}
+			if strings.ContainsAny(r.Host, " ;{}/") {
+				return fmt.Errorf("service %s: route host %q must be a host name", svc.Name, r.Host)
+			}
+			key := r.Host + " " + strings.TrimSuffix(r.routePath(), "/")
+			if seen[key] {
+				return fmt.Errorf("service %s: two routes for %s%s", svc.Name, r.Host, r.routePath())
+			}
+			seen[key] = true
+			port, ok := ports[r.Service]
+			switch {
+			case !ok:
+				return fmt.Errorf("service %s: route %s goes to '%s', which isn't a service in the config", svc.Name, r.routePath(), r.Service)
+			case r.Service == svc.Name:
+				return fmt.Errorf("service %s: route %s goes to the proxy itself", svc.Name, r.routePath())
+			case r.Port != 0:
+				port = r.Port
+			case port == 0:
+				return fmt.Errorf("service %s: route %s needs a port, since %s has none in the config", svc.Name, r.routePath(), r.Service)
+			}
+			r.targetPort = port
+		}
+	}
+	return nil
+}
+
+// nginxConfig renders the server blocks for a proxy's routes, one per host
+// name. Routes without a host go to the default server.
+func nginxConfig(routes []RouteConfig) string {
+	byHost := map[string][]RouteConfig{}
+	for _, r := range routes {
+		byHost[r.Host] = append(byHost[r.Host], r)
+	}
+	hosts := make([]string, 0, len(byHost))
+	for host := range byHost {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	var b strings.Builder
+	b.WriteString("# Generated by Plate from the proxy's routes.\n")
+	// Lets dev servers' websockets, such as hot reload, through.
+	b.WriteString("map $http_upgrade $connection_upgrade {\n  default upgrade;\n  '' close;\n}\n")
+	for _, host := range hosts {
+		b.WriteString("server {\n")
+		if host == "" {
+			b.WriteString(fmt.Sprintf("  listen %d default_server;\n  server_name _;\n", nginxPort))
+		} else {
+			b.WriteString(fmt.Sprintf("  listen %d;\n  server_name %s;\n", nginxPort, host))
+		}
+		for _, r := range byHost[host] {
+			upstream := "http://" + net.JoinHostPort(proxyUpstreamHost, strconv.Itoa(r.targetPort))
+			path := r.routePath()
+			if r.StripPrefix && path != "/" {
+				// With a URI on proxy_pass, nginx replaces the matched prefix.
+				path = strings.TrimSuffix(path, "/") + "/"
+				upstream += "/"
+			}
+			b.WriteString(fmt.Sprintf("  location %s {\n", path))
+			b.WriteString(fmt.Sprintf("    proxy_pass %s;\n", upstream))
+			b.WriteString("    proxy_http_version 1.1;\n")
+			b.WriteString("    proxy_set_header Upgrade $http_upgrade;\n")
+			b.WriteString("    proxy_set_header Connection $connection_upgrade;\n")
+			b.WriteString("    proxy_set_header Host $host;\n")
+			b.WriteString("    proxy_set_header X-Forwarded-For $proxy_add_x_forwarded_for;\n")
+			b.WriteString("    proxy_set_header X-Forwarded-Proto $scheme;\n")
+			b.WriteString("  }\n")
+		}
+		b.WriteString("}\n")
+	}
+	return b.String()
+}
+
+// nginxSpec describes an nginx container serving conf. The config is
+// passed in the env, so changing it shows up as drift, and written out
+// before nginx starts.
+func nginxSpec(conf string) serviceSpec {
+	return serviceSpec{
+		Image:         "nginx",
+		ContainerPort: nginxPort,
+		Env:           []string{nginxConfEnv + "=" + conf},
+		Args:          []string{"sh", "-c", `printf '%s\n' "$` + nginxConfEnv + `" > /etc/nginx/conf.d/default.conf && exec nginx -g 'daemon off;'`},
+	}
+}
+
+// proxySpec describes the container of a "proxy" service.
+func proxySpec(config ServiceConfig) serviceSpec {
+	return nginxSpec(nginxConfig(config.Routes))
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestResolveRoutes(t *testing.T) {
+	cfg := PlateConfig{Services: []ServiceConfig{
+		{Type: "proxy", Name: "edge", Version: "1.27-alpine", Port: 8080, Routes: []RouteConfig{
+			{Path: "/api", Service: "api", StripPrefix: true},
+			{Service: "web", Port: 5173},
+			{Host: "grafana.localhost", Service: "otel", Port: 3000},
+		}},
+		{Type: "process", Name: "api", Command: "go run .", Port: 3001},
+		{Type: "process", Name: "web", Command: "npm run dev"},
+		{Type: "observability", Name: "otel", Version: "latest", Port: 4318},
+	}}
+	if err := resolveRoutes(&cfg); err != nil {
+		t.Fatalf("Expected the routes to resolve, got %v", err)
+	}
+	conf := nginxConfig(cfg.Services[0].Routes)
+	for _, want := range []string{
+		"location /api/ {\n    proxy_pass http://host.docker.internal:3001/;",
+		"location / {\n    proxy_pass http://host.docker.internal:5173;",
+		"server_name grafana.localhost;",
+		"listen 80 default_server;",
+	} {
+		if !strings.Contains(conf, want) {
+			t.Errorf("Expected the config to contain %q, got:\n%s", want, conf)
+		}
+	}
+
+	spec, err := getServiceSpec(cfg.Services[0])
+	if err != nil || spec.Image != "nginx:1.27-alpine" || !slices.Contains(spec.Env, nginxConfEnv+"="+conf) {
+		t.Errorf("Expected nginx with the config in its env, got %+v, %v", spec, err)
+	}
+
+	bad := []RouteConfig{
+		{Service: "web"},
+		{Service: "nope", Port: 80},
+		{Service: "edge", Port: 80},
+		{Path: "api", Service: "api"},
+	}
+	for _, r := range bad {
+		cfg.Services[0].Routes = []RouteConfig{r}
+		if err := resolveRoutes(&cfg); err == nil {
+			t.Errorf("Expected an error for route %+v", r)
+		}
+	}
+	cfg.Services[0].Routes = []RouteConfig{{Path: "/a", Service: "api"}, {Path: "/a/", Service: "api"}}
+	if err := resolveRoutes(&cfg); err == nil {
+		t.Errorf("Expected an error for two routes with the same path")
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// --- ROOTLESS DOCKER ---
+
+// privilegedPortOffset moves a port below the unprivileged range to a
+// familiar alternative, such as 80 to 8080 and 443 to 8443.
+const privilegedPortOffset = 8000
+
+var privilegedPortPattern = regexp.MustCompile(`cannot expose privileged port (\d+)`)
+
+// daemonMode describes how the docker daemon maps containers to the host.
+type daemonMode struct {
+	// rootless daemons (and rootless podman) run as the user, so they can't
+	// publish ports below the host's unprivileged port start.
+	rootless bool
+	// userns-remap runs container root as an unprivileged host user, which
+	// can't read files that only their owner may read.
+	userns bool
+}
+
+// parseDaemonMode reads the daemon mode from the JSON list of security
+// options docker info reports, e.g. ["name=seccomp,profile=builtin","name=rootless"].
+func parseDaemonMode(securityOptions string) daemonMode {
+	var options []string
+	json.Unmarshal([]byte(securityOptions), &options)
+	var mode daemonMode
+	for _, opt := range options {
+		for _, field := range strings.Split(opt, ",") {
+			switch field {
+			case "name=rootless":
+				mode.rootless = true
+			case "name=userns":
+				mode.userns = true
+			}
+		}
+	}
+	return mode
+}
+
+// detectDaemonMode asks docker how it runs containers. An unreachable
+// daemon reports the plain mode.
+func detectDaemonMode() daemonMode {
+	output, err := dockerCommand("info", "--format", "{{json .SecurityOptions}}").Output()
+	if err != nil {
+		return daemonMode{}
+	}
+	return parseDaemonMode(strings.TrimSpace(string(output)))
+}
+
+// unprivilegedPortStart returns the lowest port a user may bind on this
+// host, from the net.ipv4.ip_unprivileged_port_start sysctl on Linux.
+func unprivilegedPortStart() int {
+	data, err := os.ReadFile("/proc/sys/net/ipv4/ip_unprivileged_port_start")
+	if err != nil {
+		return 1024
+	}
+	start, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 1024
+	}
+	return start
+}
+
+// lowestPublishablePort returns the lowest host port the daemon can
+// publish: the unprivileged port start for a rootless one, else any port.
+func lowestPublishablePort(mode daemonMode) int {
+	if mode.rootless {
+		return unprivilegedPortStart()
+	}
+	return 0
+}
+
+// privilegedPortAlternative suggests a port to use instead of one the
+// daemon can't publish.
+func privilegedPortAlternative(port int) int {
+	return port + privilegedPortOffset
+}
+
+// privilegedPortError explains a failed start caused by a rootless daemon
+// refusing a privileged port, or returns nil for other failures.
+func privilegedPortError(output string) error {
+	match := privilegedPortPattern.FindStringSubmatch(output)
+	if match == nil {
+		return nil
+	}
+	port, _ := strconv.Atoi(match[1])
+	return fmt.Errorf("rootless docker can't publish port %d; use %d instead ('plate ports' moves it), or lower net.ipv4.ip_unprivileged_port_start", port, privilegedPortAlternative(port))
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseDaemonMode(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  string
+		expected daemonMode
+	}{
+		{"rootful", `["name=apparmor","name=seccomp,profile=builtin","name=cgroupns"]`, daemonMode{}},
+		{"rootless", `["name=seccomp,profile=builtin","name=rootless","name=cgroupns"]`, daemonMode{rootless: true}},
+		{"userns-remap", `["name=seccomp,profile=builtin","name=userns"]`, daemonMode{userns: true}},
+		{"unknown", `null`, daemonMode{}},
+	}
+	for _, tt := range tests {
+		if got := parseDaemonMode(tt.options); got != tt.expected {
+			t.Errorf("%s: Expected %+v, got %+v", tt.name, tt.expected, got)
+		}
+	}
+}
+
+func TestPrivilegedPort(t *testing.T) {
+	output := "docker: Error response from daemon: driver failed programming external connectivity on endpoint plate-web: Error starting userland proxy: error while calling RootlessKit PortManager.AddPort(): cannot expose privileged port 80, you can add 'net.ipv4.ip_unprivileged_port_start=80' to /etc/sysctl.conf (currently 1024)"
+	err := privilegedPortError(output)
+	if err == nil || !strings.Contains(err.Error(), "use 8080") {
+		t.Errorf("Expected a suggestion of port 8080, got %v", err)
+	}
+	if err := privilegedPortError("Conflict. The container name is already in use"); err != nil {
+		t.Errorf("Expected no error for other failures, got %v", err)
+	}
+
+	services := []ServiceConfig{{Name: "web", Port: 80}, {Name: "proxy", Port: 8080}, {Name: "main-db", Port: 5433}}
+	changes := planPortRemap(services, func(int) bool { return false }, 1024)
+	if len(changes) != 1 || changes[0].service != "web" || changes[0].to != 8081 {
+		t.Errorf("Expected web to move past the taken 8080, got %+v", changes)
+	}
+
+	res := checkDaemonMode(PlateConfig{Services: services}, daemonMode{rootless: true}, 1024)
+	if res.ok || !strings.Contains(res.detail, "80 (web, try 8080)") {
+		t.Errorf("Expected the doctor to flag port 80, got %+v", res)
+	}
+	if res := checkDaemonMode(PlateConfig{Services: services}, daemonMode{}, 1024); !res.ok {
+		t.Errorf("Expected a rootful daemon to publish any port, got %+v", res)
+	}
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// --- CONTAINER RUNTIME ---
+
+// containerRuntime is what Plate asks of the container engine to look up,
+// create, control, and watch service containers. The docker CLI implements
+// it; tests and `plate --demo` swap in a fake that needs no daemon.
+//
+// Image builds, `docker events`, the daemon checks (access, rootless), and
+// the commands that work on images, volumes, and bundles (bench, snapshots,
+// images, share, bug-report) still run the docker CLI directly.
+type containerRuntime interface {
+	// containerIDs lists the full IDs of the running containers, or of all
+	// of them with all, that match every docker ps filter, e.g.
+	// "name=^plate-".
+	containerIDs(all bool, filters ...string) ([]string, error)
+	// inspect looks up containers by ID or name, skipping missing ones.
+	inspect(refs ...string) ([]containerInfo, error)
+	imagePresent(image string) bool
+	pull(image string) error
+	// run creates and starts the container called name from the arguments
+	// of getDockerRunArgs, returning its ID.
+	run(name string, args []string) (string, error)
+	start(id string) error
+	stop(id string) error
+	// remove removes a stopped container, or with force a running one too.
+	remove(id string, force bool) error
+	rename(from, to string) error
+	// exec runs command in a running container, feeding it stdin when set,
+	// and returns what it printed.
+	exec(id string, stdin io.Reader, command ...string) ([]byte, error)
+	// stats samples running containers in the "container\tCPU%\tused / limit"
+	// lines parseDockerStats reads.
+	stats(ids ...string) string
+	// top lists a container's processes as docker top prints them.
+	top(id string) (string, error)
+	// inspectJSON returns the full docker inspect document of a container.
+	inspectJSON(id string) ([]byte, error)
+	// version returns the daemon's version and the system it runs on.
+	version() (version, system string, err error)
+	// platform returns where containers run, e.g. "linux/arm64", or "" if
+	// unknown; imagePlatform does the same for a local image.
+	platform() string
+	imagePlatform(image string) string
+	// logs returns the last lines a container wrote.
+	logs(id string, lines int) string
+	// follow streams what a container writes from now on until it stops or
+	// stop is called, at which point done is closed.
+	follow(id string, stdout, stderr io.Writer) (stop func(), done <-chan struct{}, err error)
+}
+
+// backend is the container runtime Plate uses.
+var backend containerRuntime = dockerCLI{}
+
+// dockerCLI is the containerRuntime that runs the docker CLI.
+type dockerCLI struct{}
+
+func (dockerCLI) containerIDs(all bool, filters ...string) ([]string, error) {
+	args := []string{"ps", "-q", "--no-trunc"}
+	if all {
+		args = append(args, "-a")
+	}
+	for _, f := range filters {
+		args = append(args, "--filter", f)
+	}
+	output, err := dockerCommand(args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("could not list containers: %w", err)
+	}
+	return strings.Fields(string(output)), nil
+}
+
+func (dockerCLI) inspect(refs ...string) ([]containerInfo, error) {
+	output, err := dockerCommand(append([]string{"container", "inspect"}, refs...)...).Output()
+	if err != nil && !strings.HasPrefix(strings.TrimSpace(string(output)), "[") {
+		return nil, fmt.Errorf("could not inspect containers: %w", err)
+	}
+	return parseInspectOutput(output)
+}
+
+func (dockerCLI) imagePresent(image string) bool {
+	output, _ := dockerCommand("images", "-q", image).CombinedOutput()
+	return len(output) > 0
+}
+
+func (dockerCLI) pull(image string) error {
+	if output, err := dockerCommand(pullArgs(image)...).CombinedOutput(); err != nil {
+		return pullError(string(output))
+	}
+	return nil
+}
+
+func (dockerCLI) run(name string, args []string) (string, error) {
+	output, err := dockerCommand(args...).CombinedOutput()
+	if err != nil {
+		if err := privilegedPortError(string(output)); err != nil {
+			return "", err
+		}
+		if hint := execFormatHint(string(output)); hint != "" {
+			return "", fmt.Errorf("%s\n%s", lastLine(strings.TrimSpace(string(output))), hint)
+		}
+		return "", fmt.Errorf("%s", strings.TrimSpace(string(output)))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+func (dockerCLI) start(id string) error {
+	return dockerCommand("start", id).Run()
+}
+
+func (dockerCLI) stop(id string) error {
+	return dockerCommand("stop", id).Run()
+}
+
+func (dockerCLI) remove(id string, force bool) error {
+	args := []string{"rm", id}
+	if force {
+		args = []string{"rm", "-f", id}
+	}
+	if output, err := dockerCommand(args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("%s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func (dockerCLI) rename(from, to string) error {
+	if output, err := dockerCommand("rename", from, to).CombinedOutput(); err != nil {
+		return fmt.Errorf("%s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func (dockerCLI) exec(id string, stdin io.Reader, command ...string) ([]byte, error) {
+	args := []string{"exec", id}
+	if stdin != nil {
+		args = []string{"exec", "-i", id}
+	}
+	cmd := dockerCommand(append(args, command...)...)
+	cmd.Stdin = stdin
+	return cmd.CombinedOutput()
+}
+
+func (dockerCLI) stats(ids ...string) string {
+	args := append([]string{"stats", "--no-stream", "--format", "{{.Container}}\t{{.CPUPerc}}\t{{.MemUsage}}"}, ids...)
+	output, _ := dockerCommand(args...).Output()
+	return string(output)
+}
+
+func (dockerCLI) top(id string) (string, error) {
+	output, err := dockerCommand("top", id, "-eo", topColumns).Output()
+	if err != nil {
+		output, err = dockerCommand("top", id).Output()
+	}
+	return string(output), err
+}
+
+func (dockerCLI) inspectJSON(id string) ([]byte, error) {
+	return dockerCommand("inspect", id).Output()
+}
+
+func (dockerCLI) version() (string, string, error) {
+	output, err := dockerCommand("info", "--format", "{{.ServerVersion}}|{{.OperatingSystem}}").Output()
+	if err != nil {
+		return "", "", err
+	}
+	version, system, _ := strings.Cut(strings.TrimSpace(string(output)), "|")
+	return version, system, nil
+}
+
+func (dockerCLI) platform() string {
+	output, err := dockerCommand("info", "--format", "{{.OSType}}/{{.Architecture}}").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+func (dockerCLI) imagePlatform(image string) string {
+	output, err := dockerCommand("image", "inspect", "--format", "{{.Os}}/{{.Architecture}}", image).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+func (dockerCLI) logs(id string, lines int) string {
+	output, _ := dockerCommand("logs", "--tail", fmt.Sprint(lines), id).CombinedOutput()
+	return string(output)
+}
+
+func (dockerCLI) follow(id string, stdout, stderr io.Writer) (func(), <-chan struct{}, error) {
+	cmd := dockerCommand("logs", "-f", "--tail", "0", id)
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+	if err := cmd.Start(); err != nil {
+		return nil, nil, err
+	}
+	done := make(chan struct{})
+	go func() {
+		cmd.Wait()
+		close(done)
+	}()
+	return func() { cmd.Process.Kill() }, done, nil
+}
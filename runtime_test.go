@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/exp/teatest"
+)
+
+// useFakeRuntime makes Plate use a fake runtime for the rest of the test.
+func useFakeRuntime(t *testing.T) *fakeRuntime {
+	t.Helper()
+	f := newFakeRuntime()
+	orig := backend
+	backend = f
+	t.Cleanup(func() { backend = orig })
+	return f
+}
+
+// called reports whether op was done, e.g. "run plate-redis-cache".
+func (f *fakeRuntime) called(op string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Contains(f.calls, op)
+}
+
+// startTUI runs the TUI on a config with one redis service, in a scratch
+// directory so its state files don't leak. setup, when set, scripts the
+// runtime for the service before the TUI starts.
+func startTUI(t *testing.T, setup func(svc ServiceConfig)) (*teatest.TestModel, ServiceConfig) {
+	t.Helper()
+	t.Chdir(t.TempDir())
+	os.WriteFile("plate.config.json", []byte(`{"project": "shop", "services": [
+		{"type": "redis", "name": "cache", "version": "7", "port": 6380}
+	]}`), 0644)
+	cfg, err := loadConfig("plate.config.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if setup != nil {
+		setup(cfg.Services[0])
+	}
+	tm := teatest.NewTestModel(t, initialModel(cfg, false), teatest.WithInitialTermSize(120, 40))
+	t.Cleanup(func() { tm.Quit() })
+	return tm, cfg.Services[0]
+}
+
+// waitForScreen waits until the TUI shows all of texts.
+func waitForScreen(t *testing.T, tm *teatest.TestModel, texts ...string) {
+	t.Helper()
+	teatest.WaitFor(t, tm.Output(), func(out []byte) bool {
+		for _, text := range texts {
+			if !bytes.Contains(out, []byte(text)) {
+				return false
+			}
+		}
+		return true
+	}, teatest.WithDuration(5*time.Second))
+}
+
+func TestTUIStartStopReset(t *testing.T) {
+	fake := useFakeRuntime(t)
+	tm, svc := startTUI(t, nil)
+	name := containerName(svc)
+
+	// The image is pulled and the container created.
+	waitForScreen(t, tm, "✅ Running")
+	if !fake.called("pull "+imageName(svc)) || !fake.called("run "+name) {
+		t.Fatalf("Expected the image to be pulled and the container run, got %v", fake.calls)
+	}
+
+	tm.Type("s")
+	waitForScreen(t, tm, "🛑 Stopped")
+	tm.Type("b")
+	waitForScreen(t, tm, "✅ Running")
+	if !fake.called("start " + name) {
+		t.Errorf("Expected the stopped container to be started again, got %v", fake.calls)
+	}
+
+	tm.Type("r")
+	waitForScreen(t, tm, "Confirm Reset? (y/n)")
+	tm.Type("y")
+	waitForScreen(t, tm, "✅ Running")
+	if !fake.called("rm " + name) {
+		t.Errorf("Expected the reset to remove the container, got %v", fake.calls)
+	}
+	if infos, _ := fake.inspect(name); len(infos) != 1 || infos[0].ID == fmt.Sprintf("%064d", 1) {
+		t.Errorf("Expected a new container after the reset, got %+v", infos)
+	}
+}
+
+func TestTUIFindsExistingContainer(t *testing.T) {
+	fake := useFakeRuntime(t)
+	tm, svc := startTUI(t, func(svc ServiceConfig) {
+		fake.containers = []*containerInfo{{
+			ID:     fmt.Sprintf("%064d", 99),
+			Name:   containerName(svc),
+			State:  "exited",
+			Labels: map[string]string{labelManaged: "true"},
+		}}
+	})
+
+	// A stopped container is left stopped, not recreated.
+	waitForScreen(t, tm, "🛑 Stopped")
+	if fake.called("run " + containerName(svc)) {
+		t.Errorf("Expected the existing container to be used, got %v", fake.calls)
+	}
+}
+
+func TestTUIErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		script func(f *fakeRuntime, svc ServiceConfig)
+		want   string
+	}{
+		{"pull fails", func(f *fakeRuntime, svc ServiceConfig) {
+			f.fail["pull "+imageName(svc)] = errors.New("manifest for redis:7 not found")
+		}, "manifest for redis:7 not found"},
+		{"run fails", func(f *fakeRuntime, svc ServiceConfig) {
+			f.images[imageName(svc)] = true
+			f.fail["run "+containerName(svc)] = errors.New("port is already allocated")
+		}, "port is already allocated"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := useFakeRuntime(t)
+			tm, _ := startTUI(t, func(svc ServiceConfig) { tt.script(fake, svc) })
+			waitForScreen(t, tm, "🔥 Error", tt.want)
+		})
+	}
+}
+
+func TestTUIStopFails(t *testing.T) {
+	fake := useFakeRuntime(t)
+	tm, svc := startTUI(t, nil)
+	waitForScreen(t, tm, "✅ Running")
+
+	fake.mu.Lock()
+	fake.fail["stop "+containerName(svc)] = errors.New("cannot stop container")
+	fake.mu.Unlock()
+	tm.Type("s")
+	waitForScreen(t, tm, "🔥 Error", "cannot stop container")
+
+	tm.Send(tea.Quit())
+	if it := tm.FinalModel(t, teatest.WithFinalTimeout(5*time.Second)).(model).items[0].(item); it.status != statusError {
+		t.Errorf("Expected the service to be in error, got %v", it.status)
+	}
+}
+
+func TestTUIProcessesAndInspect(t *testing.T) {
+	useFakeRuntime(t)
+	tm, svc := startTUI(t, nil)
+	waitForScreen(t, tm, "✅ Running")
+
+	tm.Type("t")
+	waitForScreen(t, tm, "docker-entrypoint.sh")
+	tm.Send(tea.KeyMsg{Type: tea.KeyEsc})
+	tm.Type("i")
+	waitForScreen(t, tm, `"/`+containerName(svc)+`"`)
+}
@@ -0,0 +1,311 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// --- ENVIRONMENT BUNDLES ---
+
+// Names of the entries inside an environment bundle.
+const (
+	bundleConfigName = "plate.config.json"
+	bundleLockName   = "plate.lock.json"
+	bundleDataDir    = "data/"
+)
+
+// envLock pins the exact images (and states) an environment was running.
+type envLock struct {
+	CreatedAt time.Time       `json:"createdAt"`
+	Project   string          `json:"project"`
+	Platform  string          `json:"platform"`
+	Services  []lockedService `json:"services"`
+}
+
+// lockedService is one service in an envLock.
+type lockedService struct {
+	Name    string `json:"name"`
+	Image   string `json:"image"`
+	Digest  string `json:"digest,omitempty"` // repo@sha256:... when the image came from a registry
+	State   string `json:"state"`
+	HasData bool   `json:"hasData,omitempty"`
+}
+
+// imageDigest returns the registry digest of a local image, if it has one.
+func imageDigest(image string) string {
+	output, err := dockerCommand("image", "inspect", "--format", "{{if .RepoDigests}}{{index .RepoDigests 0}}{{end}}", image).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// handleShareCmd bundles the effective config, pinned images, and optionally
+// data snapshots into one archive a teammate can restore.
+func handleShareCmd(args []string) {
+	fs := flag.NewFlagSet("share", flag.ExitOnError)
+	addConfigFlag(fs)
+	output := fs.String("o", "", "archive to write (default plate-env-<project>-<date>.tar.gz)")
+	withData := fs.Bool("with-data", false, "include snapshots of each service's data")
+	fs.Parse(args)
+	plateConfig := mustResolveBuilds(mustLoadConfig(configPathArg(fs)))
+	if *output == "" {
+		*output = fmt.Sprintf("plate-env-%s-%s.tar.gz", plateConfig.Project, time.Now().Format("20060102-150405"))
+	}
+
+	if err := writeEnvBundle(*output, plateConfig, *withData, os.Stdout); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Remove(*output)
+		os.Exit(1)
+	}
+	fmt.Printf("✅ Wrote '%s'. Restore it with 'plate restore-env %s'.\n", *output, *output)
+}
+
+// writeEnvBundle writes the environment bundle for cfg to path.
+func writeEnvBundle(path string, cfg PlateConfig, withData bool, out io.Writer) error {
+	containers, err := listPlateContainers(cfg.Project)
+	if err != nil {
+		return err
+	}
+	matched := serviceContainers(cfg, containers)
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+
+	// The bundle must stand on its own, so the merged config is shared
+	// rather than a reference to a base the teammate may not have.
+	shared := cfg
+	shared.Extends = ""
+	configData, _ := json.MarshalIndent(shared, "", "  ")
+	if err := addBundleFile(tw, bundleConfigName, configData); err != nil {
+		return err
+	}
+
+	lock := envLock{CreatedAt: time.Now().UTC(), Project: cfg.Project, Platform: runtime.GOOS + "/" + runtime.GOARCH}
+	for _, svc := range cfg.containerServices() {
+		locked := lockedService{Name: svc.Name, Image: imageName(svc), Digest: imageDigest(imageName(svc)), State: "missing"}
+		if ctr, ok := matched[svc.Name]; ok {
+			locked.State = ctr.State
+			if withData {
+				fmt.Fprintf(out, "Snapshotting %s...\n", svc.Name)
+				if err := addDataSnapshot(tw, svc, ctr.ID); err != nil {
+					return err
+				}
+				locked.HasData = true
+			}
+		}
+		lock.Services = append(lock.Services, locked)
+	}
+	lockData, _ := json.MarshalIndent(lock, "", "  ")
+	if err := addBundleFile(tw, bundleLockName, lockData); err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// addBundleFile writes an in-memory file into the bundle.
+func addBundleFile(tw *tar.Writer, name string, data []byte) error {
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(data)), ModTime: time.Now()}); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
+
+// addDataSnapshot snapshots a service into a temp file and copies it into
+// the bundle, since tar needs each entry's size up front.
+func addDataSnapshot(tw *tar.Writer, svc ServiceConfig, containerID string) error {
+	tmp, err := os.CreateTemp("", "plate-snapshot-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	if err := snapshotServiceData(svc, containerID, tmp); err != nil {
+		return err
+	}
+	info, err := tmp.Stat()
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	if err := tw.WriteHeader(&tar.Header{Name: bundleDataDir + svc.Name + ".tar.gz", Mode: 0644, Size: info.Size(), ModTime: time.Now()}); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, tmp)
+	return err
+}
+
+// handleRestoreEnvCmd recreates an environment from a bundle made by `plate share`.
+func handleRestoreEnvCmd(args []string) {
+	fs := flag.NewFlagSet("restore-env", flag.ExitOnError)
+	force := fs.Bool("force", false, "overwrite plate.config.json and replace existing containers")
+	ignoreLock := fs.Bool("ignore-lock", false, "run even if another plate instance holds the lock")
+	fs.Parse(args)
+	if fs.NArg() < 1 {
+		fmt.Println("Usage: plate restore-env [--force] [--ignore-lock] <bundle.tar.gz>")
+		os.Exit(exitUsage)
+	}
+	lock := mustAcquireLock(*ignoreLock)
+	defer lock.release()
+
+	if err := restoreEnvBundle(fs.Arg(0), *force, os.Stdout); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		lock.release()
+		os.Exit(1)
+	}
+	fmt.Println("✅ Environment restored. Run 'plate' to start the remaining services.")
+}
+
+// restoreEnvBundle unpacks a bundle into the working directory: it writes
+// the config, pulls the pinned images, and recreates services with data.
+func restoreEnvBundle(path string, force bool, out io.Writer) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("'%s' is not a plate bundle: %w", path, err)
+	}
+	tr := tar.NewReader(gz)
+
+	var configData []byte
+	var lock envLock
+	dataFiles := map[string]string{} // service name -> temp file
+	defer func() {
+		for _, tmp := range dataFiles {
+			os.Remove(tmp)
+		}
+	}()
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		switch {
+		case hdr.Name == bundleConfigName:
+			if configData, err = io.ReadAll(tr); err != nil {
+				return err
+			}
+		case hdr.Name == bundleLockName:
+			if err := json.NewDecoder(tr).Decode(&lock); err != nil {
+				return fmt.Errorf("could not parse %s: %w", bundleLockName, err)
+			}
+		case strings.HasPrefix(hdr.Name, bundleDataDir):
+			tmp, err := os.CreateTemp("", "plate-restore-")
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(tmp, tr)
+			tmp.Close()
+			dataFiles[strings.TrimSuffix(strings.TrimPrefix(hdr.Name, bundleDataDir), ".tar.gz")] = tmp.Name()
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if configData == nil {
+		return fmt.Errorf("'%s' has no %s", path, bundleConfigName)
+	}
+
+	if _, err := os.Stat(bundleConfigName); err == nil && !force {
+		return fmt.Errorf("'%s' already exists, pass --force to overwrite it", bundleConfigName)
+	} else if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if err := os.WriteFile(bundleConfigName, configData, 0644); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Wrote %s.\n", bundleConfigName)
+	cfg, err := loadConfig(bundleConfigName)
+	if err != nil {
+		return err
+	}
+	if err := resolveBuilds(&cfg); err != nil {
+		return err
+	}
+
+	for _, locked := range lock.Services {
+		if locked.Digest == "" || imageDigest(locked.Image) == locked.Digest {
+			continue
+		}
+		fmt.Fprintf(out, "Pulling %s...\n", locked.Digest)
+		if err := dockerCommand("pull", locked.Digest).Run(); err != nil {
+			return fmt.Errorf("could not pull %s: %w", locked.Digest, err)
+		}
+		if err := dockerCommand("tag", locked.Digest, locked.Image).Run(); err != nil {
+			return fmt.Errorf("could not tag %s: %w", locked.Image, err)
+		}
+	}
+
+	containers, err := listPlateContainers(cfg.Project)
+	if err != nil {
+		return err
+	}
+	matched := serviceContainers(cfg, containers)
+	for _, svc := range cfg.containerServices() {
+		tmp, ok := dataFiles[svc.Name]
+		if !ok {
+			continue
+		}
+		if ctr, exists := matched[svc.Name]; exists {
+			if !force || !ctr.managed() {
+				return fmt.Errorf("%s already has a container, pass --force to replace it", svc.Name)
+			}
+			if err := removeContainer(ctr.ID); err != nil {
+				return err
+			}
+		}
+
+		fmt.Fprintf(out, "Restoring %s...\n", svc.Name)
+		if !hasImage(svc) {
+			if err := obtainImage(svc, io.Discard); err != nil {
+				return err
+			}
+		}
+		containerID, err := createServiceContainer(svc)
+		if err != nil {
+			return err
+		}
+		data, err := os.Open(tmp)
+		if err != nil {
+			return err
+		}
+		err = restoreServiceData(svc, containerID, data)
+		data.Close()
+		if err != nil {
+			return err
+		}
+		if err := dockerCommand("start", containerID).Run(); err != nil {
+			return fmt.Errorf("could not start %s: %w", svc.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEnvBundleRoundTrip(t *testing.T) {
+	bundle := filepath.Join(t.TempDir(), "env.tar.gz")
+	t.Chdir(t.TempDir())
+	fake := useFakeRuntime(t)
+	os.WriteFile("base.json", []byte(`{"services": [{"type": "redis", "name": "cache", "version": "7", "port": 6380}]}`), 0644)
+	os.WriteFile("plate.config.json", []byte(`{"project": "shop", "extends": "base.json", "services": [
+		{"type": "postgres", "name": "main-db", "version": "16", "port": 5433}
+	]}`), 0644)
+	cfg, err := loadConfig("plate.config.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake.containers = []*containerInfo{{ID: "abc", Name: "plate-redis-cache", State: "running", Labels: map[string]string{labelManaged: "true", labelProject: "shop"}}}
+	if err := writeEnvBundle(bundle, cfg, false, &bytes.Buffer{}); err != nil {
+		t.Fatalf("Expected the bundle to be written, got %v", err)
+	}
+
+	// A teammate restores it in an empty directory, without the base config.
+	t.Chdir(t.TempDir())
+	if err := restoreEnvBundle(bundle, false, &bytes.Buffer{}); err != nil {
+		t.Fatalf("Expected the bundle to restore, got %v", err)
+	}
+	restored, err := loadConfig("plate.config.json")
+	if err != nil {
+		t.Fatalf("Expected the restored config to load, got %v", err)
+	}
+	var names []string
+	for _, svc := range restored.Services {
+		names = append(names, svc.Name)
+	}
+	if restored.Project != "shop" || strings.Join(names, ",") != "cache,main-db" {
+		t.Errorf("Expected the merged config of shop, got %s with %v", restored.Project, names)
+	}
+
+	// An existing config is only overwritten with force.
+	os.WriteFile("plate.config.json", []byte(`{"project": "mine"}`), 0644)
+	if err := restoreEnvBundle(bundle, false, &bytes.Buffer{}); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("Expected the existing config to be kept, got %v", err)
+	}
+	if err := restoreEnvBundle(bundle, true, &bytes.Buffer{}); err != nil {
+		t.Errorf("Expected --force to overwrite the config, got %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// --- SHUTDOWN SIGNALS ---
+
+// signalCleanupTimeout bounds the cleanup after a signal: a closing terminal
+// or a shutting-down system won't wait long.
+const signalCleanupTimeout = 15 * time.Second
+
+// shutdownSignalMsg asks the program to quit because Plate was signaled.
+type shutdownSignalMsg struct {
+	signal os.Signal
+}
+
+// notifyShutdown forwards SIGINT, SIGTERM, and SIGHUP (the terminal closed)
+// to the program. The returned flag reports whether one arrived; stop ends
+// the forwarding.
+func notifyShutdown(p *tea.Program) (signaled *atomic.Bool, stop func()) {
+	signaled = &atomic.Bool{}
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case s := <-sigs:
+				signaled.Store(true)
+				p.Send(shutdownSignalMsg{signal: s})
+			case <-done:
+				return
+			}
+		}
+	}()
+	return signaled, func() {
+		signal.Stop(sigs)
+		close(done)
+	}
+}
+
+// cleanupAfterSignal stops what quitting with q stops, giving up after
+// timeout. It runs after the program ended, since the terminal may be gone.
+// It reports whether the cleanup finished.
+func cleanupAfterSignal(items []list.Item, keepContainers bool, timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		stopAllContainersOnExit(items, keepContainers)()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// renderLeftRunning summarizes the containers quitting left running, all of
+// them with keepAll or else the pinned ones, and how to stop them, so
+// nothing keeps using resources unnoticed. It is empty when nothing runs.
+func renderLeftRunning(items []list.Item, keepAll bool) string {
+	var rows [][3]string
+	width := [2]int{}
+	for _, itm := range items {
+		i := itm.(item)
+		if i.config.isProcess() || i.containerID == "" || i.status != statusRunning || !keepAll && !i.pinned {
+			continue
+		}
+		where := i.connectionString
+		if where == "" {
+			where = fmt.Sprintf("port %d", i.config.Port)
+		}
+		row := [3]string{i.config.Name, containerName(i.config), where}
+		width[0], width[1] = max(width[0], len(row[0])), max(width[1], len(row[1]))
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("%d service(s) left running:\n", len(rows)))
+	for _, row := range rows {
+		b.WriteString(fmt.Sprintf("  %-*s  %-*s  %s\n", width[0], row[0], width[1], row[1], row[2]))
+	}
+	b.WriteString("Stop them with 'plate down' ('plate down --include-pinned' for pinned ones), or one with 'docker stop <container>'.\n")
+	return b.String()
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestShutdownSignal(t *testing.T) {
+	// Even mid-confirmation or while quitting, a signal ends the program.
+	m := model{quitting: true}
+	_, cmd := m.update(shutdownSignalMsg{signal: syscall.SIGHUP})
+	if cmd == nil {
+		t.Fatalf("Expected a command")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Errorf("Expected the program to quit")
+	}
+
+	// Nothing running leaves nothing to stop.
+	items := []list.Item{item{config: ServiceConfig{Name: "db"}, status: statusStopped, containerID: "abc"}}
+	if !cleanupAfterSignal(items, false, time.Second) {
+		t.Errorf("Expected the cleanup to finish")
+	}
+}
+
+func TestRenderLeftRunning(t *testing.T) {
+	items := []list.Item{
+		item{config: ServiceConfig{Type: "postgres", Name: "main-db", Port: 5433}, status: statusRunning, containerID: "abc", connectionString: "postgresql://localhost:5433"},
+		item{config: ServiceConfig{Type: "redis", Name: "cache", Port: 6380}, status: statusStopped, containerID: "def"},
+		item{config: ServiceConfig{Type: "process", Name: "web"}, status: statusRunning},
+	}
+	expected := "1 service(s) left running:\n" +
+		"  main-db  plate-postgres-main-db  postgresql://localhost:5433\n" +
+		"Stop them with 'plate down' ('plate down --include-pinned' for pinned ones), or one with 'docker stop <container>'.\n"
+	if got := renderLeftRunning(items, true); got != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, got)
+	}
+	if got := renderLeftRunning(items[1:], true); got != "" {
+		t.Errorf("Expected nothing when nothing runs, got %q", got)
+	}
+
+	// A normal quit only leaves pinned services running.
+	if got := renderLeftRunning(items, false); got != "" {
+		t.Errorf("Expected nothing without pins, got %q", got)
+	}
+	pinned := items[0].(item)
+	pinned.pinned = true
+	if got := renderLeftRunning([]list.Item{pinned}, false); got != expected {
+		t.Errorf("Expected the pinned service, got %q", got)
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// --- DATA SNAPSHOTS ---
+
+// snapshotHelperImage is the throwaway image used to read and write a
+// container's data volume.
+const snapshotHelperImage = "alpine:3"
+
+// snapshotServiceData writes a gzipped tarball of the service's data
+// directory to w. A running container is paused while the copy is taken, so
+// the snapshot is consistent without a full shutdown.
+func snapshotServiceData(config ServiceConfig, containerID string, w io.Writer) error {
+	spec, err := getServiceSpec(config)
+	if err != nil {
+		return err
+	}
+	if out, err := dockerCommand("pause", containerID).CombinedOutput(); err == nil {
+		defer dockerCommand("unpause", containerID).Run()
+	} else if !strings.Contains(string(out), "is not running") {
+		return fmt.Errorf("could not pause %s: %s", config.Name, strings.TrimSpace(string(out)))
+	}
+
+	var stderr bytes.Buffer
+	cmd := dockerCommand("run", "--rm", "--volumes-from", containerID, snapshotHelperImage, "tar", "czf", "-", "-C", spec.DataDir, ".")
+	cmd.Stdout = w
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("could not snapshot %s: %s", config.Name, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// restoreServiceData replaces the service's data directory with the gzipped
+// tarball read from r. The container must not be running.
+func restoreServiceData(config ServiceConfig, containerID string, r io.Reader) error {
+	spec, err := getServiceSpec(config)
+	if err != nil {
+		return err
+	}
+
+	var stderr bytes.Buffer
+	script := fmt.Sprintf("find %s -mindepth 1 -delete && tar xzf - -C %s", spec.DataDir, spec.DataDir)
+	cmd := dockerCommand("run", "--rm", "-i", "--volumes-from", containerID, snapshotHelperImage, "sh", "-c", script)
+	cmd.Stdin = r
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("could not restore %s: %s", config.Name, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// --- STACKS ---
+
+// expandStacks replaces every service that references a stack with the
+// stack's members. A member is named "<entry>-<member>" and remembers the
+// entry as its group, so the TUI can show the stack as one collapsible row.
+func expandStacks(cfg PlateConfig) (PlateConfig, error) {
+	var services []ServiceConfig
+	for _, svc := range cfg.Services {
+		if svc.Stack == "" {
+			services = append(services, svc)
+			continue
+		}
+		members, ok := cfg.Stacks[svc.Stack]
+		if !ok {
+			return cfg, fmt.Errorf("service %s: unknown stack '%s'", svc.Name, svc.Stack)
+		}
+		if len(members) == 0 {
+			return cfg, fmt.Errorf("stack %s has no services", svc.Stack)
+		}
+		for _, member := range members {
+			if member.Stack != "" {
+				return cfg, fmt.Errorf("stack %s: member %s can't reference another stack", svc.Stack, member.Name)
+			}
+			member.Name = svc.Name + "-" + member.Name
+			member.Disabled = member.Disabled || svc.Disabled
+			member.group = svc.Name
+			services = append(services, member)
+		}
+	}
+	cfg.Services = services
+	return cfg, nil
+}
+
+// groupItem is the list row standing for a stack. Its members follow it
+// unless the stack is collapsed.
+type groupItem struct {
+	name      string
+	members   []item
+	collapsed bool
+}
+
+func (g groupItem) FilterValue() string { return g.name }
+
+func (g groupItem) Title() string {
+	arrow := "▾"
+	if g.collapsed {
+		arrow = "▸"
+	}
+	return fmt.Sprintf("%s 📚 %s (%d)", arrow, g.name, len(g.members))
+}
+
+// Description summarizes the members' statuses.
+func (g groupItem) Description() string {
+	running, failing := 0, 0
+	for _, it := range g.members {
+		switch it.status {
+		case statusRunning:
+			running++
+		case statusError, statusCrashLooping:
+			failing++
+		}
+	}
+	switch {
+	case failing > 0:
+		return errorStyle.Render(fmt.Sprintf("%d failing, %d/%d running", failing, running, len(g.members)))
+	case running == len(g.members):
+		return successStyle.Render(fmt.Sprintf("Running (%d/%d)", running, len(g.members)))
+	case running == 0:
+		return stoppedStyle.Render(fmt.Sprintf("Stopped (0/%d)", len(g.members)))
+	}
+	return pendingStyle.Render(fmt.Sprintf("%d/%d running", running, len(g.members)))
+}
+
+// visibleItems builds the rows the list shows: services outside a stack,
+// and for each stack a header followed by its members unless collapsed.
+func (m model) visibleItems() []list.Item {
+	var rows []list.Item
+	seen := map[string]bool{}
+	for _, itm := range m.items {
+		it := itm.(item)
+		group := it.config.group
+		if group == "" {
+			rows = append(rows, it)
+			continue
+		}
+		if !seen[group] {
+			seen[group] = true
+			rows = append(rows, m.groupHeader(group))
+		}
+		if !m.collapsed[group] {
+			rows = append(rows, it)
+		}
+	}
+	return rows
+}
+
+// groupHeader returns the header row of a stack.
+func (m model) groupHeader(name string) groupItem {
+	header := groupItem{name: name, collapsed: m.collapsed[name]}
+	for _, itm := range m.items {
+		if it := itm.(item); it.config.group == name {
+			header.members = append(header.members, it)
+		}
+	}
+	return header
+}
+
+// setItem stores a service's new state. The list is rebuilt from m.items
+// after every update.
+func (m model) setItem(index int, it item) tea.Cmd {
+	m.items[index] = it
+	return nil
+}
+
+// lookupItem finds a service by name. Messages refer to services by name
+// rather than position, so one that arrives for a service no longer in the
+// list finds nothing and is dropped.
+func (m model) lookupItem(service string) (item, bool) {
+	for _, itm := range m.items {
+		if it := itm.(item); it.config.Name == service {
+			return it, true
+		}
+	}
+	return item{}, false
+}
+
+// forSelected applies action to the selected service, or to every member
+// when a stack's header is selected.
+func (m model) forSelected(action func(item) tea.Cmd) tea.Cmd {
+	switch selected := m.list.SelectedItem().(type) {
+	case item:
+		return action(selected)
+	case groupItem:
+		var cmds []tea.Cmd
+		for _, it := range selected.members {
+			cmds = append(cmds, action(it))
+		}
+		return tea.Batch(cmds...)
+	}
+	return nil
+}
+
+// stopService stops a running container or process.
+func (m model) stopService(it item) tea.Cmd {
+	if it.process != nil {
+		it.stopping = true
+		return tea.Batch(m.setItem(it.index, it), stopProcessCmd(it.config, it.process))
+	}
+	if it.status == statusRunning {
+		return stopContainerCmd(it.config.Name, it.config, it.containerID)
+	}
+	return nil
+}
+
+// startService starts a stopped container or process.
+func (m model) startService(it item) tea.Cmd {
+	if it.removed {
+		return nil
+	}
+	if it.config.isProcess() && it.process == nil {
+		it.status = statusStarting
+		return tea.Batch(m.setItem(it.index, it), startProcessCmd(it.config.Name, it.config, siblingEnv(m.items), m.logs))
+	}
+	if it.status == statusStopped || it.status == statusCrashLooping {
+		it.status, it.crashes = statusStarting, 0
+		return tea.Batch(m.setItem(it.index, it), restartContainerCmd(it.config.Name, it.config, it.containerID, m.slots.starts))
+	}
+	return nil
+}
+
+// renderGroupDetail describes a stack in the detail pane.
+func (m model) renderGroupDetail(g groupItem) string {
+	var b strings.Builder
+	b.WriteString(detailTitleStyle.Render(g.Title()))
+	b.WriteString("\n\n")
+	b.WriteString(fmt.Sprintf("%s: %s\n\n", detailAttrStyle.Render("Status"), g.Description()))
+	for _, it := range g.members {
+		b.WriteString(fmt.Sprintf("%s  %s\n", it.Title(), it.Description()))
+	}
+	hint := "Press enter to collapse or expand the stack."
+	if !m.readOnly {
+		hint += " s/b stop or boot every member."
+	}
+	b.WriteString(fmt.Sprintf("\n%s\n", helpStyle.Render(hint)))
+	return b.String()
+}
@@ -16,11 +16,15 @@ const (
 	statusResetting
 	statusDeleting
 	statusError
+	statusExternal
+	statusAbsent
+	statusBuilding
+	statusCrashLooping
 )
 
 func (s status) String() string {
 	return [...]string{
-		"Pending...", "🔍 Checking...", "📥 Downloading...", "🚀 Starting...", "✅ Running", "🛑 Stopped", "🔄 Restarting...", "💥 Resetting...", "🗑️ Deleting...", "🔥 Error",
+		"Pending...", "🔍 Checking...", "📥 Downloading...", "🚀 Starting...", "✅ Running", "🛑 Stopped", "🔄 Restarting...", "💥 Resetting...", "🗑️ Deleting...", "🔥 Error", "⚠️ External container", "⚪ Not created", "🔨 Building...", "🌀 Crash-looping",
 	}[s]
 }
 
@@ -31,4 +35,17 @@ const (
 	actionNone confirmationAction = iota
 	actionReset
 	actionDelete
+	actionResolveExternal
+	actionWatchReset // the watched schema changed; reset and migrate?
+)
+
+// conflictKind describes how a container Plate didn't create relates to a service.
+type conflictKind int
+
+const (
+	conflictNone      conflictKind = iota
+	conflictName                   // it uses the service's container name
+	conflictPort                   // it publishes the service's host port
+	conflictCandidate              // it runs the service's image and port and could be adopted
+	conflictRenamed                // it belonged to a service that was renamed to this one
 )
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// --- PERSISTED LOCAL STATE ---
+
+// stateFileName is the file inside stateDirName holding plateState.
+const stateFileName = "state.json"
+
+// plateState is Plate's per-project memory between runs.
+type plateState struct {
+	// Adopted maps service names to containers that were created outside
+	// Plate but are managed by it. Docker can't add labels to an existing
+	// container, so adoption is recorded here instead.
+	Adopted map[string]string `json:"adopted,omitempty"`
+	// Pinned holds pins toggled in the TUI, which override the config's.
+	Pinned map[string]bool `json:"pinned,omitempty"`
+	// Prompts maps service names to the answers to their prompts.
+	Prompts map[string]map[string]string `json:"prompts,omitempty"`
+	// Announcement is the config's announcement as last shown.
+	Announcement *announcementState `json:"announcement,omitempty"`
+}
+
+// stateMu serializes read-modify-write cycles from concurrent commands.
+var stateMu sync.Mutex
+
+// loadState reads the state file. A missing file yields an empty state.
+func loadState() (plateState, error) {
+	var st plateState
+	data, err := os.ReadFile(filepath.Join(stateDir, stateFileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return st, nil
+	}
+	if err != nil {
+		return st, err
+	}
+	if err := json.Unmarshal(data, &st); err != nil {
+		return st, fmt.Errorf("could not parse %s: %w", filepath.Join(stateDir, stateFileName), err)
+	}
+	return st, nil
+}
+
+// updateState applies fn to the stored state and writes it back.
+func updateState(fn func(*plateState)) error {
+	stateMu.Lock()
+	defer stateMu.Unlock()
+
+	st, err := loadState()
+	if err != nil {
+		return err
+	}
+	fn(&st)
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(stateDir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(stateDir, stateFileName), data, 0644)
+}
+
+// applyAdoptions marks adopted containers as managed by the given project, so
+// the rest of Plate can treat them like containers it created itself.
+func applyAdoptions(containers []containerInfo, st plateState, project string) {
+	for i := range containers {
+		for service, id := range st.Adopted {
+			if containers[i].ID != id {
+				continue
+			}
+			labels := map[string]string{labelManaged: "true", labelService: service, labelProject: project}
+			for k, v := range containers[i].Labels {
+				if _, ok := labels[k]; !ok {
+					labels[k] = v
+				}
+			}
+			containers[i].Labels = labels
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// --- STATIC FILE SERVICE ---
+
+// staticRoot is where a "static" service's directory is mounted.
+const staticRoot = "/usr/share/nginx/html"
+
+// staticConfig renders the nginx config serving the mounted directory. An
+// SPA gets index.html for paths that aren't files, so client-side routes
+// survive a reload; otherwise directories are listed, which suits fixtures.
+func staticConfig(spa bool) string {
+	fallback, index := "=404", "on"
+	if spa {
+		fallback, index = "/index.html", "off"
+	}
+	return fmt.Sprintf(`# Generated by Plate for a static service.
+server {
+  listen %d default_server;
+  root %s;
+  autoindex %s;
+  # Rebuilt files show up on the next reload.
+  add_header Cache-Control "no-store";
+  location / {
+    try_files $uri $uri/ %s;
+  }
+}
+`, nginxPort, staticRoot, index, fallback)
+}
+
+// staticSpec describes the container of a "static" service.
+func staticSpec(config ServiceConfig) serviceSpec {
+	return nginxSpec(staticConfig(config.SPA))
+}
+
+// dirMountTarget returns where the service's dir is mounted, or "" for
+// types that don't serve a local directory.
+func dirMountTarget(config ServiceConfig) string {
+	switch config.Type {
+	case "static":
+		return staticRoot
+	case "mock-api":
+		return wiremockRoot
+	}
+	return ""
+}
+
+// dirMount returns the -v value mounting the service's directory. The
+// directory has to exist, or docker would create an empty one owned by root.
+func dirMount(config ServiceConfig) (string, error) {
+	dir, err := filepath.Abs(config.Dir)
+	if err != nil {
+		return "", err
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return "", fmt.Errorf("%s serves %s, which isn't a directory; build it first", config.Name, config.Dir)
+	}
+	return dir + ":" + dirMountTarget(config) + ":ro", nil
+}
+
+// validateStatic checks that static and mock-api services say what to
+// serve.
+func validateStatic(cfg PlateConfig) error {
+	for _, svc := range cfg.Services {
+		if dirMountTarget(svc) != "" && svc.Dir == "" {
+			return fmt.Errorf("service %s: a %s service needs the dir to serve", svc.Name, svc.Type)
+		}
+		if svc.SPA && svc.Type != "static" {
+			return fmt.Errorf("service %s: spa is for static services, not %s", svc.Name, svc.Type)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestStaticService(t *testing.T) {
+	t.Chdir(t.TempDir())
+	svc := ServiceConfig{Type: "static", Name: "frontend", Version: "1.27-alpine", Port: 8081, Dir: "dist", SPA: true}
+
+	spec, err := getServiceSpec(svc)
+	if err != nil || !strings.Contains(spec.Env[0], "try_files $uri $uri/ /index.html;") {
+		t.Errorf("Expected an SPA fallback in the nginx config, got %v, %v", spec.Env, err)
+	}
+	if _, _, err := getDockerRunArgs(svc, "plate-static-frontend"); err == nil || !strings.Contains(err.Error(), "build it first") {
+		t.Errorf("Expected an error for a missing directory, got %v", err)
+	}
+
+	os.Mkdir("dist", 0755)
+	_, args, err := getDockerRunArgs(svc, "plate-static-frontend")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	abs, _ := filepath.Abs("dist")
+	if i := slices.Index(args, "-v"); i < 0 || args[i+1] != abs+":"+staticRoot+":ro" {
+		t.Errorf("Expected the directory mounted read-only, got %v", args)
+	}
+
+	if err := validateStatic(PlateConfig{Services: []ServiceConfig{{Type: "static", Name: "frontend"}}}); err == nil {
+		t.Errorf("Expected an error for a static service without a dir")
+	}
+	if err := validateStatic(PlateConfig{Services: []ServiceConfig{{Type: "redis", Name: "cache", SPA: true}}}); err == nil {
+		t.Errorf("Expected an error for spa on another type")
+	}
+	if !strings.Contains(staticConfig(false), "autoindex on;") {
+		t.Errorf("Expected directory listings without spa")
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// --- STATUS ---
+
+// serviceStatus is one service in `plate status`.
+type serviceStatus struct {
+	Name             string `json:"name"`
+	Type             string `json:"type"`
+	State            string `json:"state"` // container state, "missing", "process", or "unknown"
+	Port             int    `json:"port,omitempty"`
+	Container        string `json:"container,omitempty"`
+	ConnectionString string `json:"connectionString,omitempty"`
+}
+
+// environmentStatus is the output of `plate status --json`.
+type environmentStatus struct {
+	Project     string          `json:"project"`
+	Fingerprint fingerprint     `json:"fingerprint"`
+	Services    []serviceStatus `json:"services"`
+	Error       string          `json:"error,omitempty"` // why container states are unknown
+}
+
+// collectStatus reports the state of every enabled service. If docker can't
+// be reached, container states are "unknown" and Error says why.
+func collectStatus(cfg PlateConfig) environmentStatus {
+	status := environmentStatus{Project: cfg.Project, Fingerprint: collectFingerprint(cfg), Services: []serviceStatus{}}
+	containers, err := listPlateContainers(cfg.Project)
+	if err != nil {
+		status.Error = err.Error()
+	}
+	matched := serviceContainers(cfg, containers)
+	for _, svc := range cfg.enabledServices() {
+		s := serviceStatus{Name: svc.Name, Type: svc.Type, State: "missing", Port: svc.Port}
+		if svc.isProcess() {
+			// Processes only live as long as the TUI that started them.
+			s.State = "process"
+		} else if status.Error != "" {
+			s.State = "unknown"
+		} else if ctr, ok := matched[svc.Name]; ok {
+			s.State = ctr.State
+			s.Container = ctr.Name
+			if ctr.State == "running" {
+				s.ConnectionString, _ = getConnectionString(svc)
+			}
+		}
+		status.Services = append(status.Services, s)
+	}
+	return status
+}
+
+// handleStatusCmd prints the fingerprint and the state of every service.
+func handleStatusCmd(args []string) {
+	fs := flag.NewFlagSet("status", flag.ExitOnError)
+	asJSON := fs.Bool("json", false, "print machine-readable JSON")
+	addConfigFlag(fs)
+	addOutputFlags(fs)
+	fs.Parse(args)
+	plateConfig := mustLoadConfig(configPathArg(fs))
+
+	status := collectStatus(plateConfig)
+	if *asJSON {
+		data, _ := json.MarshalIndent(status, "", "  ")
+		fmt.Println(string(data))
+		if status.Error != "" {
+			os.Exit(exitDocker)
+		}
+		return
+	}
+	if output == outputQuiet {
+		// One "name state" line per service.
+		for _, s := range status.Services {
+			fmt.Printf("%s %s\n", s.Name, s.State)
+		}
+		if status.Error != "" {
+			fmt.Printf("Error: %s\n", status.Error)
+			os.Exit(exitDocker)
+		}
+		return
+	}
+	fmt.Println(helpStyle.Render(status.Fingerprint.String()))
+	fmt.Println()
+	for _, s := range status.Services {
+		line := fmt.Sprintf("%-20s %-10s %-8s", s.Name, s.Type, s.State)
+		if s.ConnectionString != "" {
+			line += " " + s.ConnectionString
+		}
+		switch s.State {
+		case "running":
+			fmt.Println(successStyle.Render(line))
+		case "missing":
+			fmt.Println(pendingStyle.Render(line))
+		default:
+			fmt.Println(stoppedStyle.Render(line))
+		}
+	}
+	if status.Error != "" {
+		fmt.Printf("\nError: %s\n", status.Error)
+		os.Exit(exitDocker)
+	}
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// --- STATUS LINE ---
+
+// liveStatusFileName is the file inside stateDirName where a running TUI
+// publishes the status of its services for `plate statusline`.
+const liveStatusFileName = "live.json"
+
+// liveStatus is the snapshot a running TUI keeps up to date.
+type liveStatus struct {
+	PID      int               `json:"pid"`
+	Updated  time.Time         `json:"updated"`
+	Services []liveServiceStat `json:"services"`
+}
+
+type liveServiceStat struct {
+	Name   string `json:"name"`
+	Status string `json:"status"` // running, stopped, error, or busy
+}
+
+// liveStatusOf classifies a TUI status for the status line.
+func liveStatusOf(s status) string {
+	switch s {
+	case statusRunning:
+		return "running"
+	case statusStopped, statusAbsent, statusPending:
+		return "stopped"
+	case statusError, statusExternal, statusCrashLooping:
+		return "error"
+	default:
+		return "busy"
+	}
+}
+
+// statusesChanged reports whether any service changed status.
+func statusesChanged(before, after []list.Item) bool {
+	if len(before) != len(after) {
+		return true
+	}
+	for i := range after {
+		if before[i].(item).status != after[i].(item).status {
+			return true
+		}
+	}
+	return false
+}
+
+// writeLiveStatus publishes the services' status. Errors are ignored: the
+// status line is a nicety and must not disturb the TUI.
+func writeLiveStatus(items []list.Item) {
+	st := liveStatus{PID: os.Getpid(), Updated: time.Now()}
+	for _, itm := range items {
+		i := itm.(item)
+		st.Services = append(st.Services, liveServiceStat{Name: i.config.Name, Status: liveStatusOf(i.status)})
+	}
+	data, _ := json.Marshal(st)
+	if os.MkdirAll(stateDir, 0755) != nil {
+		return
+	}
+	// Write and rename so readers never see half a file.
+	path := filepath.Join(stateDir, liveStatusFileName)
+	if os.WriteFile(path+".tmp", data, 0644) == nil {
+		os.Rename(path+".tmp", path)
+	}
+}
+
+// removeLiveStatus withdraws the snapshot when the TUI exits.
+func removeLiveStatus() {
+	os.Remove(filepath.Join(stateDir, liveStatusFileName))
+}
+
+// readLiveStatus returns the snapshot of a TUI that is still running.
+func readLiveStatus() (liveStatus, bool) {
+	var st liveStatus
+	data, err := os.ReadFile(filepath.Join(stateDir, liveStatusFileName))
+	if err != nil || json.Unmarshal(data, &st) != nil || !processAlive(st.PID) {
+		return st, false
+	}
+	return st, true
+}
+
+// renderStatusLine condenses a snapshot into e.g. "plate: 3✅ 1🛑".
+func renderStatusLine(st liveStatus, plain bool) string {
+	counts := map[string]int{}
+	for _, s := range st.Services {
+		counts[s.Status]++
+	}
+	symbols := []struct{ status, emoji, word string }{
+		{"running", "✅", "up"},
+		{"busy", "⏳", "busy"},
+		{"stopped", "🛑", "down"},
+		{"error", "🔥", "err"},
+	}
+	var parts []string
+	for _, s := range symbols {
+		if counts[s.status] == 0 {
+			continue
+		}
+		if plain {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[s.status], s.word))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d%s", counts[s.status], s.emoji))
+		}
+	}
+	if len(parts) == 0 {
+		return "plate: no services"
+	}
+	return "plate: " + strings.Join(parts, " ")
+}
+
+// handleStatuslineCmd prints a one-line summary for tmux or wezterm status
+// bars. It only reads the snapshot of a running TUI, so it is cheap to call
+// every few seconds; it never talks to docker.
+func handleStatuslineCmd(args []string) {
+	fs := flag.NewFlagSet("statusline", flag.ExitOnError)
+	plain := fs.Bool("plain", false, "use words instead of emoji")
+	dir := fs.String("C", "", "project directory to report on (default: current directory)")
+	fs.Parse(args)
+	if *dir != "" && os.Chdir(*dir) != nil {
+		fmt.Println("plate: off")
+		return
+	}
+
+	st, ok := readLiveStatus()
+	if !ok {
+		fmt.Println("plate: off")
+		return
+	}
+	fmt.Println(renderStatusLine(st, *plain))
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+func TestStatusLine(t *testing.T) {
+	t.Chdir(t.TempDir())
+	items := []list.Item{
+		item{config: ServiceConfig{Name: "main-db"}, status: statusRunning},
+		item{config: ServiceConfig{Name: "cache"}, status: statusRunning},
+		item{config: ServiceConfig{Name: "search"}, status: statusStopped},
+		item{config: ServiceConfig{Name: "queue"}, status: statusError},
+	}
+	writeLiveStatus(items)
+
+	st, ok := readLiveStatus()
+	if !ok {
+		t.Fatalf("Expected the snapshot of this process to be live")
+	}
+	if got := renderStatusLine(st, false); got != "plate: 2✅ 1🛑 1🔥" {
+		t.Errorf("Expected plate: 2✅ 1🛑 1🔥, got %s", got)
+	}
+	if got := renderStatusLine(st, true); got != "plate: 2 up 1 down 1 err" {
+		t.Errorf("Expected plate: 2 up 1 down 1 err, got %s", got)
+	}
+
+	removeLiveStatus()
+	if _, ok := readLiveStatus(); ok {
+		t.Errorf("Expected no live status after removal")
+	}
+}
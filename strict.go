@@ -0,0 +1,201 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// --- UNKNOWN CONFIG KEYS ---
+
+// strictConfig turns unknown config keys into errors instead of warnings.
+// It is set by --strict.
+var strictConfig bool
+
+// unknownConfigKeys lists the keys in a config that no setting takes, such
+// as a misspelled "verison", as paths like services[0].verison followed by
+// the setting that was most likely meant.
+func unknownConfigKeys(data []byte) []string {
+	var raw any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil
+	}
+	var unknown []string
+	collectUnknownKeys(raw, reflect.TypeOf(PlateConfig{}), "", &unknown)
+	return unknown
+}
+
+// collectUnknownKeys walks a decoded JSON value alongside the type it is
+// decoded into, the way encoding/json matches keys: case-insensitively.
+func collectUnknownKeys(value any, t reflect.Type, path string, unknown *[]string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return
+		}
+		fields := jsonFields(t)
+		for _, key := range sortedKeys(object) {
+			field, ok := matchField(fields, key)
+			if !ok {
+				*unknown = append(*unknown, describeUnknownKey(joinKeyPath(path, key), key, fields))
+				continue
+			}
+			collectUnknownKeys(object[key], field.Type, joinKeyPath(path, key), unknown)
+		}
+	case reflect.Slice, reflect.Array:
+		items, _ := value.([]any)
+		for i, v := range items {
+			collectUnknownKeys(v, t.Elem(), fmt.Sprintf("%s[%d]", path, i), unknown)
+		}
+	case reflect.Map:
+		object, _ := value.(map[string]any)
+		for _, key := range sortedKeys(object) {
+			collectUnknownKeys(object[key], t.Elem(), joinKeyPath(path, key), unknown)
+		}
+	}
+}
+
+// sortedKeys returns the keys of a JSON object in order, so unknown keys are
+// reported the same way every time.
+func sortedKeys(object map[string]any) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// jsonFields maps the JSON names of a struct's fields, including those of
+// embedded structs, to the fields.
+func jsonFields(t reflect.Type) map[string]reflect.StructField {
+	fields := map[string]reflect.StructField{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" || !f.IsExported() && !f.Anonymous {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			for n, embedded := range jsonFields(f.Type) {
+				fields[n] = embedded
+			}
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f
+	}
+	return fields
+}
+
+// matchField finds the field a key decodes into.
+func matchField(fields map[string]reflect.StructField, key string) (reflect.StructField, bool) {
+	if f, ok := fields[key]; ok {
+		return f, true
+	}
+	for name, f := range fields {
+		if strings.EqualFold(name, key) {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// joinKeyPath appends a key to a path like services[0].
+func joinKeyPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// describeUnknownKey names an unknown key, with the setting it is closest
+// to when it looks like a typo.
+func describeUnknownKey(path, key string, fields map[string]reflect.StructField) string {
+	best, bestDistance := "", 3
+	for name := range fields {
+		if d := editDistance(strings.ToLower(key), strings.ToLower(name)); d < bestDistance || d == bestDistance && name < best {
+			best, bestDistance = name, d
+		}
+	}
+	if best != "" && bestDistance <= 2 {
+		return fmt.Sprintf("%s (did you mean %q?)", path, best)
+	}
+	return path
+}
+
+// editDistance counts the insertions, deletions, substitutions, and swaps of
+// neighbouring letters that turn a into b.
+func editDistance(a, b string) int {
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(b)]
+}
+
+// checkUnknownKeys returns an error for the unknown keys of the config at
+// path with --strict, and otherwise warnings to print.
+func checkUnknownKeys(path string, data []byte) (warnings []string, err error) {
+	unknown := unknownConfigKeys(data)
+	if len(unknown) == 0 {
+		return nil, nil
+	}
+	if strictConfig {
+		return nil, fmt.Errorf("'%s' has unknown keys: %s", path, strings.Join(unknown, ", "))
+	}
+	for _, key := range unknown {
+		warnings = append(warnings, fmt.Sprintf("'%s': ignoring unknown key %s", path, key))
+	}
+	return warnings, nil
+}
+
+// configWarningsMsg shows the config's warnings in the TUI, which takes the
+// terminal over before anyone reads them on stderr.
+type configWarningsMsg struct {
+	warnings []string
+}
+
+// configWarning summarizes the config's warnings in one line for the
+// status bar.
+func configWarning(warnings []string) string {
+	s := "Warning: " + warnings[0]
+	if len(warnings) > 1 {
+		s += fmt.Sprintf(" (and %d more)", len(warnings)-1)
+	}
+	return s
+}
+
+// printConfigWarnings prints the config's warnings to stderr, so they don't
+// get in the way of output meant for scripts.
+func printConfigWarnings(cfg PlateConfig) {
+	for _, w := range cfg.warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s. Pass --strict to make this an error.\n", w)
+	}
+}
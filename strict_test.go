@@ -0,0 +1,72 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestUnknownConfigKeys(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		want   []string
+	}{
+		{"known keys", `{"services": [{"type": "postgres", "name": "db", "version": "16", "port": 5432}], "intervals": {"reconcile": "5s"}}`, nil},
+		{"keys match case-insensitively", `{"Services": [{"Type": "postgres", "NAME": "db"}]}`, nil},
+		{"typo in a service", `{"services": [{"type": "postgres", "name": "db", "verison": "16"}]}`, []string{`services[0].verison (did you mean "version"?)`}},
+		{"typo at the top", `{"servcies": []}`, []string{`servcies (did you mean "services"?)`}},
+		{"nothing close", `{"services": [], "flavour": "mint"}`, []string{"flavour"}},
+		{"inside a stack", `{"stacks": {"web": [{"name": "db", "prot": 5432}]}}`, []string{`stacks.web[0].prot (did you mean "port"?)`}},
+		{"inside a nested object", `{"docker": {"sudoo": true}}`, []string{`docker.sudoo (did you mean "sudo"?)`}},
+		{"free-form env", `{"services": [{"name": "db", "env": {"ANYTHING": "goes"}}]}`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := unknownConfigKeys([]byte(tt.config)); !slices.Equal(got, tt.want) {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"version", "version", 0},
+		{"verison", "version", 1},
+		{"prot", "port", 1},
+		{"servcies", "services", 1},
+		{"port", "ports", 1},
+		{"name", "time", 2},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("Expected distance %d between %s and %s, got %d", tt.want, tt.a, tt.b, got)
+		}
+	}
+}
+
+func TestLoadConfigStrict(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "plate.config.json")
+	os.WriteFile(path, []byte(`{"services": [{"type": "redis", "name": "cache", "verison": "7", "port": 6380}]}`), 0644)
+
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("Expected unknown keys to only warn, got %v", err)
+	}
+	if len(cfg.warnings) != 1 || !strings.Contains(cfg.warnings[0], "services[0].verison") {
+		t.Errorf("Expected a warning about verison, got %q", cfg.warnings)
+	}
+
+	strictConfig = true
+	t.Cleanup(func() { strictConfig = false })
+	if _, err := loadConfig(path); err == nil || !strings.Contains(err.Error(), `did you mean "version"?`) {
+		t.Errorf("Expected --strict to reject verison, got %v", err)
+	}
+}
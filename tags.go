@@ -0,0 +1,301 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// --- IMAGE TAGS ---
+
+// maxTagPages caps how many pages of 100 tags are read from Docker Hub.
+const maxTagPages = 10
+
+var (
+	dockerHubAPI = "https://hub.docker.com"
+	tagClient    = proxiedClient(10 * time.Second)
+)
+
+// splitRegistry splits a repository such as "ghcr.io/org/app" into its
+// registry host and path. Repositories without a host are on Docker Hub.
+func splitRegistry(repo string) (string, string) {
+	if first, rest, ok := strings.Cut(repo, "/"); ok && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		return first, rest
+	}
+	if !strings.Contains(repo, "/") {
+		repo = "library/" + repo
+	}
+	return "", repo
+}
+
+// fetchTags lists the tags of an image's repository, newest first where the
+// registry tells.
+func fetchTags(image string) ([]string, error) {
+	host, repo := splitRegistry(imageRepository(image))
+	if host == "" {
+		return fetchHubTags(repo)
+	}
+	return fetchRegistryTags(host, repo)
+}
+
+// fetchHubTags reads tags from the Docker Hub API, most recently pushed first.
+func fetchHubTags(repo string) ([]string, error) {
+	var tags []string
+	next := fmt.Sprintf("%s/v2/repositories/%s/tags?page_size=100&ordering=last_updated", dockerHubAPI, repo)
+	for page := 0; next != "" && page < maxTagPages; page++ {
+		var body struct {
+			Next    string `json:"next"`
+			Results []struct {
+				Name string `json:"name"`
+			} `json:"results"`
+		}
+		if err := getJSON(next, "", &body); err != nil {
+			return nil, fmt.Errorf("could not list tags of %s: %v", repo, err)
+		}
+		for _, r := range body.Results {
+			tags = append(tags, r.Name)
+		}
+		next = body.Next
+	}
+	return tags, nil
+}
+
+// fetchRegistryTags reads tags with the registry v2 API, getting an
+// anonymous token first if the registry asks for one.
+func fetchRegistryTags(host, repo string) ([]string, error) {
+	location := fmt.Sprintf("https://%s/v2/%s/tags/list", host, repo)
+	resp, err := tagClient.Get(location)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	token := ""
+	if resp.StatusCode == http.StatusUnauthorized {
+		if token, err = registryToken(resp.Header.Get("Www-Authenticate")); err != nil {
+			return nil, fmt.Errorf("could not list tags of %s/%s: %v", host, repo, err)
+		}
+	}
+	var body struct {
+		Tags []string `json:"tags"`
+	}
+	if err := getJSON(location, token, &body); err != nil {
+		return nil, fmt.Errorf("could not list tags of %s/%s: %v", host, repo, err)
+	}
+	// The v2 API lists tags in lexical order; reverse it so higher versions
+	// come first.
+	for i, j := 0, len(body.Tags)-1; i < j; i, j = i+1, j-1 {
+		body.Tags[i], body.Tags[j] = body.Tags[j], body.Tags[i]
+	}
+	return body.Tags, nil
+}
+
+// registryToken requests an anonymous pull token from the realm named in a
+// `Bearer realm="...",service="...",scope="..."` challenge.
+func registryToken(challenge string) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return "", fmt.Errorf("unsupported authentication %q", scheme)
+	}
+	values := url.Values{}
+	realm := ""
+	for _, p := range strings.Split(params, ",") {
+		k, v, _ := strings.Cut(strings.TrimSpace(p), "=")
+		v = strings.Trim(v, `"`)
+		if k == "realm" {
+			realm = v
+		} else {
+			values.Set(k, v)
+		}
+	}
+	if realm == "" {
+		return "", fmt.Errorf("no token realm in %q", challenge)
+	}
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := getJSON(realm+"?"+values.Encode(), "", &body); err != nil {
+		return "", err
+	}
+	if body.Token == "" {
+		return body.AccessToken, nil
+	}
+	return body.Token, nil
+}
+
+// getJSON fetches location and decodes the JSON response into v.
+func getJSON(location, token string, v any) error {
+	req, err := http.NewRequest("GET", location, nil)
+	if err != nil {
+		return err
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := tagClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("server returned %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// tagItem is a tag in the picker.
+type tagItem struct {
+	tag     string
+	current bool
+}
+
+func (t tagItem) FilterValue() string { return t.tag }
+func (t tagItem) Title() string {
+	if t.current {
+		return t.tag + " " + successStyle.Render("(current)")
+	}
+	return t.tag
+}
+func (t tagItem) Description() string { return "" }
+
+// tagPicker is a filterable list of tags; choice is empty if nothing was
+// picked.
+type tagPicker struct {
+	list   list.Model
+	choice string
+}
+
+func newTagPicker(image, current string, tags []string) tagPicker {
+	items := make([]list.Item, len(tags))
+	for i, t := range tags {
+		items[i] = tagItem{tag: t, current: t == current}
+	}
+	delegate := list.NewDefaultDelegate()
+	delegate.ShowDescription = false
+	delegate.SetSpacing(0)
+	l := list.New(items, delegate, 0, 0)
+	l.Title = "Tags of " + imageRepository(image)
+	l.SetStatusBarItemName("tag", "tags")
+	return tagPicker{list: l}
+}
+
+func (p tagPicker) Init() tea.Cmd { return nil }
+
+func (p tagPicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		h, v := docStyle.GetFrameSize()
+		p.list.SetSize(msg.Width-h, msg.Height-v)
+	case tea.KeyMsg:
+		if p.list.SettingFilter() {
+			break
+		}
+		switch msg.String() {
+		case "enter":
+			if t, ok := p.list.SelectedItem().(tagItem); ok {
+				p.choice = t.tag
+			}
+			return p, tea.Quit
+		case "q", "ctrl+c":
+			return p, tea.Quit
+		}
+	}
+	var cmd tea.Cmd
+	p.list, cmd = p.list.Update(msg)
+	return p, cmd
+}
+
+func (p tagPicker) View() string {
+	return docStyle.Render(p.list.View())
+}
+
+// pickTag fetches the tags of image and lets the user choose one. It
+// returns "" if the user quit without choosing.
+func pickTag(image, current string) (string, error) {
+	fmt.Printf("Fetching tags of %s...\n", imageRepository(image))
+	tags, err := fetchTags(image)
+	if err != nil {
+		return "", err
+	}
+	if len(tags) == 0 {
+		return "", fmt.Errorf("%s has no tags", imageRepository(image))
+	}
+	final, err := tea.NewProgram(newTagPicker(image, current, tags), tea.WithAltScreen()).Run()
+	if err != nil {
+		return "", err
+	}
+	return final.(tagPicker).choice, nil
+}
+
+func handleTagsCmd(args []string) {
+	fs := flag.NewFlagSet("tags", flag.ExitOnError)
+	addConfigFlag(fs)
+	local := fs.Bool("local", false, "write the version to the local override file instead")
+	fs.Parse(args)
+	if fs.NArg() < 1 {
+		fmt.Println("Usage: plate tags [--config path] [--local] <service>")
+		os.Exit(exitUsage)
+	}
+	configPath := "plate.config.json"
+	if v := fs.Lookup("config").Value.String(); v != "" {
+		configPath = v
+	}
+	plateConfig := mustLoadConfig(configPath)
+
+	var svc *ServiceConfig
+	for i := range plateConfig.Services {
+		if plateConfig.Services[i].Name == fs.Arg(0) {
+			svc = &plateConfig.Services[i]
+		}
+	}
+	switch {
+	case svc == nil:
+		fmt.Printf("Error: no service named '%s' in the config.\n", fs.Arg(0))
+		os.Exit(1)
+	case svc.isProcess():
+		fmt.Printf("Error: %s is a process and has no image.\n", svc.Name)
+		os.Exit(1)
+	case svc.group != "":
+		fmt.Printf("Error: %s belongs to the stack of %s. Change its version in the stack definition.\n", svc.Name, svc.group)
+		os.Exit(1)
+	case svc.Build != nil:
+		fmt.Printf("Error: %s is built from %s. Change the base image there.\n", svc.Name, svc.Build.dockerfilePath())
+		os.Exit(1)
+	}
+
+	target := configPath
+	if *local {
+		target = localConfigPath(configPath)
+	} else if !isFileSource(configPath) {
+		fmt.Println("Error: The config isn't a local file. Use --local to write the version to your local override file.")
+		os.Exit(1)
+	}
+	tag, err := pickTag(imageName(*svc), svc.Version)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if tag == "" || tag == svc.Version {
+		fmt.Println("Nothing changed.")
+		return
+	}
+	if _, err := os.Stat(target); *local && os.IsNotExist(err) {
+		os.WriteFile(target, []byte("{\"services\": []}\n"), 0644)
+	}
+	err = editConfigServices(target, func(services []any) []any {
+		return setServiceField(services, svc.Name, "version", tag)
+	})
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("%s %s: %s → %s in %s. Run 'plate apply' to recreate its container.\n", successStyle.Render("✓"), svc.Name, svc.Version, tag, target)
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSplitRegistry(t *testing.T) {
+	tests := []struct {
+		repo         string
+		expectedHost string
+		expectedPath string
+	}{
+		{"postgres", "", "library/postgres"},
+		{"grafana/otel-lgtm", "", "grafana/otel-lgtm"},
+		{"ghcr.io/org/app", "ghcr.io", "org/app"},
+		{"localhost:5000/app", "localhost:5000", "app"},
+	}
+	for _, tt := range tests {
+		host, path := splitRegistry(tt.repo)
+		if host != tt.expectedHost || path != tt.expectedPath {
+			t.Errorf("%s: Expected %q %q, got %q %q", tt.repo, tt.expectedHost, tt.expectedPath, host, path)
+		}
+	}
+}
+
+func TestFetchHubTags(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/repositories/library/postgres/tags" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `{"next": null, "results": [{"name": "15-alpine"}]}`)
+			return
+		}
+		fmt.Fprintf(w, `{"next": "%s%s?page=2", "results": [{"name": "16.3-alpine"}, {"name": "16"}]}`, server.URL, r.URL.Path)
+	}))
+	defer server.Close()
+	oldAPI := dockerHubAPI
+	dockerHubAPI = server.URL
+	defer func() { dockerHubAPI = oldAPI }()
+
+	tags, err := fetchTags("postgres:14-alpine")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := strings.Join(tags, ","); got != "16.3-alpine,16,15-alpine" {
+		t.Errorf("Expected 16.3-alpine,16,15-alpine, got %s", got)
+	}
+}
+
+func TestFetchRegistryTags(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			if r.URL.Query().Get("scope") != "repository:org/app:pull" {
+				http.Error(w, "bad scope", http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, `{"token": "secret"}`)
+		case "/v2/org/app/tags/list":
+			if r.Header.Get("Authorization") != "Bearer secret" {
+				w.Header().Set("Www-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repository:org/app:pull"`, server.URL))
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, `{"name": "org/app", "tags": ["1.0", "1.1", "2.0"]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	oldClient := tagClient
+	tagClient = server.Client()
+	defer func() { tagClient = oldClient }()
+
+	host := strings.TrimPrefix(server.URL, "https://")
+	tags, err := fetchTags(host + "/org/app:1.0")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := strings.Join(tags, ","); got != "2.0,1.1,1.0" {
+		t.Errorf("Expected 2.0,1.1,1.0, got %s", got)
+	}
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// --- SCHEDULED TASKS ---
+
+// TaskConfig is a recurring job that runs inside a service's container
+// while Plate is open, e.g. refreshing materialized views every 10 minutes.
+type TaskConfig struct {
+	Name    string `json:"name"`
+	Service string `json:"service"`
+	// Every is a Go duration such as "10m" or "1h30m", or one of the
+	// shorthands @hourly and @daily.
+	Every   string `json:"every"`
+	Command string `json:"command"`
+}
+
+// taskInterval parses a task's schedule.
+func taskInterval(every string) (time.Duration, error) {
+	switch every {
+	case "@hourly":
+		return time.Hour, nil
+	case "@daily":
+		return 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(every)
+	if err != nil {
+		return 0, fmt.Errorf("invalid schedule %q, use a duration like \"10m\" or @hourly/@daily", every)
+	}
+	if d < time.Second {
+		return 0, fmt.Errorf("schedule %q is shorter than a second", every)
+	}
+	return d, nil
+}
+
+// validateTasks checks that every task has a valid schedule and targets a
+// container service in the config.
+func validateTasks(cfg PlateConfig) error {
+	services := map[string]ServiceConfig{}
+	for _, s := range cfg.Services {
+		services[s.Name] = s
+	}
+	for _, task := range cfg.Tasks {
+		if task.Name == "" || task.Command == "" {
+			return fmt.Errorf("every task needs a name and a command")
+		}
+		svc, ok := services[task.Service]
+		if !ok {
+			return fmt.Errorf("task %s: no service named '%s'", task.Name, task.Service)
+		}
+		if svc.isProcess() {
+			return fmt.Errorf("task %s: '%s' is a process service, tasks run in containers", task.Name, task.Service)
+		}
+		if _, err := taskInterval(task.Every); err != nil {
+			return fmt.Errorf("task %s: %w", task.Name, err)
+		}
+	}
+	return nil
+}
+
+// taskState is a task's schedule and the outcome of its last run.
+type taskState struct {
+	config   TaskConfig
+	interval time.Duration
+	running  bool
+	lastRun  time.Time // zero until the task has run
+	lastErr  error
+	skipped  bool // the last run was skipped because the service wasn't running
+}
+
+// newTaskStates prepares the schedule for every task whose service is enabled.
+func newTaskStates(cfg PlateConfig) []taskState {
+	enabled := map[string]bool{}
+	for _, s := range cfg.containerServices() {
+		enabled[s.Name] = true
+	}
+	var tasks []taskState
+	for _, t := range cfg.Tasks {
+		if !enabled[t.Service] {
+			continue
+		}
+		interval, _ := taskInterval(t.Every) // validated when the config was loaded
+		tasks = append(tasks, taskState{config: t, interval: interval})
+	}
+	return tasks
+}
+
+// statusLine summarizes the task's last run for the detail pane.
+func (t taskState) statusLine() string {
+	switch {
+	case t.running:
+		return pendingStyle.Render("running...")
+	case t.lastRun.IsZero():
+		return stoppedStyle.Render("not run yet")
+	case t.skipped:
+		return stoppedStyle.Render(fmt.Sprintf("skipped %s (service not running)", timeDisplay.when(t.lastRun, time.Now())))
+	case t.lastErr != nil:
+		return errorStyle.Render(fmt.Sprintf("failed %s: %v", timeDisplay.when(t.lastRun, time.Now()), t.lastErr))
+	default:
+		return successStyle.Render("ok " + timeDisplay.when(t.lastRun, time.Now()))
+	}
+}
+
+type taskDueMsg struct {
+	index int
+}
+
+type taskFinishedMsg struct {
+	index   int
+	at      time.Time
+	err     error
+	skipped bool
+}
+
+// scheduleTaskCmd fires taskDueMsg once the task's interval has passed.
+func scheduleTaskCmd(index int, interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return taskDueMsg{index: index}
+	})
+}
+
+// runTaskCmd runs the task's command in the service's container. An empty
+// containerID means the service isn't running, so the run is skipped.
+func runTaskCmd(index int, task TaskConfig, containerID string) tea.Cmd {
+	return func() tea.Msg {
+		at := time.Now()
+		if containerID == "" {
+			return taskFinishedMsg{index: index, at: at, skipped: true}
+		}
+		output, err := backend.exec(containerID, nil, "sh", "-c", task.Command)
+		if err != nil {
+			if out := strings.TrimSpace(string(output)); out != "" {
+				err = fmt.Errorf("%s", lastLine(out))
+			}
+		}
+		return taskFinishedMsg{index: index, at: at, err: err}
+	}
+}
+
+// lastLine returns the final line of s, which is usually the error message.
+func lastLine(s string) string {
+	if i := strings.LastIndexByte(s, '\n'); i >= 0 {
+		return s[i+1:]
+	}
+	return s
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTaskInterval(t *testing.T) {
+	tests := []struct {
+		every    string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{"10m", 10 * time.Minute, false},
+		{"1h30m", 90 * time.Minute, false},
+		{"@hourly", time.Hour, false},
+		{"@daily", 24 * time.Hour, false},
+		{"500ms", 0, true},
+		{"*/10 * * * *", 0, true},
+		{"", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := taskInterval(tt.every)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%q: Expected error %v, got %v", tt.every, tt.wantErr, err)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("%q: Expected %v, got %v", tt.every, tt.expected, got)
+		}
+	}
+}
+
+func TestValidateTasks(t *testing.T) {
+	services := []ServiceConfig{
+		{Type: "postgres", Name: "main-db", Version: "16", Port: 5433},
+		{Type: "process", Name: "web", Command: "npm run dev"},
+	}
+	tests := []struct {
+		name    string
+		task    TaskConfig
+		wantErr bool
+	}{
+		{"valid", TaskConfig{Name: "refresh", Service: "main-db", Every: "10m", Command: "psql -c 'select 1'"}, false},
+		{"unknown service", TaskConfig{Name: "refresh", Service: "cache", Every: "10m", Command: "true"}, true},
+		{"process service", TaskConfig{Name: "refresh", Service: "web", Every: "10m", Command: "true"}, true},
+		{"bad schedule", TaskConfig{Name: "refresh", Service: "main-db", Every: "often", Command: "true"}, true},
+		{"no command", TaskConfig{Name: "refresh", Service: "main-db", Every: "10m"}, true},
+	}
+	for _, tt := range tests {
+		err := validateTasks(PlateConfig{Services: services, Tasks: []TaskConfig{tt.task}})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: Expected error %v, got %v", tt.name, tt.wantErr, err)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// --- TIME FORMATTING ---
+
+// TimeConfig sets how the TUI shows times.
+type TimeConfig struct {
+	// Format is "absolute" (15:04:05, the default) or "relative" (3m ago).
+	Format string `json:"format,omitempty"`
+	// Clock is "24h" or "12h". It defaults to the locale's, from LC_TIME.
+	Clock string `json:"clock,omitempty"`
+}
+
+// timeDisplayOptions is a resolved TimeConfig.
+type timeDisplayOptions struct {
+	relative bool
+	clock12  bool
+}
+
+// timeDisplay is how times are shown in this run, set when the config is
+// loaded.
+var timeDisplay = timeDisplayOptions{clock12: localeUses12h()}
+
+// clock12Regions are the regions whose locales write times on a 12-hour
+// clock.
+var clock12Regions = map[string]bool{"US": true, "CA": true, "AU": true, "NZ": true, "PH": true, "IN": true, "PK": true, "EG": true}
+
+// localeUses12h reports whether the user's locale, e.g. en_US.UTF-8 in
+// LC_ALL, LC_TIME, or LANG, uses a 12-hour clock.
+func localeUses12h() bool {
+	for _, key := range []string{"LC_ALL", "LC_TIME", "LANG"} {
+		locale := os.Getenv(key)
+		if locale == "" {
+			continue
+		}
+		locale, _, _ = strings.Cut(locale, ".")
+		_, region, _ := strings.Cut(locale, "_")
+		return clock12Regions[region]
+	}
+	return false
+}
+
+// resolveTimeDisplay validates cfg and fills in the defaults.
+func resolveTimeDisplay(cfg *TimeConfig) (timeDisplayOptions, error) {
+	opts := timeDisplayOptions{clock12: localeUses12h()}
+	if cfg == nil {
+		return opts, nil
+	}
+	switch cfg.Format {
+	case "", "absolute":
+	case "relative":
+		opts.relative = true
+	default:
+		return opts, fmt.Errorf("time.format must be absolute or relative, not %q", cfg.Format)
+	}
+	switch cfg.Clock {
+	case "":
+	case "24h":
+		opts.clock12 = false
+	case "12h":
+		opts.clock12 = true
+	default:
+		return opts, fmt.Errorf("time.clock must be 24h or 12h, not %q", cfg.Clock)
+	}
+	return opts, nil
+}
+
+// clockLayout is the time.Format layout for a time of day.
+func (o timeDisplayOptions) clockLayout() string {
+	if o.clock12 {
+		return "3:04:05 PM"
+	}
+	return "15:04:05"
+}
+
+// clock formats the time of day of t, e.g. 15:04:05 or 3:04:05 PM.
+func (o timeDisplayOptions) clock(t time.Time) string {
+	return t.Local().Format(o.clockLayout())
+}
+
+// stamp formats t with its date, or relative to now.
+func (o timeDisplayOptions) stamp(t, now time.Time) string {
+	if o.relative {
+		return relativeTime(t, now)
+	}
+	return t.Local().Format("2006-01-02") + " " + o.clock(t)
+}
+
+// when formats t to follow an event, as in "ok at 15:04:05" or "ok 3m ago".
+func (o timeDisplayOptions) when(t, now time.Time) string {
+	if o.relative {
+		return relativeTime(t, now)
+	}
+	return "at " + o.clock(t)
+}
+
+// relativeTime formats how long ago t was, in its largest whole unit.
+func relativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+	var s string
+	switch {
+	case d < 5*time.Second:
+		return "just now"
+	case d < time.Minute:
+		s = fmt.Sprintf("%ds", int(d/time.Second))
+	case d < time.Hour:
+		s = fmt.Sprintf("%dm", int(d/time.Minute))
+	case d < 24*time.Hour:
+		s = fmt.Sprintf("%dh", int(d/time.Hour))
+	default:
+		s = fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+	}
+	if future {
+		return "in " + s
+	}
+	return s + " ago"
+}
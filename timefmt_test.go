@@ -0,0 +1,60 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2025, 3, 3, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		t        time.Time
+		expected string
+	}{
+		{now.Add(-2 * time.Second), "just now"},
+		{now.Add(-42 * time.Second), "42s ago"},
+		{now.Add(-3*time.Minute - 30*time.Second), "3m ago"},
+		{now.Add(-5 * time.Hour), "5h ago"},
+		{now.Add(-50 * time.Hour), "2d ago"},
+		{now.Add(10 * time.Minute), "in 10m"},
+	}
+	for _, tt := range tests {
+		if got := relativeTime(tt.t, now); got != tt.expected {
+			t.Errorf("Expected %s, got %s", tt.expected, got)
+		}
+	}
+}
+
+func TestResolveTimeDisplay(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_TIME", "en_US.UTF-8")
+	opts, err := resolveTimeDisplay(nil)
+	if err != nil || !opts.clock12 || opts.relative {
+		t.Errorf("Expected an absolute 12-hour clock for en_US, got %+v, %v", opts, err)
+	}
+	t.Setenv("LC_TIME", "de_DE.UTF-8")
+	if opts, _ := resolveTimeDisplay(nil); opts.clock12 {
+		t.Errorf("Expected a 24-hour clock for de_DE")
+	}
+	opts, err = resolveTimeDisplay(&TimeConfig{Format: "relative", Clock: "12h"})
+	if err != nil || !opts.relative || !opts.clock12 {
+		t.Errorf("Expected a relative 12-hour display, got %+v, %v", opts, err)
+	}
+	if _, err := resolveTimeDisplay(&TimeConfig{Format: "fuzzy"}); err == nil {
+		t.Errorf("Expected an error for an unknown format")
+	}
+	if _, err := resolveTimeDisplay(&TimeConfig{Clock: "36h"}); err == nil {
+		t.Errorf("Expected an error for an unknown clock")
+	}
+
+	at := time.Date(2025, 3, 3, 15, 4, 5, 0, time.Local)
+	if got := (timeDisplayOptions{clock12: true}).when(at, at); got != "at 3:04:05 PM" {
+		t.Errorf("Expected a 12-hour time, got %s", got)
+	}
+	if got := (timeDisplayOptions{}).stamp(at, at); got != "2025-03-03 15:04:05" {
+		t.Errorf("Expected a 24-hour stamp, got %s", got)
+	}
+	if got := (timeDisplayOptions{relative: true}).when(at, at.Add(time.Hour)); got != "1h ago" {
+		t.Errorf("Expected a relative time, got %s", got)
+	}
+}
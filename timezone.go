@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// --- CONTAINER TIME ZONES ---
+
+// localTimezone makes a service use the time zone of the machine Plate
+// runs on.
+const localTimezone = "local"
+
+// timezoneNamePattern matches IANA zone names such as UTC or
+// America/Argentina/Buenos_Aires.
+var timezoneNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_+-]*(/[A-Za-z0-9_+-]+)*$`)
+
+// clockSkewTolerance is how far a container's clock may be off before the
+// detail pane warns. date only reports whole seconds.
+const clockSkewTolerance = 2 * time.Second
+
+// hostTimezone returns the name of this machine's time zone, from TZ or
+// the zoneinfo file /etc/localtime links to.
+func hostTimezone() (string, error) {
+	if tz := strings.TrimPrefix(os.Getenv("TZ"), ":"); timezoneNamePattern.MatchString(tz) {
+		return tz, nil
+	}
+	target, err := filepath.EvalSymlinks("/etc/localtime")
+	if err == nil {
+		if _, name, ok := strings.Cut(filepath.ToSlash(target), "zoneinfo/"); ok && timezoneNamePattern.MatchString(name) {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("could not tell this machine's time zone; set timezone to its name, e.g. Europe/Berlin")
+}
+
+// serviceTimezone returns the zone name a service's TZ is set to, with
+// "local" resolved, or "" when it keeps the image's default (UTC).
+func serviceTimezone(config ServiceConfig) (string, error) {
+	if config.Timezone == localTimezone {
+		return hostTimezone()
+	}
+	return config.Timezone, nil
+}
+
+// validateTimezones checks every service's timezone, and that nothing else
+// sets its TZ.
+func validateTimezones(cfg PlateConfig) error {
+	for _, svc := range cfg.Services {
+		if svc.Timezone == "" {
+			continue
+		}
+		if svc.isProcess() {
+			return fmt.Errorf("service %s: timezone is for container services; set TZ in env instead", svc.Name)
+		}
+		if svc.Timezone != localTimezone && !timezoneNamePattern.MatchString(svc.Timezone) {
+			return fmt.Errorf("service %s: timezone %q must be a zone name like Europe/Berlin, or local", svc.Name, svc.Timezone)
+		}
+		if _, ok := svc.Env["TZ"]; ok {
+			return fmt.Errorf("service %s: set either timezone or TZ in env", svc.Name)
+		}
+		if svc.MySQL != nil && svc.MySQL.TimeZone != "" {
+			return fmt.Errorf("service %s: set either timezone or mysql.timeZone", svc.Name)
+		}
+		if _, err := serviceTimezone(svc); err != nil {
+			return fmt.Errorf("service %s: %w", svc.Name, err)
+		}
+	}
+	return nil
+}
+
+// containerClock is a reading of a container's clock.
+type containerClock struct {
+	service string
+	zone    string        // abbreviation, e.g. CET
+	offset  int           // seconds east of UTC
+	skew    time.Duration // how far ahead of this machine the container is
+	err     error
+}
+
+type containerClockMsg containerClock
+
+// readContainerClockCmd asks the container for its time and zone.
+func readContainerClockCmd(service, containerID string) tea.Cmd {
+	return func() tea.Msg {
+		before := time.Now()
+		output, err := backend.exec(containerID, nil, "date", "+%s %Z %z")
+		// Compare with the middle of the round trip.
+		now := before.Add(time.Since(before) / 2)
+		if err != nil {
+			return containerClockMsg{service: service, err: err}
+		}
+		clock, err := parseContainerClock(string(output), now)
+		clock.service = service
+		clock.err = err
+		return containerClockMsg(clock)
+	}
+}
+
+// parseContainerClock reads the "epoch zone offset" date prints.
+func parseContainerClock(output string, now time.Time) (containerClock, error) {
+	var clock containerClock
+	fields := strings.Fields(output)
+	if len(fields) != 3 {
+		return clock, fmt.Errorf("unexpected date output %q", strings.TrimSpace(output))
+	}
+	epoch, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return clock, fmt.Errorf("unexpected date output %q", strings.TrimSpace(output))
+	}
+	offset, err := time.Parse("-0700", fields[2])
+	if err != nil {
+		return clock, fmt.Errorf("unexpected date output %q", strings.TrimSpace(output))
+	}
+	_, clock.offset = offset.Zone()
+	clock.zone = fields[1]
+	clock.skew = time.Unix(epoch, 0).Sub(now.Truncate(time.Second))
+	return clock, nil
+}
+
+// renderContainerClock renders the container's current time, with a
+// warning when its clock is off.
+func renderContainerClock(clock containerClock, now time.Time) string {
+	if clock.err != nil {
+		return stoppedStyle.Render("unknown")
+	}
+	t := now.Add(clock.skew).In(time.FixedZone(clock.zone, clock.offset))
+	line := detailValStyle.Render(t.Format(timeDisplay.clockLayout()) + " " + t.Format("MST (-07:00)"))
+	skew := clock.skew.Round(time.Second)
+	switch {
+	case skew >= clockSkewTolerance:
+		line += " " + confirmStyle.Render(fmt.Sprintf("⚠️ %s ahead of this machine", skew))
+	case skew <= -clockSkewTolerance:
+		line += " " + confirmStyle.Render(fmt.Sprintf("⚠️ %s behind this machine", -skew))
+	}
+	return line
+}
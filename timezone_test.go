@@ -0,0 +1,62 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServiceTimezone(t *testing.T) {
+	svc := ServiceConfig{Type: "postgres", Name: "main-db", Version: "16", Port: 5433, Timezone: "Europe/Berlin"}
+	spec, err := getServiceSpec(svc)
+	if err != nil || !slices.Contains(spec.Env, "TZ=Europe/Berlin") {
+		t.Errorf("Expected TZ in the env, got %v, %v", spec.Env, err)
+	}
+
+	t.Setenv("TZ", "America/New_York")
+	svc.Timezone = localTimezone
+	if spec, _ := getServiceSpec(svc); !slices.Contains(spec.Env, "TZ=America/New_York") {
+		t.Errorf("Expected local to resolve to this machine's zone, got %v", spec.Env)
+	}
+
+	tests := []struct {
+		svc ServiceConfig
+		err string
+	}{
+		{ServiceConfig{Type: "redis", Name: "cache", Timezone: "Europe/Berlin"}, ""},
+		{ServiceConfig{Type: "redis", Name: "cache", Timezone: "not a zone"}, "must be a zone name"},
+		{ServiceConfig{Type: "redis", Name: "cache", Timezone: "UTC", Env: map[string]string{"TZ": "UTC"}}, "either timezone or TZ"},
+		{ServiceConfig{Type: "mysql", Name: "db", Timezone: "UTC", MySQL: &MySQLConfig{TimeZone: "+00:00"}}, "either timezone or mysql.timeZone"},
+		{ServiceConfig{Type: "process", Name: "web", Command: "npm run dev", Timezone: "UTC"}, "container services"},
+	}
+	for _, tt := range tests {
+		err := validateTimezones(PlateConfig{Services: []ServiceConfig{tt.svc}})
+		if tt.err == "" && err != nil {
+			t.Errorf("Expected %s to be valid, got %v", tt.svc.Timezone, err)
+		}
+		if tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)) {
+			t.Errorf("Expected an error containing %q, got %v", tt.err, err)
+		}
+	}
+}
+
+func TestParseContainerClock(t *testing.T) {
+	now := time.Unix(1741000000, 400*int64(time.Millisecond))
+	clock, err := parseContainerClock("1740999955 CET +0100\n", now)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if clock.zone != "CET" || clock.offset != 3600 || clock.skew != -45*time.Second {
+		t.Errorf("Expected CET, +1h, and 45s behind, got %+v", clock)
+	}
+	if out := renderContainerClock(clock, now); !strings.Contains(out, "45s behind") || !strings.Contains(out, "CET (+01:00)") {
+		t.Errorf("Expected the zone and a skew warning, got %q", out)
+	}
+	if out := renderContainerClock(containerClock{zone: "UTC"}, now); strings.Contains(out, "⚠️") {
+		t.Errorf("Expected no warning without skew, got %q", out)
+	}
+	if _, err := parseContainerClock("Mon Mar  3 12:00:00 UTC 2025", now); err == nil {
+		t.Errorf("Expected an error for unexpected output")
+	}
+}
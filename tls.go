@@ -0,0 +1,233 @@
+package main
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// --- TLS SERVICES ---
+
+const (
+	// certsDirName is the directory inside stateDirName holding the local CA
+	// and one subdirectory of server certificates per service.
+	certsDirName = "certs"
+	// tlsMountDir is where a service's certificate directory is mounted.
+	tlsMountDir = "/plate-certs"
+	// labelTLS marks containers that were started with TLS enabled.
+	labelTLS = "plate.tls"
+	// certRenewBefore is how close to expiry a server certificate is replaced.
+	certRenewBefore = 30 * 24 * time.Hour
+)
+
+// tlsServiceTypes lists the service types that can run with TLS.
+var tlsServiceTypes = map[string]bool{"postgres": true, "redis": true, "mysql": true}
+
+// caCertPath and caKeyPath locate the local certificate authority.
+func caCertPath() string { return filepath.Join(stateDir, certsDirName, "ca.crt") }
+func caKeyPath() string  { return filepath.Join(stateDir, certsDirName, "ca.key") }
+
+// serviceCertDir returns the directory mounted into a TLS service. It holds
+// server.crt, server.key and a copy of ca.crt.
+func serviceCertDir(config ServiceConfig) string {
+	return filepath.Join(stateDir, certsDirName, config.Name)
+}
+
+// tlsHosts lists the names a service's certificate is valid for.
+func tlsHosts(config ServiceConfig) []string {
+	hosts := []string{"localhost", "127.0.0.1", "::1", config.Name}
+	if config.Host != "" && config.Host != "localhost" {
+		hosts = append(hosts, config.Host)
+	}
+	return hosts
+}
+
+// tlsStartScript returns the shell script a TLS service's container runs.
+// Servers refuse private keys they don't own, so the key is copied into the
+// container with the right owner before the image's entrypoint takes over.
+func tlsStartScript(config ServiceConfig) string {
+	switch config.Type {
+	case "postgres":
+		return "install -o postgres -m 600 " + tlsMountDir + "/server.key /tmp/server.key && " +
+			"exec docker-entrypoint.sh postgres -c ssl=on -c ssl_cert_file=" + tlsMountDir + "/server.crt -c ssl_key_file=/tmp/server.key"
+	case "redis":
+		return "install -o redis -m 600 " + tlsMountDir + "/server.key /tmp/server.key && " +
+			"exec docker-entrypoint.sh redis-server --port 0 --tls-port 6379 --tls-cert-file " + tlsMountDir + "/server.crt " +
+			"--tls-key-file /tmp/server.key --tls-ca-cert-file " + tlsMountDir + "/ca.crt --tls-auth-clients no"
+	case "mysql":
+		return "install -o mysql -m 600 " + tlsMountDir + "/server.key /tmp/server.key && " +
+			"exec docker-entrypoint.sh mysqld --ssl-cert=" + tlsMountDir + "/server.crt --ssl-key=/tmp/server.key " +
+			"--ssl-ca=" + tlsMountDir + "/ca.crt --require-secure-transport=ON"
+	}
+	return ""
+}
+
+// ensureCA loads the local certificate authority, creating it on first use.
+func ensureCA() (*x509.Certificate, crypto.Signer, error) {
+	if cert, key, err := loadCertPair(caCertPath(), caKeyPath()); err == nil {
+		return cert, key, nil
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, err
+	}
+	hostname, _ := os.Hostname()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{Organization: []string{"Plate local development"}, CommonName: "Plate local CA (" + caTrustName() + " on " + hostname + ")"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().AddDate(10, 0, 0),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := writeCertPair(caCertPath(), caKeyPath(), der, key); err != nil {
+		return nil, nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	return cert, key, err
+}
+
+// ensureServiceCert makes sure the service has a server certificate signed
+// by the local CA that covers its hosts and isn't about to expire.
+func ensureServiceCert(config ServiceConfig) error {
+	return ensureCert(serviceCertDir(config), config.Name, tlsHosts(config))
+}
+
+// ensureCert makes sure dir holds server.crt and server.key, signed by the
+// local CA and valid for hosts, plus a copy of ca.crt. An existing
+// certificate is kept as long as it still fits.
+func ensureCert(dir, name string, hosts []string) error {
+	ca, caKey, err := ensureCA()
+	if err != nil {
+		return fmt.Errorf("could not set up the local CA: %w", err)
+	}
+	certPath, keyPath := filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key")
+	if cert, _, err := loadCertPair(certPath, keyPath); err == nil && certCovers(cert, ca, hosts) {
+		return copyFile(caCertPath(), filepath.Join(dir, "ca.crt"))
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return err
+	}
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{Organization: []string{"Plate local development"}, CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().AddDate(1, 0, 0),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	for _, h := range hosts {
+		if ip := net.ParseIP(h); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, h)
+		}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, key.Public(), caKey)
+	if err != nil {
+		return err
+	}
+	if err := writeCertPair(certPath, keyPath, der, key); err != nil {
+		return err
+	}
+	return copyFile(caCertPath(), filepath.Join(dir, "ca.crt"))
+}
+
+// certCovers reports whether cert was signed by ca, is valid for every host,
+// and stays valid for a while yet.
+func certCovers(cert, ca *x509.Certificate, hosts []string) bool {
+	if cert.CheckSignatureFrom(ca) != nil || time.Until(cert.NotAfter) < certRenewBefore {
+		return false
+	}
+	for _, h := range hosts {
+		if cert.VerifyHostname(h) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// loadCertPair reads a PEM certificate and its PEM private key.
+func loadCertPair(certPath, keyPath string) (*x509.Certificate, crypto.Signer, error) {
+	certPEM, err := os.ReadFile(certPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyPEM, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	certBlock, _ := pem.Decode(certPEM)
+	keyBlock, _ := pem.Decode(keyPEM)
+	if certBlock == nil || keyBlock == nil {
+		return nil, nil, fmt.Errorf("%s or %s isn't PEM encoded", certPath, keyPath)
+	}
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := x509.ParsePKCS8PrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, nil, fmt.Errorf("%s doesn't hold a signing key", keyPath)
+	}
+	return cert, signer, nil
+}
+
+// writeCertPair stores a certificate and its private key as PEM files.
+func writeCertPair(certPath, keyPath string, der []byte, key crypto.Signer) error {
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(certPath), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644); err != nil {
+		return err
+	}
+	return os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0600)
+}
+
+// copyFile copies src to dst.
+func copyFile(src, dst string) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dst, data, 0644)
+}
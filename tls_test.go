@@ -0,0 +1,72 @@
+package main
+
+import (
+	"crypto/x509"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEnsureServiceCert(t *testing.T) {
+	t.Chdir(t.TempDir())
+	svc := ServiceConfig{Type: "postgres", Name: "main-db", Version: "16", Port: 5433, TLS: true}
+
+	if err := ensureServiceCert(svc); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	ca, _, err := loadCertPair(caCertPath(), caKeyPath())
+	if err != nil {
+		t.Fatalf("Expected a CA, got %v", err)
+	}
+	dir := serviceCertDir(svc)
+	cert, _, err := loadCertPair(filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key"))
+	if err != nil {
+		t.Fatalf("Expected a server certificate, got %v", err)
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+	for _, host := range []string{"localhost", "127.0.0.1", "::1", "main-db"} {
+		if _, err := cert.Verify(x509.VerifyOptions{Roots: roots, DNSName: host}); err != nil {
+			t.Errorf("Expected the certificate to be valid for %s, got %v", host, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "ca.crt")); err != nil {
+		t.Errorf("Expected ca.crt next to the server certificate, got %v", err)
+	}
+
+	// A second run keeps the certificate; a new host replaces it.
+	if err := ensureServiceCert(svc); err != nil {
+		t.Fatal(err)
+	}
+	same, _, _ := loadCertPair(filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key"))
+	if same.SerialNumber.Cmp(cert.SerialNumber) != 0 {
+		t.Errorf("Expected the certificate to be reused")
+	}
+	svc.Host = "192.168.106.2"
+	if err := ensureServiceCert(svc); err != nil {
+		t.Fatal(err)
+	}
+	renewed, _, _ := loadCertPair(filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key"))
+	if renewed.VerifyHostname("192.168.106.2") != nil {
+		t.Errorf("Expected the certificate to be reissued for the new host")
+	}
+}
+
+func TestGetDockerRunArgsTLS(t *testing.T) {
+	svc := ServiceConfig{Type: "redis", Name: "cache", Version: "7", Port: 6380, TLS: true}
+
+	connStr, args, err := getDockerRunArgs(svc, "plate-redis-cache")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if connStr != "rediss://localhost:6380" {
+		t.Errorf("Expected a rediss:// URL, got %s", connStr)
+	}
+	joined := strings.Join(args, " ")
+	for _, want := range []string{"--label plate.tls=true", ":/plate-certs:ro", "--entrypoint sh", "redis:7 -c ", "--tls-port 6379"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("Expected args to contain %q, got %v", want, args)
+		}
+	}
+}
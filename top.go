@@ -0,0 +1,173 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// --- PROCESS VIEW ---
+
+// topRefreshInterval is how often the open process view runs docker top.
+const topRefreshInterval = 2 * time.Second
+
+// topColumns are the ps columns asked for; docker top falls back to its
+// default columns when the host's ps doesn't support them.
+const topColumns = "pid,ppid,pcpu,pmem,etime,args"
+
+// topView is the state of the process view.
+type topView struct {
+	viewport    viewport.Model
+	service     string // service whose container is shown
+	containerID string
+	session     int // counts openings, so refreshes of an earlier one stop
+	titles      []string
+	processes   [][]string
+	err         error
+	updated     time.Time // zero until the first result
+}
+
+func newTopView() topView {
+	return topView{viewport: viewport.New(0, 0)}
+}
+
+type topTickMsg struct {
+	session     int
+	containerID string
+}
+
+type topLoadedMsg struct {
+	session   int
+	titles    []string
+	processes [][]string
+	err       error
+}
+
+// topTickCmd schedules the next refresh of the process view.
+func topTickCmd(session int, containerID string) tea.Cmd {
+	return tea.Tick(topRefreshInterval, func(time.Time) tea.Msg { return topTickMsg{session: session, containerID: containerID} })
+}
+
+// containerTopCmd lists the processes running in a container.
+func containerTopCmd(session int, containerID string) tea.Cmd {
+	return func() tea.Msg {
+		output, err := backend.top(containerID)
+		if err != nil {
+			return topLoadedMsg{session: session, err: fmt.Errorf("docker top failed: %v", err)}
+		}
+		titles, processes := parseTop(output)
+		return topLoadedMsg{session: session, titles: titles, processes: processes}
+	}
+}
+
+// parseTop splits docker top output into its column titles and one row per
+// process. The last column is the command line, which may contain spaces.
+func parseTop(output string) ([]string, [][]string) {
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) == "" {
+		return nil, nil
+	}
+	titles := strings.Fields(lines[0])
+	var processes [][]string
+	for _, line := range lines[1:] {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) > len(titles) {
+			last := len(titles) - 1
+			fields = append(fields[:last], strings.Join(fields[last:], " "))
+		}
+		processes = append(processes, fields)
+	}
+	return titles, processes
+}
+
+// cpuColumn returns the index of the %CPU column, or -1.
+func cpuColumn(titles []string) int {
+	for i, t := range titles {
+		if t == "%CPU" || t == "C" {
+			return i
+		}
+	}
+	return -1
+}
+
+// renderTop lays the processes out as a table. Processes using a lot of
+// CPU are highlighted.
+func renderTop(titles []string, processes [][]string) string {
+	widths := make([]int, len(titles))
+	for i, t := range titles {
+		widths[i] = len(t)
+	}
+	for _, p := range processes {
+		for i := 0; i < len(p) && i < len(widths)-1; i++ {
+			widths[i] = max(widths[i], len(p[i]))
+		}
+	}
+	row := func(fields []string) string {
+		cells := make([]string, len(fields))
+		for i, f := range fields {
+			if i < len(fields)-1 {
+				f = fmt.Sprintf("%-*s", widths[i], f)
+			}
+			cells[i] = f
+		}
+		return strings.Join(cells, "  ")
+	}
+
+	cpu := cpuColumn(titles)
+	var b strings.Builder
+	b.WriteString(detailAttrStyle.Render(row(titles)))
+	for _, p := range processes {
+		line := row(p)
+		if cpu >= 0 && cpu < len(p) {
+			if usage, err := strconv.ParseFloat(p[cpu], 64); err == nil {
+				switch {
+				case usage >= 80:
+					line = errorStyle.Render(line)
+				case usage >= 30:
+					line = pendingStyle.Render(line)
+				}
+			}
+		}
+		b.WriteString("\n" + line)
+	}
+	return b.String()
+}
+
+// updateTopView handles keys while the process view is open.
+func (m model) updateTopView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "t", "q", "esc":
+		m.showingTop = false
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.top.viewport, cmd = m.top.viewport.Update(msg)
+	return m, cmd
+}
+
+func (m model) renderTopView() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Processes in " + m.top.service))
+	if !m.top.updated.IsZero() {
+		b.WriteString("  " + detailValStyle.Render(fmt.Sprintf("%d process(es), updated %s", len(m.top.processes), timeDisplay.when(m.top.updated, time.Now()))))
+	}
+	b.WriteString("\n\n")
+	switch {
+	case m.top.err != nil:
+		b.WriteString(errorStyle.Render(m.top.err.Error()))
+	case m.top.updated.IsZero():
+		b.WriteString(fmt.Sprintf("%s Listing processes...", m.spinner.View()))
+	default:
+		b.WriteString(m.top.viewport.View())
+	}
+	b.WriteString("\n\n")
+	b.WriteString(helpStyle.Render(fmt.Sprintf("Refreshes every %s • ↑/↓/pgup/pgdn: scroll • t/q/esc: back", topRefreshInterval)))
+	return docStyle.Render(b.String())
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseTop(t *testing.T) {
+	output := `PID                 PPID                %CPU                %MEM                ELAPSED             COMMAND
+4242                4221                0.3                 1.2                 01:02:03            postgres -c config_file=/etc/postgresql.conf
+4300                4242                95.0                0.1                 00:10               postgres: checkpointer
+`
+	titles, processes := parseTop(output)
+	if strings.Join(titles, ",") != "PID,PPID,%CPU,%MEM,ELAPSED,COMMAND" {
+		t.Errorf("Expected 6 titles, got %v", titles)
+	}
+	if len(processes) != 2 {
+		t.Fatalf("Expected 2 processes, got %d", len(processes))
+	}
+	if got := processes[0][5]; got != "postgres -c config_file=/etc/postgresql.conf" {
+		t.Errorf("Expected the full command line, got %q", got)
+	}
+	if got := processes[1][5]; got != "postgres: checkpointer" {
+		t.Errorf("Expected the full command line, got %q", got)
+	}
+	if cpu := cpuColumn(titles); cpu != 2 {
+		t.Errorf("Expected %%CPU in column 2, got %d", cpu)
+	}
+
+	if titles, processes := parseTop(""); titles != nil || processes != nil {
+		t.Errorf("Expected nothing for empty output, got %v %v", titles, processes)
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// --- ONBOARDING TOUR ---
+
+// tourStep is one page of the first-run tour. Pressing one of its keys
+// performs the usual action and moves the tour on.
+type tourStep struct {
+	text string
+	keys []string
+}
+
+var tourSteps = []tourStep{
+	{text: "Welcome to Plate! Each service in plate.config.json is listed on the left. Use ↑/↓ to select one.", keys: []string{"up", "down", "k", "j"}},
+	{text: "This pane shows the selected service. Plate starts services for you. If one is stopped, press b to boot it.", keys: []string{"b"}},
+	{text: "Once a service is running, press c to copy its connection string into your app's config.", keys: []string{"c"}},
+	{text: "If a database gets into a bad state, press r to reset it: its container and data are deleted and a fresh one is created. Answer y to confirm or n to cancel.", keys: []string{"r"}},
+	{text: "That's the tour! Press h any time to see every key, and q to quit (which stops the services)."},
+}
+
+var tourStyle = lipgloss.NewStyle().
+	Border(lipgloss.RoundedBorder()).
+	BorderForeground(lipgloss.Color("39")).
+	Padding(0, 1).
+	MarginBottom(1)
+
+// tourMarkerPath is the file marking that this user has finished the tour.
+func tourMarkerPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "plate", "tour-done"), nil
+}
+
+// tourDone reports whether the user has finished or skipped the tour.
+// When in doubt, it says yes, so nobody is shown the tour over and over.
+func tourDone() bool {
+	path, err := tourMarkerPath()
+	if err != nil {
+		return true
+	}
+	_, err = os.Stat(path)
+	return !errors.Is(err, fs.ErrNotExist)
+}
+
+// markTourDoneCmd records that the tour was shown.
+func markTourDoneCmd() tea.Cmd {
+	return func() tea.Msg {
+		if path, err := tourMarkerPath(); err == nil && os.MkdirAll(filepath.Dir(path), 0755) == nil {
+			os.WriteFile(path, nil, 0644)
+		}
+		return nil
+	}
+}
+
+// advanceTour moves the tour on if key belongs to the current step, and
+// handles tab (next) and esc (skip).
+func (m model) advanceTour(key string) (model, tea.Cmd) {
+	step := tourSteps[m.tourStep]
+	next := key == "tab"
+	for _, k := range step.keys {
+		next = next || k == key
+	}
+	if key == "esc" || (next && m.tourStep == len(tourSteps)-1) {
+		m.touring = false
+		return m, markTourDoneCmd()
+	}
+	if next {
+		m.tourStep++
+	}
+	return m, nil
+}
+
+// renderTour renders the current tour step.
+func (m model) renderTour(width int) string {
+	var b strings.Builder
+	b.WriteString(detailAttrStyle.Render(fmt.Sprintf("Tour %d/%d", m.tourStep+1, len(tourSteps))))
+	b.WriteString("\n" + tourSteps[m.tourStep].text + "\n")
+	hint := "tab: next • esc: skip tour"
+	if m.tourStep == len(tourSteps)-1 {
+		hint = "tab: finish"
+	}
+	b.WriteString(helpStyle.Render(hint))
+	return tourStyle.Width(width).Render(b.String())
+}
@@ -0,0 +1,30 @@
+package main
+
+import "testing"
+
+func TestAdvanceTour(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	m := model{touring: true}
+
+	m, _ = m.advanceTour("c")
+	if m.tourStep != 0 {
+		t.Errorf("Expected keys of later steps to be ignored, got step %d", m.tourStep)
+	}
+	m, _ = m.advanceTour("down")
+	if m.tourStep != 1 {
+		t.Errorf("Expected the step's key to advance the tour, got step %d", m.tourStep)
+	}
+	m, _ = m.advanceTour("tab")
+	if m.tourStep != 2 {
+		t.Errorf("Expected tab to advance the tour, got step %d", m.tourStep)
+	}
+
+	m, cmd := m.advanceTour("esc")
+	if m.touring || cmd == nil {
+		t.Errorf("Expected esc to end the tour and mark it done")
+	}
+	cmd()
+	if !tourDone() {
+		t.Errorf("Expected the tour to be marked done")
+	}
+}
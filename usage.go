@@ -0,0 +1,238 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// --- LOCAL USAGE STATS ---
+
+// usageFileName is the file inside stateDirName that usage events are
+// appended to. It never leaves the machine.
+const usageFileName = "usage.jsonl"
+
+// Usage event kinds.
+const (
+	usageStart = "start" // a service was started and became ready
+	usageError = "error" // a service ended up in the error state
+)
+
+// usageEvent is one line of usage.jsonl.
+type usageEvent struct {
+	Time    time.Time `json:"time"`
+	Service string    `json:"service"`
+	Type    string    `json:"type"`
+	Event   string    `json:"event"`
+	ReadyMs int64     `json:"readyMs,omitempty"` // time from starting to running
+	Error   string    `json:"error,omitempty"`
+}
+
+// recordUsage appends events to the usage file. Stats are a convenience,
+// so failures are ignored.
+func recordUsage(events []usageEvent) {
+	if len(events) == 0 {
+		return
+	}
+	if err := os.MkdirAll(stateDir, 0755); err != nil {
+		return
+	}
+	f, err := os.OpenFile(filepath.Join(stateDir, usageFileName), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	enc := json.NewEncoder(f)
+	for _, ev := range events {
+		enc.Encode(ev)
+	}
+}
+
+// usageTracker turns status transitions into usage events.
+type usageTracker struct {
+	starting map[string]time.Time // service -> when its start began
+}
+
+func newUsageTracker() *usageTracker {
+	return &usageTracker{starting: map[string]time.Time{}}
+}
+
+// observe compares the service list before and after an update.
+func (u *usageTracker) observe(before, after []list.Item, now time.Time) []usageEvent {
+	var events []usageEvent
+	for i, itm := range after {
+		if i >= len(before) {
+			continue
+		}
+		prev, cur := before[i].(item), itm.(item)
+		name := cur.config.Name
+		if cur.status == statusDownloading || cur.status == statusBuilding || cur.status == statusStarting {
+			// Not only on transitions: Init starts services before any update.
+			if _, ok := u.starting[name]; !ok {
+				u.starting[name] = now
+			}
+		}
+		if prev.status == cur.status {
+			continue
+		}
+		switch cur.status {
+		case statusRunning:
+			if began, ok := u.starting[name]; ok {
+				delete(u.starting, name)
+				events = append(events, usageEvent{Time: now, Service: name, Type: cur.config.Type, Event: usageStart, ReadyMs: now.Sub(began).Milliseconds()})
+			}
+		case statusError, statusCrashLooping:
+			delete(u.starting, name)
+			events = append(events, usageEvent{Time: now, Service: name, Type: cur.config.Type, Event: usageError, Error: cur.statusText})
+		}
+	}
+	return events
+}
+
+// readUsage reads the usage events newer than since.
+func readUsage(since time.Time) ([]usageEvent, error) {
+	f, err := os.Open(filepath.Join(stateDir, usageFileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var events []usageEvent
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var ev usageEvent
+		if json.Unmarshal(scanner.Bytes(), &ev) != nil {
+			continue // a torn write from a crash; skip it
+		}
+		if !ev.Time.Before(since) {
+			events = append(events, ev)
+		}
+	}
+	return events, scanner.Err()
+}
+
+// serviceUsage aggregates the events of one service.
+type serviceUsage struct {
+	name    string
+	starts  int
+	readyMs int64 // sum, for the average
+	errors  int
+}
+
+// errorCount is how often an error message occurred.
+type errorCount struct {
+	message string
+	count   int
+}
+
+// usageReport is the aggregated stats.
+type usageReport struct {
+	services []serviceUsage
+	errors   []errorCount   // most common first
+	weekly   map[string]int // ISO week -> starts
+	weeks    []string       // sorted keys of weekly
+}
+
+// summarizeUsage aggregates usage events.
+func summarizeUsage(events []usageEvent) usageReport {
+	byService := map[string]*serviceUsage{}
+	var order []string
+	errs := map[string]int{}
+	report := usageReport{weekly: map[string]int{}}
+	for _, ev := range events {
+		s, ok := byService[ev.Service]
+		if !ok {
+			s = &serviceUsage{name: ev.Service}
+			byService[ev.Service] = s
+			order = append(order, ev.Service)
+		}
+		switch ev.Event {
+		case usageStart:
+			s.starts++
+			s.readyMs += ev.ReadyMs
+			year, week := ev.Time.ISOWeek()
+			report.weekly[fmt.Sprintf("%d-W%02d", year, week)]++
+		case usageError:
+			s.errors++
+			errs[fmt.Sprintf("%s: %s", ev.Service, ev.Error)]++
+		}
+	}
+	for _, name := range order {
+		report.services = append(report.services, *byService[name])
+	}
+	for msg, n := range errs {
+		report.errors = append(report.errors, errorCount{message: msg, count: n})
+	}
+	sort.Slice(report.errors, func(i, j int) bool {
+		if report.errors[i].count != report.errors[j].count {
+			return report.errors[i].count > report.errors[j].count
+		}
+		return report.errors[i].message < report.errors[j].message
+	})
+	for week := range report.weekly {
+		report.weeks = append(report.weeks, week)
+	}
+	sort.Strings(report.weeks)
+	return report
+}
+
+// renderUsageReport prints a report.
+func renderUsageReport(r usageReport, out io.Writer) {
+	if len(r.services) == 0 {
+		fmt.Fprintln(out, "No usage recorded yet. Stats are collected while the TUI runs.")
+		return
+	}
+	fmt.Fprintln(out, detailAttrStyle.Render(fmt.Sprintf("%-20s %8s %16s %8s", "SERVICE", "STARTS", "AVG TIME-TO-READY", "ERRORS")))
+	for _, s := range r.services {
+		avg := "-"
+		if s.starts > 0 {
+			avg = (time.Duration(s.readyMs/int64(s.starts)) * time.Millisecond).Round(100 * time.Millisecond).String()
+		}
+		fmt.Fprintf(out, "%-20s %8d %16s %8d\n", s.name, s.starts, avg, s.errors)
+	}
+
+	if len(r.errors) > 0 {
+		fmt.Fprintln(out, "\n"+detailAttrStyle.Render("Most common errors"))
+		for i, e := range r.errors {
+			if i == 5 {
+				break
+			}
+			fmt.Fprintf(out, "%4d× %s\n", e.count, errorStyle.Render(e.message))
+		}
+	}
+
+	if len(r.weeks) > 0 {
+		fmt.Fprintln(out, "\n"+detailAttrStyle.Render("Starts per week"))
+		for _, week := range r.weeks {
+			fmt.Fprintf(out, "%s %s %d\n", week, successStyle.Render(strings.Repeat("▇", min(r.weekly[week], 40))), r.weekly[week])
+		}
+	}
+}
+
+// handleStatsCmd reports local usage stats.
+func handleStatsCmd(args []string) {
+	fs := flag.NewFlagSet("stats", flag.ExitOnError)
+	days := fs.Int("days", 90, "only include the last N days")
+	fs.Parse(args)
+
+	events, err := readUsage(time.Now().AddDate(0, 0, -*days))
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	renderUsageReport(summarizeUsage(events), os.Stdout)
+}
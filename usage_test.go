@@ -0,0 +1,60 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+func TestUsageTracker(t *testing.T) {
+	db := ServiceConfig{Type: "postgres", Name: "main-db"}
+	at := func(s status) []list.Item {
+		return []list.Item{item{config: db, status: s, statusText: "port is already allocated"}}
+	}
+	start := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+
+	u := newUsageTracker()
+	u.observe(at(statusChecking), at(statusDownloading), start)
+	u.observe(at(statusDownloading), at(statusStarting), start.Add(20*time.Second))
+	events := u.observe(at(statusStarting), at(statusRunning), start.Add(25*time.Second))
+	if len(events) != 1 || events[0].Event != usageStart || events[0].ReadyMs != 25000 {
+		t.Fatalf("Expected one start event with 25s to ready, got %+v", events)
+	}
+
+	// Finding an already running container isn't a start.
+	if events := u.observe(at(statusChecking), at(statusRunning), start); len(events) != 0 {
+		t.Errorf("Expected no events for an already running container, got %+v", events)
+	}
+
+	events = u.observe(at(statusStarting), at(statusError), start)
+	if len(events) != 1 || events[0].Event != usageError || events[0].Error != "port is already allocated" {
+		t.Errorf("Expected an error event, got %+v", events)
+	}
+}
+
+func TestSummarizeUsage(t *testing.T) {
+	monday := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	events := []usageEvent{
+		{Time: monday, Service: "main-db", Event: usageStart, ReadyMs: 2000},
+		{Time: monday.AddDate(0, 0, 1), Service: "main-db", Event: usageStart, ReadyMs: 4000},
+		{Time: monday.AddDate(0, 0, 7), Service: "cache", Event: usageStart, ReadyMs: 500},
+		{Time: monday, Service: "main-db", Event: usageError, Error: "boom"},
+		{Time: monday, Service: "cache", Event: usageError, Error: "oops"},
+		{Time: monday, Service: "main-db", Event: usageError, Error: "boom"},
+	}
+	r := summarizeUsage(events)
+
+	if len(r.services) != 2 || r.services[0].name != "main-db" {
+		t.Fatalf("Expected main-db and cache, got %+v", r.services)
+	}
+	if db := r.services[0]; db.starts != 2 || db.readyMs/int64(db.starts) != 3000 || db.errors != 2 {
+		t.Errorf("Expected 2 starts, 3s average, and 2 errors for main-db, got %+v", db)
+	}
+	if len(r.errors) != 2 || r.errors[0].message != "main-db: boom" || r.errors[0].count != 2 {
+		t.Errorf("Expected main-db: boom to be the most common error, got %+v", r.errors)
+	}
+	if len(r.weeks) != 2 || r.weekly["2026-W02"] != 2 || r.weekly["2026-W03"] != 1 {
+		t.Errorf("Expected starts in two weeks, got %v", r.weekly)
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+)
+
+// --- VERSION ---
+
+// Release builds set these with -ldflags, e.g.
+//
+//	go build -ldflags "-X main.buildVersion=v1.4.0 -X main.buildCommit=$(git rev-parse HEAD) -X main.buildDate=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// Builds without them fall back to what the go toolchain records.
+var (
+	buildVersion string
+	buildCommit  string
+	buildDate    string
+)
+
+// buildInfo describes the Plate binary, for `plate version` and for
+// anything that needs to tell releases apart.
+type buildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	Modified  bool   `json:"modified,omitempty"` // built from a checkout with uncommitted changes
+	Date      string `json:"date,omitempty"`     // the build date, or the commit's for go install builds
+	GoVersion string `json:"goVersion"`
+	Platform  string `json:"platform"`
+}
+
+// currentBuild returns the build metadata, preferring what -ldflags set over
+// the module version and VCS settings the go toolchain embeds.
+func currentBuild() buildInfo {
+	info := buildInfo{
+		Version:   "(devel)",
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		if bi.Main.Version != "" {
+			info.Version = bi.Main.Version
+		}
+		for _, s := range bi.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				info.Commit = s.Value
+			case "vcs.time":
+				info.Date = s.Value
+			case "vcs.modified":
+				info.Modified = s.Value == "true"
+			}
+		}
+	}
+	if buildVersion != "" {
+		info.Version = buildVersion
+	}
+	if buildCommit != "" {
+		info.Commit, info.Modified = buildCommit, false
+	}
+	if buildDate != "" {
+		info.Date = buildDate
+	}
+	return info
+}
+
+// plateVersion returns the version Plate was built as.
+func plateVersion() string {
+	return currentBuild().Version
+}
+
+// String renders the build metadata for people, one detail per line.
+func (b buildInfo) String() string {
+	s := "plate " + b.Version + "\n"
+	if b.Commit != "" {
+		commit := b.Commit
+		if b.Modified {
+			commit += " (modified)"
+		}
+		s += fmt.Sprintf("commit:   %s\n", commit)
+	}
+	if b.Date != "" {
+		s += fmt.Sprintf("built:    %s\n", b.Date)
+	}
+	s += fmt.Sprintf("go:       %s\n", b.GoVersion)
+	s += fmt.Sprintf("platform: %s\n", b.Platform)
+	return s
+}
+
+// handleVersionCmd prints the build metadata.
+func handleVersionCmd(args []string) {
+	fs := flag.NewFlagSet("version", flag.ExitOnError)
+	asJSON := fs.Bool("json", false, "print the build metadata as JSON")
+	fs.Parse(args)
+	if *asJSON {
+		data, _ := json.MarshalIndent(currentBuild(), "", "  ")
+		fmt.Println(string(data))
+		return
+	}
+	fmt.Print(currentBuild().String())
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestCurrentBuildLdflags(t *testing.T) {
+	orig := [3]string{buildVersion, buildCommit, buildDate}
+	t.Cleanup(func() { buildVersion, buildCommit, buildDate = orig[0], orig[1], orig[2] })
+	buildVersion, buildCommit, buildDate = "v1.4.0", "3f2a9c1", "2026-10-17T09:12:03Z"
+
+	b := currentBuild()
+	if b.Version != "v1.4.0" || b.Commit != "3f2a9c1" || b.Modified || b.Date != "2026-10-17T09:12:03Z" {
+		t.Errorf("Expected the -ldflags values to win, got %+v", b)
+	}
+	if b.GoVersion != runtime.Version() {
+		t.Errorf("Expected Go version %s, got %s", runtime.Version(), b.GoVersion)
+	}
+	if plateVersion() != "v1.4.0" {
+		t.Errorf("Expected plateVersion to follow the build, got %s", plateVersion())
+	}
+}
+
+func TestBuildInfoString(t *testing.T) {
+	b := buildInfo{Version: "v1.4.0", Commit: "3f2a9c1", Modified: true, GoVersion: "go1.24.2", Platform: "darwin/arm64"}
+	got := b.String()
+	for _, want := range []string{"plate v1.4.0\n", "commit:   3f2a9c1 (modified)\n", "platform: darwin/arm64\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected %q in:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "built:") {
+		t.Errorf("Expected no build date line without a date, got:\n%s", got)
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// --- SCHEMA WATCH ---
+
+// readyTimeout is how long to wait for a reset database.
+const readyTimeout = 60 * time.Second
+
+// WatchConfig makes Plate reset a database when its schema files change.
+type WatchConfig struct {
+	// Path is the migrations or schema directory (or file) to watch.
+	Path string `json:"path"`
+	// Migrate is a shell command run after the reset, once the database
+	// accepts connections. It gets the service's PLATE_<NAME>_URL.
+	Migrate string `json:"migrate,omitempty"`
+	// Auto resets without asking first.
+	Auto bool `json:"auto,omitempty"`
+}
+
+// watchSum hashes the names, sizes and modification times below path. It
+// returns "" if path doesn't exist.
+func watchSum(path string) string {
+	h := sha256.New()
+	found := false
+	filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		found = true
+		fmt.Fprintf(h, "%s|%d|%d\n", p, info.Size(), info.ModTime().UnixNano())
+		return nil
+	})
+	if !found {
+		return ""
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+type watchMsg struct {
+	service string
+	sum     string
+}
+
+// watchCmd re-hashes the watched path after interval.
+func watchCmd(service, path string, interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return watchMsg{service: service, sum: watchSum(path)}
+	})
+}
+
+type migrationDoneMsg struct {
+	service string
+	err     error
+}
+
+// waitForService polls the service's health command every poll until it
+// succeeds.
+func waitForService(config ServiceConfig, containerID string, poll time.Duration) error {
+	spec, err := getServiceSpec(config)
+	if err != nil || spec.HealthCmd == "" {
+		return err
+	}
+	deadline := time.Now().Add(readyTimeout)
+	for {
+		if _, err := backend.exec(containerID, nil, "sh", "-c", spec.HealthCmd); err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return &notReadyError{service: config.Name, after: readyTimeout}
+		}
+		time.Sleep(poll)
+	}
+}
+
+// migrateCmd waits for the reset service, creates its databases and
+// extensions, and runs its migrate command.
+func migrateCmd(service string, config ServiceConfig, containerID string, poll time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		if err := waitForService(config, containerID, poll); err != nil {
+			return migrationDoneMsg{service: service, err: err}
+		}
+		if err := prepareService(config, containerID, poll); err != nil {
+			return migrationDoneMsg{service: service, err: err}
+		}
+		connStr, _ := getConnectionString(config)
+		cmd := exec.Command("sh", "-c", config.Watch.Migrate)
+		cmd.Env = append(os.Environ(), connectionEnv(config, connStr)...)
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			if out := strings.TrimSpace(string(output)); out != "" {
+				err = fmt.Errorf("%s", lastLine(out))
+			}
+		}
+		return migrationDoneMsg{service: service, err: err}
+	}
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatchSum(t *testing.T) {
+	dir := t.TempDir()
+	if got := watchSum(filepath.Join(dir, "missing")); got != "" {
+		t.Errorf("Expected empty sum for a missing path, got %q", got)
+	}
+
+	schema := filepath.Join(dir, "001_init.sql")
+	if err := os.WriteFile(schema, []byte("CREATE TABLE a (id int);"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	first := watchSum(dir)
+	if first == "" || watchSum(dir) != first {
+		t.Fatalf("Expected a stable sum, got %q", first)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "002_more.sql"), []byte("ALTER TABLE a ADD b int;"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	second := watchSum(dir)
+	if second == first {
+		t.Errorf("Expected a new file to change the sum")
+	}
+
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(schema, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if watchSum(dir) == second {
+		t.Errorf("Expected an edited file to change the sum")
+	}
+}